@@ -0,0 +1,143 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// redacted replaces secrets in formatted and logged values.
+const redacted = "[REDACTED]"
+
+// Secret is a sensitive value, such as an access key. It is redacted when
+// formatted using the fmt package or logged using log/slog. Convert it to
+// string to use the value.
+type Secret string
+
+// String implements [fmt.Stringer].
+func (Secret) String() string {
+	return redacted
+}
+
+// GoString implements [fmt.GoStringer].
+func (Secret) GoString() string {
+	return redacted
+}
+
+// LogValue implements [slog.LogValuer].
+func (Secret) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// AccessKeyInfo describes an access key of the organisation.
+type AccessKeyInfo struct {
+	// KeyID is the ID of the access key.
+	KeyID string `json:"key_id,omitempty"`
+	// Name is the name given to the access key when it was created.
+	Name string `json:"name,omitempty"`
+	// CreatedAt is the date and time the access key was created.
+	CreatedAt Time `json:"created_at"`
+	// LastUsedAt is the date and time the access key was last used to create
+	// a token. It is zero if the access key was never used.
+	LastUsedAt Time `json:"last_used_at"`
+}
+
+// AccessKeySecret is a newly created access key.
+type AccessKeySecret struct {
+	AccessKeyInfo `json:",inline"`
+	// AccessKey is the access key to create tokens with. It is only returned
+	// once, so store it securely right away.
+	AccessKey Secret `json:"access_key"`
+}
+
+// AccessKeysList represents the access keys of an organisation.
+type AccessKeysList struct {
+	AccessKeys []AccessKeyInfo `json:"access_keys,omitempty"`
+}
+
+// AccessKeyCreateRequest represents the request to create an access key.
+type AccessKeyCreateRequest struct {
+	Name string `json:"name"`
+}
+
+// AccessKeys lists the access keys of the organisation.
+func (c *Client) AccessKeys(ctx context.Context) ([]AccessKeyInfo, error) {
+	req, err := c.newEndpointRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("/fsa/v1.1/auth/%s/accesskeys", url.PathEscape(c.oid)),
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[AccessKeysList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data.AccessKeys, nil
+}
+
+// AccessKeyCreate creates an access key named name. The access key is only
+// returned once; see [Secret] for how it is protected from being logged.
+func (c *Client) AccessKeyCreate(ctx context.Context, name string) (AccessKeySecret, error) {
+	if strings.TrimSpace(name) == "" {
+		return AccessKeySecret{}, errors.New("access key create: empty name")
+	}
+
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/fsa/v1.1/auth/%s/accesskeys", url.PathEscape(c.oid)),
+		nil,
+		AccessKeyCreateRequest{Name: name},
+	)
+	if err != nil {
+		return AccessKeySecret{}, err
+	}
+
+	var result Response[AccessKeySecret]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return AccessKeySecret{}, err
+	}
+
+	return result.Data, nil
+}
+
+// AccessKeyRevoke revokes the access key keyID. Tokens can no longer be
+// created using it, so this requires [WithDestructiveOps].
+func (c *Client) AccessKeyRevoke(ctx context.Context, keyID string) error {
+	if !c.destructiveOps {
+		return fmt.Errorf("access key revoke: %w", ErrDestructiveOpsDisabled)
+	}
+	if keyID == "" {
+		return errors.New("access key revoke: empty key ID")
+	}
+
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"/fsa/v1.1/auth/%s/accesskeys/%s",
+			url.PathEscape(c.oid),
+			url.PathEscape(keyID),
+		),
+		nil,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	var result Response[json.RawMessage]
+	_, err = c.doJSON(req, &result)
+	return err
+}
@@ -0,0 +1,189 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testAccessKey = "ak_live_5f0c8e2b9d"
+
+// loggingTracer logs all operations and their outcome to logger.
+type loggingTracer struct {
+	logger *slog.Logger
+}
+
+func (t loggingTracer) Start(ctx context.Context, op Operation) (context.Context, Span) {
+	t.logger.Info("start", "op", op)
+	return ctx, loggingSpan(t)
+}
+
+type loggingSpan loggingTracer
+
+func (s loggingSpan) RateLimited(retryAfter time.Time) {
+	s.logger.Info("rate limited", "retry_after", retryAfter)
+}
+
+func (s loggingSpan) End(statusCode int, err error) {
+	s.logger.Info("end", "status", statusCode, "err", err)
+}
+
+// accessKeysHandler serves the access key endpoints of test-org.
+func accessKeysHandler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /fsa/v1.1/auth/test-org/accesskeys",
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"success": true,
+				"data": map[string]any{"access_keys": []map[string]any{
+					{
+						"key_id":       "k1",
+						"name":         "sync",
+						"created_at":   "2024-01-05 08:00:00",
+						"last_used_at": "2024-06-01T12:00:00Z",
+					},
+					{
+						"key_id":       "k2",
+						"name":         "unused",
+						"created_at":   "2024-02-01 09:30:00",
+						"last_used_at": nil,
+					},
+				}},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"POST /fsa/v1.1/auth/test-org/accesskeys",
+		func(w http.ResponseWriter, r *http.Request) {
+			var body AccessKeyCreateRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Name != "rotation" {
+				t.Errorf("request body = %+v, %v", body, err)
+			}
+			writeJSON(w, http.StatusOK, map[string]any{
+				"success": true,
+				"data": map[string]any{
+					"key_id":     "k3",
+					"name":       body.Name,
+					"access_key": testAccessKey,
+				},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"DELETE /fsa/v1.1/auth/test-org/accesskeys/{id}",
+		func(w http.ResponseWriter, r *http.Request) {
+			if id := r.PathValue("id"); id != "k1" && id != "k/1" {
+				writeJSON(w, http.StatusNotFound, map[string]any{"message": "unknown key " + id})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]any{"success": true})
+		},
+	)
+
+	return mux
+}
+
+func TestClient_AccessKeys(t *testing.T) {
+	client, _ := newTestClient(t, accessKeysHandler(t))
+
+	keys, err := client.AccessKeys(context.Background())
+	if err != nil {
+		t.Fatalf("AccessKeys() error = %v", err)
+	}
+
+	if len(keys) != 2 {
+		t.Fatalf("AccessKeys() = %+v, want 2 keys", keys)
+	}
+	wantLastUsed := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	if keys[0].KeyID != "k1" || !keys[0].LastUsedAt.Equal(wantLastUsed) {
+		t.Errorf("keys[0] = %+v, want k1 last used at %v", keys[0], wantLastUsed)
+	}
+	if keys[1].CreatedAt.IsZero() || !keys[1].LastUsedAt.IsZero() {
+		t.Errorf("keys[1] = %+v, want creation time without last use", keys[1])
+	}
+}
+
+func TestClient_AccessKeyCreate_SecretNotLogged(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	client, _ := newTestClient(
+		t,
+		accessKeysHandler(t),
+		WithTracer(loggingTracer{logger: logger}),
+		WithWarningHandler(func(message string) { logger.Warn(message) }),
+	)
+
+	secret, err := client.AccessKeyCreate(context.Background(), "rotation")
+	if err != nil {
+		t.Fatalf("AccessKeyCreate() error = %v", err)
+	}
+	if string(secret.AccessKey) != testAccessKey || secret.KeyID != "k3" {
+		t.Errorf("AccessKeyCreate() = %+v, want key k3 with secret", secret)
+	}
+
+	logger.Info("created", "key", secret, "secret", secret.AccessKey)
+	fmt.Fprintf(
+		&logs,
+		"%v %+v %#v %s %q",
+		secret,
+		secret,
+		secret,
+		secret.AccessKey,
+		secret.AccessKey,
+	)
+
+	if !strings.Contains(logs.String(), "k3") {
+		t.Errorf("logs = %q, want key ID", logs.String())
+	}
+	if strings.Contains(logs.String(), testAccessKey) {
+		t.Errorf("logs = %q, contain secret", logs.String())
+	}
+}
+
+func TestClient_AccessKeyCreate_EmptyName(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	}))
+
+	if _, err := client.AccessKeyCreate(context.Background(), " "); err == nil {
+		t.Error("AccessKeyCreate() error = nil, want error")
+	}
+}
+
+func TestClient_AccessKeyRevoke(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		keyID   string
+		wantErr error
+	}{
+		{name: "revoked", opts: []ClientOption{WithDestructiveOps()}, keyID: "k1"},
+		{name: "escaped key ID", opts: []ClientOption{WithDestructiveOps()}, keyID: "k/1"},
+		{name: "destructive ops disabled", keyID: "k1", wantErr: ErrDestructiveOpsDisabled},
+		{
+			name:    "unknown key",
+			opts:    []ClientOption{WithDestructiveOps()},
+			keyID:   "k9",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, accessKeysHandler(t), tt.opts...)
+
+			err := client.AccessKeyRevoke(context.Background(), tt.keyID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("AccessKeyRevoke() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
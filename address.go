@@ -0,0 +1,268 @@
+package fairgate
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// AddressIssueCode identifies the kind of an [AddressIssue].
+type AddressIssueCode string
+
+const (
+	// AddressIssueCityMissing reports an address without city.
+	AddressIssueCityMissing AddressIssueCode = "city_missing"
+	// AddressIssuePostalCodeMissing reports an address without postal code.
+	AddressIssuePostalCodeMissing AddressIssueCode = "postal_code_missing"
+	// AddressIssuePostalCodeFormat reports a postal code not matching the
+	// format of the country, such as a Swiss postal code with spaces.
+	AddressIssuePostalCodeFormat AddressIssueCode = "postal_code_format"
+	// AddressIssuePostalCodeInStreet reports a street containing a postal code
+	// and city.
+	AddressIssuePostalCodeInStreet AddressIssueCode = "postal_code_in_street"
+	// AddressIssueStreetMissing reports an address with neither street nor
+	// post office box.
+	AddressIssueStreetMissing AddressIssueCode = "street_missing"
+	// AddressIssueStreetNumberMissing reports a street without house number.
+	AddressIssueStreetNumberMissing AddressIssueCode = "street_number_missing"
+	// AddressIssueStreetWithPostOfficeBox reports an address with both street
+	// and post office box.
+	AddressIssueStreetWithPostOfficeBox AddressIssueCode = "street_with_post_office_box"
+)
+
+// AddressIssue is a structural problem of an [Address].
+type AddressIssue struct {
+	// Field is the JSON name of the affected field, such as "postale_code".
+	// For issues reported by [Contact.AddressIssues], it is prefixed with the
+	// address, such as "corr_address.postale_code".
+	Field string
+	// Code identifies the kind of issue.
+	Code AddressIssueCode
+	// Message describes the issue.
+	Message string
+}
+
+var (
+	// swissPostalCode matches a Swiss postal code.
+	swissPostalCode = regexp.MustCompile(`^[1-9][0-9]{3}$`)
+	// swissPostalCodeAndCity matches a Swiss postal code followed by a city.
+	swissPostalCodeAndCity = regexp.MustCompile(`(^|[\s,])[1-9][0-9]{3}\s+\p{L}`)
+)
+
+// swissCountries are the lowercase names and codes of Switzerland used in
+// addresses.
+var swissCountries = map[string]bool{
+	"ch":          true,
+	"che":         true,
+	"schweiz":     true,
+	"suisse":      true,
+	"svizzera":    true,
+	"svizra":      true,
+	"switzerland": true,
+}
+
+// Validate checks the structure of the address, such as the format of Swiss
+// postal codes, without validating that the address exists. It returns no
+// issues for an empty address.
+func (a Address) Validate() []AddressIssue {
+	if a.isEmpty() {
+		return nil
+	}
+
+	street := strings.TrimSpace(a.Street)
+	postOfficeBox := strings.TrimSpace(a.PostOfficeBox)
+	postalCode := strings.TrimSpace(a.PostaleCode)
+	swiss := swissCountries[strings.ToLower(strings.TrimSpace(a.Country))]
+
+	var issues []AddressIssue
+	switch {
+	case street == "" && postOfficeBox == "":
+		issues = append(issues, AddressIssue{
+			Field:   "street",
+			Code:    AddressIssueStreetMissing,
+			Message: "neither street nor post office box is set",
+		})
+	case street != "" && postOfficeBox != "":
+		issues = append(issues, AddressIssue{
+			Field:   "post_office_box",
+			Code:    AddressIssueStreetWithPostOfficeBox,
+			Message: "street and post office box are both set",
+		})
+	}
+
+	if street != "" {
+		if swiss && swissPostalCodeAndCity.MatchString(street) {
+			issues = append(issues, AddressIssue{
+				Field:   "street",
+				Code:    AddressIssuePostalCodeInStreet,
+				Message: fmt.Sprintf("street %q contains a postal code and city", street),
+			})
+		} else if !strings.ContainsFunc(street, unicode.IsDigit) {
+			issues = append(issues, AddressIssue{
+				Field:   "street",
+				Code:    AddressIssueStreetNumberMissing,
+				Message: fmt.Sprintf("street %q has no house number", street),
+			})
+		}
+	}
+
+	switch {
+	case postalCode == "":
+		issues = append(issues, AddressIssue{
+			Field:   "postale_code",
+			Code:    AddressIssuePostalCodeMissing,
+			Message: "postal code is empty",
+		})
+	case swiss && !swissPostalCode.MatchString(postalCode):
+		issues = append(issues, AddressIssue{
+			Field:   "postale_code",
+			Code:    AddressIssuePostalCodeFormat,
+			Message: fmt.Sprintf("Swiss postal code %q is not four digits", a.PostaleCode),
+		})
+	}
+
+	if strings.TrimSpace(a.City) == "" {
+		issues = append(issues, AddressIssue{
+			Field:   "city",
+			Code:    AddressIssueCityMissing,
+			Message: "city is empty",
+		})
+	}
+
+	return issues
+}
+
+// isEmpty reports whether no location field of the address is set.
+func (a Address) isEmpty() bool {
+	for _, field := range []string{a.Street, a.City, a.PostaleCode, a.PostOfficeBox} {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddressIssues validates the correspondence and invoice addresses of the
+// contact, see [Address.Validate].
+func (c Contact) AddressIssues() []AddressIssue {
+	var issues []AddressIssue
+	for _, address := range []struct {
+		field   string
+		address Address
+	}{
+		{"corr_address", c.CorrAddress},
+		{"invoice_address", c.InvoiceAddress},
+	} {
+		for _, issue := range address.address.Validate() {
+			issue.Field = address.field + "." + issue.Field
+			issues = append(issues, issue)
+		}
+	}
+
+	return issues
+}
+
+// ContactAddressIssues holds the address issues of a contact.
+type ContactAddressIssues struct {
+	ContactID int
+	Issues    []AddressIssue
+}
+
+// ContactsAddressIssues returns an iterator over the contacts with address
+// issues, see [Contact.AddressIssues]. Contacts without issues are skipped.
+func (c *Client) ContactsAddressIssues(
+	ctx context.Context,
+	opts ...IterOption,
+) iter.Seq2[ContactAddressIssues, error] {
+	return func(yield func(ContactAddressIssues, error) bool) {
+		for contact, err := range c.ContactsIter(ctx, opts...) {
+			if err != nil {
+				yield(ContactAddressIssues{}, err)
+				return
+			}
+
+			issues := contact.AddressIssues()
+			if len(issues) == 0 {
+				continue
+			}
+			if !yield(ContactAddressIssues{
+				ContactID: contact.Basefields.ContactID.Int(),
+				Issues:    issues,
+			}, nil) {
+				return
+			}
+		}
+	}
+}
+
+// AddressSource identifies the address a field of an effective address was
+// taken from, see [Contact.EffectiveInvoiceAddress].
+type AddressSource string
+
+const (
+	// AddressSourceCorrespondence is the correspondence address.
+	AddressSourceCorrespondence AddressSource = "corr_address"
+	// AddressSourceInvoice is the invoice address.
+	AddressSourceInvoice AddressSource = "invoice_address"
+)
+
+// EffectiveInvoiceAddress returns the address to send invoices to. Fairgate
+// stores only the fields of the invoice address differing from the
+// correspondence address, so each empty field of the invoice address falls
+// back to the correspondence address. The provenance maps the JSON names of the
+// fields set, such as "street", to their source.
+func (c Contact) EffectiveInvoiceAddress() (Address, map[string]AddressSource) {
+	return mergeAddresses(
+		c.InvoiceAddress, AddressSourceInvoice,
+		c.CorrAddress, AddressSourceCorrespondence,
+	)
+}
+
+// EffectiveCorrespondenceAddress returns the correspondence address, with each
+// empty field falling back to the invoice address, like
+// [Contact.EffectiveInvoiceAddress].
+func (c Contact) EffectiveCorrespondenceAddress() (Address, map[string]AddressSource) {
+	return mergeAddresses(
+		c.CorrAddress, AddressSourceCorrespondence,
+		c.InvoiceAddress, AddressSourceInvoice,
+	)
+}
+
+// mergeAddresses returns primary with its empty fields set from fallback, and
+// the source of each field set.
+func mergeAddresses(
+	primary Address,
+	primarySource AddressSource,
+	fallback Address,
+	fallbackSource AddressSource,
+) (Address, map[string]AddressSource) {
+	merged := primary
+	provenance := map[string]AddressSource{}
+	for _, field := range []struct {
+		name     string
+		merged   *string
+		fallback string
+	}{
+		{"alias_name", &merged.AliasName, fallback.AliasName},
+		{"street", &merged.Street, fallback.Street},
+		{"city", &merged.City, fallback.City},
+		{"state", &merged.State, fallback.State},
+		{"postale_code", &merged.PostaleCode, fallback.PostaleCode},
+		{"country", &merged.Country, fallback.Country},
+		{"post_office_box", &merged.PostOfficeBox, fallback.PostOfficeBox},
+	} {
+		switch {
+		case strings.TrimSpace(*field.merged) != "":
+			provenance[field.name] = primarySource
+		case strings.TrimSpace(field.fallback) != "":
+			*field.merged = field.fallback
+			provenance[field.name] = fallbackSource
+		}
+	}
+
+	return merged, provenance
+}
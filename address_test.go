@@ -0,0 +1,323 @@
+package fairgate
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestAddress_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		address Address
+		want    []AddressIssueCode
+	}{
+		{name: "empty"},
+		{
+			name: "valid swiss",
+			address: Address{
+				Street:      "Bahnhofstrasse 12",
+				PostaleCode: "8001",
+				City:        "Zürich",
+				Country:     "CH",
+			},
+		},
+		{
+			name: "valid post office box",
+			address: Address{
+				PostOfficeBox: "Postfach 123",
+				PostaleCode:   "3001",
+				City:          "Bern",
+				Country:       "Schweiz",
+			},
+		},
+		{
+			name: "postal code with space",
+			address: Address{
+				Street:      "Rue du Marché 4",
+				PostaleCode: "1 204",
+				City:        "Genève",
+				Country:     "Suisse",
+			},
+			want: []AddressIssueCode{AddressIssuePostalCodeFormat},
+		},
+		{
+			name: "postal code with country prefix",
+			address: Address{
+				Street:      "Via Nassa 5",
+				PostaleCode: "CH-6900",
+				City:        "Lugano",
+				Country:     "ch",
+			},
+			want: []AddressIssueCode{AddressIssuePostalCodeFormat},
+		},
+		{
+			name: "five digit swiss postal code",
+			address: Address{
+				Street:      "Dorfstrasse 1",
+				PostaleCode: "80010",
+				City:        "Zürich",
+				Country:     "Switzerland",
+			},
+			want: []AddressIssueCode{AddressIssuePostalCodeFormat},
+		},
+		{
+			name: "postal code and city in street",
+			address: Address{
+				Street:  "Seestrasse 7, 8700 Küsnacht",
+				City:    "Küsnacht",
+				Country: "CH",
+			},
+			want: []AddressIssueCode{AddressIssuePostalCodeInStreet, AddressIssuePostalCodeMissing},
+		},
+		{
+			name: "street without number",
+			address: Address{
+				Street:      "Dorfplatz",
+				PostaleCode: "3920",
+				City:        "Zermatt",
+				Country:     "CH",
+			},
+			want: []AddressIssueCode{AddressIssueStreetNumberMissing},
+		},
+		{
+			name: "street and post office box",
+			address: Address{
+				Street:        "Industriestrasse 3",
+				PostOfficeBox: "Postfach",
+				PostaleCode:   "6300",
+				City:          "Zug",
+				Country:       "CH",
+			},
+			want: []AddressIssueCode{AddressIssueStreetWithPostOfficeBox},
+		},
+		{
+			name: "missing city",
+			address: Address{
+				Street:      "Hauptgasse 20",
+				PostaleCode: "4500",
+				City:        "  ",
+				Country:     "CH",
+			},
+			want: []AddressIssueCode{AddressIssueCityMissing},
+		},
+		{
+			name: "only city",
+			address: Address{
+				City:    "Basel",
+				Country: "CH",
+			},
+			want: []AddressIssueCode{
+				AddressIssueStreetMissing,
+				AddressIssuePostalCodeMissing,
+			},
+		},
+		{
+			name: "foreign postal code",
+			address: Address{
+				Street:      "Unter den Linden 77",
+				PostaleCode: "10117",
+				City:        "Berlin",
+				Country:     "DE",
+			},
+		},
+		{
+			name: "foreign postal code in street",
+			address: Address{
+				Street:      "Marienplatz 8, 80331 München",
+				PostaleCode: "80331",
+				City:        "München",
+				Country:     "Deutschland",
+			},
+		},
+		{
+			name: "whitespace around postal code",
+			address: Address{
+				Street:      "Bärenplatz 2",
+				PostaleCode: " 3011 ",
+				City:        "Bern",
+				Country:     " CH ",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := tt.address.Validate()
+
+			var codes []AddressIssueCode
+			for _, issue := range issues {
+				codes = append(codes, issue.Code)
+				if issue.Field == "" || issue.Message == "" {
+					t.Errorf("issue %+v lacks field or message", issue)
+				}
+			}
+			if !slices.Equal(codes, tt.want) {
+				t.Errorf("Validate() = %v, want %v", codes, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ContactsAddressIssues(t *testing.T) {
+	valid := Address{
+		Street:      "Bahnhofstrasse 12",
+		PostaleCode: "8001",
+		City:        "Zürich",
+		Country:     "CH",
+	}
+	invalid := Address{
+		Street:      "Bahnhofstrasse 12",
+		PostaleCode: "80 01",
+		City:        "Zürich",
+		Country:     "CH",
+	}
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{TotalRecords: 3, TotalPages: 1, PageNo: 1},
+				Contacts: []Contact{
+					{Basefields: ContactBasefields{ContactID: 1}, CorrAddress: valid},
+					{Basefields: ContactBasefields{ContactID: 2}, InvoiceAddress: invalid},
+					{Basefields: ContactBasefields{ContactID: 3}},
+				},
+			},
+		})
+	}))
+
+	var got []ContactAddressIssues
+	for issues, err := range client.ContactsAddressIssues(context.Background()) {
+		if err != nil {
+			t.Fatalf("ContactsAddressIssues() error = %v", err)
+		}
+		got = append(got, issues)
+	}
+
+	if len(got) != 1 || got[0].ContactID != 2 {
+		t.Fatalf("got %+v, want issues of contact 2", got)
+	}
+	if len(got[0].Issues) != 1 || got[0].Issues[0].Field != "invoice_address.postale_code" {
+		t.Errorf("got issues %+v, want invoice_address.postale_code", got[0].Issues)
+	}
+}
+
+func TestContact_EffectiveAddresses(t *testing.T) {
+	corr := Address{
+		Street:      "Bahnhofstrasse 12",
+		PostaleCode: "8001",
+		City:        "Zürich",
+		Country:     "CH",
+	}
+	invoice := Address{
+		AliasName:   "Buchhaltung",
+		Street:      "Rue du Marché 4",
+		PostaleCode: "1204",
+		City:        "Genève",
+		Country:     "CH",
+	}
+
+	tests := []struct {
+		name           string
+		contact        Contact
+		wantInvoice    Address
+		wantInvoiceSrc map[string]AddressSource
+		wantCorr       Address
+		wantCorrSrc    map[string]AddressSource
+	}{
+		{
+			name:        "full override",
+			contact:     Contact{CorrAddress: corr, InvoiceAddress: invoice},
+			wantInvoice: invoice,
+			wantInvoiceSrc: map[string]AddressSource{
+				"alias_name":   AddressSourceInvoice,
+				"street":       AddressSourceInvoice,
+				"postale_code": AddressSourceInvoice,
+				"city":         AddressSourceInvoice,
+				"country":      AddressSourceInvoice,
+			},
+			wantCorr: Address{
+				AliasName:   "Buchhaltung",
+				Street:      "Bahnhofstrasse 12",
+				PostaleCode: "8001",
+				City:        "Zürich",
+				Country:     "CH",
+			},
+			wantCorrSrc: map[string]AddressSource{
+				"alias_name":   AddressSourceInvoice,
+				"street":       AddressSourceCorrespondence,
+				"postale_code": AddressSourceCorrespondence,
+				"city":         AddressSourceCorrespondence,
+				"country":      AddressSourceCorrespondence,
+			},
+		},
+		{
+			name: "partial override",
+			contact: Contact{
+				CorrAddress:    corr,
+				InvoiceAddress: Address{Street: "Löwenstrasse 1", City: " "},
+			},
+			wantInvoice: Address{
+				Street:      "Löwenstrasse 1",
+				PostaleCode: "8001",
+				City:        "Zürich",
+				Country:     "CH",
+			},
+			wantInvoiceSrc: map[string]AddressSource{
+				"street":       AddressSourceInvoice,
+				"postale_code": AddressSourceCorrespondence,
+				"city":         AddressSourceCorrespondence,
+				"country":      AddressSourceCorrespondence,
+			},
+			wantCorr: corr,
+			wantCorrSrc: map[string]AddressSource{
+				"street":       AddressSourceCorrespondence,
+				"postale_code": AddressSourceCorrespondence,
+				"city":         AddressSourceCorrespondence,
+				"country":      AddressSourceCorrespondence,
+			},
+		},
+		{
+			name:        "empty invoice address",
+			contact:     Contact{CorrAddress: corr},
+			wantInvoice: corr,
+			wantInvoiceSrc: map[string]AddressSource{
+				"street":       AddressSourceCorrespondence,
+				"postale_code": AddressSourceCorrespondence,
+				"city":         AddressSourceCorrespondence,
+				"country":      AddressSourceCorrespondence,
+			},
+			wantCorr: corr,
+			wantCorrSrc: map[string]AddressSource{
+				"street":       AddressSourceCorrespondence,
+				"postale_code": AddressSourceCorrespondence,
+				"city":         AddressSourceCorrespondence,
+				"country":      AddressSourceCorrespondence,
+			},
+		},
+		{
+			name:           "no addresses",
+			wantInvoiceSrc: map[string]AddressSource{},
+			wantCorrSrc:    map[string]AddressSource{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, src := tt.contact.EffectiveInvoiceAddress()
+			if got != tt.wantInvoice || !maps.Equal(src, tt.wantInvoiceSrc) {
+				t.Errorf("EffectiveInvoiceAddress() = %+v, %v, want %+v, %v",
+					got, src, tt.wantInvoice, tt.wantInvoiceSrc)
+			}
+
+			got, src = tt.contact.EffectiveCorrespondenceAddress()
+			if got != tt.wantCorr || !maps.Equal(src, tt.wantCorrSrc) {
+				t.Errorf("EffectiveCorrespondenceAddress() = %+v, %v, want %+v, %v",
+					got, src, tt.wantCorr, tt.wantCorrSrc)
+			}
+		})
+	}
+}
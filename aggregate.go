@@ -0,0 +1,33 @@
+package fairgate
+
+import "context"
+
+// AggregateContacts counts the distinct values extract returns for all
+// contacts, e.g. to list the membership types or languages present in the
+// data. Empty values are not counted. As the FSA has no endpoints returning
+// distinct values, all contacts are fetched; use [WithProgress] to report the
+// progress and cancel ctx to abort. If extract panics, a [CallbackPanicError]
+// is returned.
+func AggregateContacts(
+	ctx context.Context,
+	c *Client,
+	extract func(Contact) string,
+	opts ...IterOption,
+) (map[string]int, error) {
+	counts := map[string]int{}
+	for contact, err := range c.ContactsIter(ctx, opts...) {
+		if err != nil {
+			return nil, err
+		}
+
+		var value string
+		if err := callSafely("extract", func() { value = extract(contact) }); err != nil {
+			return nil, err
+		}
+		if value != "" {
+			counts[value]++
+		}
+	}
+
+	return counts, nil
+}
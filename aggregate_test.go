@@ -0,0 +1,114 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// contactsHandler serves total contacts in pages of up to pageLimit contacts.
+// The language of a contact is derived from its ID.
+func contactsHandler(total int) http.Handler {
+	languages := []Language{LanguageDE, LanguageFR, LanguageIT, ""}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		pageLimit, _ := strconv.Atoi(r.URL.Query().Get("pageLimit"))
+
+		var contacts []Contact
+		for id := (pageNo-1)*pageLimit + 1; id <= min(pageNo*pageLimit, total); id++ {
+			contacts = append(contacts, Contact{
+				Basefields: ContactBasefields{ContactID: FlexInt(id)},
+				Communication: Communication{
+					CorrespondenceLanguage: languages[id%len(languages)],
+				},
+			})
+		}
+
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{
+					TotalRecords: FlexInt(total),
+					TotalPages:   FlexInt((total + pageLimit - 1) / pageLimit),
+					PageNo:       FlexInt(pageNo),
+					PageLimit:    FlexInt(pageLimit),
+				},
+				Contacts: contacts,
+			},
+		})
+	})
+}
+
+func contactLanguage(c Contact) string {
+	return string(c.Communication.CorrespondenceLanguage)
+}
+
+func TestAggregateContacts(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		want      map[string]int
+		wantPages int
+	}{
+		{
+			name:      "empty",
+			total:     0,
+			want:      map[string]int{},
+			wantPages: 1,
+		},
+		{
+			name:      "multiple pages",
+			total:     1002,
+			want:      map[string]int{"de": 250, "fr": 251, "it": 251},
+			wantPages: 11,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, contactsHandler(tt.total))
+
+			pages := 0
+			got, err := AggregateContacts(
+				context.Background(),
+				client,
+				contactLanguage,
+				WithProgress(func(Progress) { pages++ }),
+			)
+			if err != nil {
+				t.Fatalf("AggregateContacts() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Errorf("AggregateContacts() = %v, want %v", got, tt.want)
+			}
+			for value, n := range tt.want {
+				if got[value] != n {
+					t.Errorf("count of %q = %d, want %d", value, got[value], n)
+				}
+			}
+			if pages != tt.wantPages {
+				t.Errorf("got %d progress reports, want %d", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestAggregateContacts_Canceled(t *testing.T) {
+	client, _ := newTestClient(t, contactsHandler(1000))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := AggregateContacts(ctx, client, contactLanguage, WithProgress(func(p Progress) {
+		if p.PagesSeen == 2 {
+			cancel()
+		}
+	}))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("AggregateContacts() error = %v, want context.Canceled", err)
+	}
+}
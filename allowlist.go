@@ -0,0 +1,106 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+)
+
+// ErrEndpointNotAllowed is returned for requests to endpoints which are not
+// permitted by [WithAllowedEndpoints].
+var ErrEndpointNotAllowed = errors.New("endpoint not allowed")
+
+// endpointPattern is a parsed pattern of [WithAllowedEndpoints].
+type endpointPattern struct {
+	method   string
+	segments []string
+}
+
+// authEndpoints are allowed regardless of [WithAllowedEndpoints].
+var authEndpoints = []string{
+	"POST /fsa/v1.1/auth/create/*/token",
+	"POST /fsa/v1.1/auth/refresh/*/token",
+}
+
+// WithAllowedEndpoints restricts the client to the endpoints matching one of
+// patterns, e.g. "GET /fsa/v2.0/contact/*/contacts/**". A pattern consists of
+// a method and a path; the method may be omitted or "*" to match any method.
+// Within the path, "*" matches a single segment and "**" matches any number of
+// segments. Requests to other endpoints fail with [ErrEndpointNotAllowed]
+// without a network call.
+//
+// The authentication endpoints are always allowed, so tokens can still be
+// created and refreshed.
+func WithAllowedEndpoints(patterns []string) ClientOption {
+	return func(c *Client) {
+		c.allowedEndpoints = make([]endpointPattern, 0, len(authEndpoints)+len(patterns))
+		for _, pattern := range slices.Concat(authEndpoints, patterns) {
+			c.allowedEndpoints = append(c.allowedEndpoints, parseEndpointPattern(pattern))
+		}
+	}
+}
+
+// parseEndpointPattern parses a pattern of the form "[METHOD] PATH".
+func parseEndpointPattern(pattern string) endpointPattern {
+	method, p, ok := strings.Cut(strings.TrimSpace(pattern), " ")
+	if !ok {
+		method, p = "*", method
+	}
+
+	return endpointPattern{
+		method:   strings.ToUpper(method),
+		segments: splitPath(strings.TrimSpace(p)),
+	}
+}
+
+// checkEndpoint returns an error if the request is not allowed.
+func (c *Client) checkEndpoint(method, p string) error {
+	if c.allowedEndpoints == nil {
+		return nil
+	}
+
+	segments := splitPath(p)
+	for _, pattern := range c.allowedEndpoints {
+		if pattern.method != "*" && pattern.method != method {
+			continue
+		}
+		if matchSegments(pattern.segments, segments) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s %s", ErrEndpointNotAllowed, method, p)
+}
+
+// splitPath splits p into its segments.
+func splitPath(p string) []string {
+	return strings.Split(strings.Trim(p, "/"), "/")
+}
+
+// matchSegments reports whether the path segments match the pattern segments.
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := range len(segments) + 1 {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segments) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], segments[0])
+	if err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], segments[1:])
+}
@@ -0,0 +1,159 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/fsa/v2.0/contact/*/contacts/**", "/fsa/v2.0/contact/org/contacts/extended", true},
+		{"/fsa/v2.0/contact/*/contacts/**", "/fsa/v2.0/contact/org/contacts/1/extended", true},
+		{"/fsa/v2.0/contact/*/contacts/**", "/fsa/v2.0/contact/org/contacts", true},
+		{"/fsa/v2.0/contact/*/contacts/**", "/fsa/v2.0/contact/org/invoices", false},
+		{"/fsa/v2.0/contact/*/contacts", "/fsa/v2.0/contact/org/a/contacts", false},
+		{"/fsa/**/extended", "/fsa/v2.0/contact/org/contacts/extended", true},
+		{"/fsa/**/extended", "/fsa/v2.0/contact/org/contacts/1/merge", false},
+		{"/fsa/v2.*/contact/*", "/fsa/v2.0/contact/org", true},
+		{"/fsa/v2.0", "/fsa/v2.0/contact", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			got := matchSegments(splitPath(tt.pattern), splitPath(tt.path))
+			if got != tt.want {
+				t.Errorf("matchSegments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithAllowedEndpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		call     func(*Client) error
+		wantErr  bool
+	}{
+		{
+			name:     "allowed",
+			patterns: []string{"GET /fsa/v2.0/contact/*/contacts/**"},
+			call: func(c *Client) error {
+				_, err := c.Contact(context.Background(), 1)
+				return err
+			},
+		},
+		{
+			name:     "any method",
+			patterns: []string{"/fsa/v2.0/contact/*/contacts/**"},
+			call: func(c *Client) error {
+				_, err := c.Contact(context.Background(), 1)
+				return err
+			},
+		},
+		{
+			name:     "denied method",
+			patterns: []string{"GET /fsa/v2.0/contact/*/contacts/**"},
+			call: func(c *Client) error {
+				return c.ContactUpdate(context.Background(), 1, ContactUpdate{})
+			},
+			wantErr: true,
+		},
+		{
+			name:     "denied path",
+			patterns: []string{"GET /fsa/v2.0/contact/*/contacts/extended"},
+			call: func(c *Client) error {
+				_, err := c.Contact(context.Background(), 1)
+				return err
+			},
+			wantErr: true,
+		},
+		{
+			name:     "nothing allowed",
+			patterns: []string{},
+			call: func(c *Client) error {
+				_, err := c.Contacts(context.Background(), PageParams{})
+				return err
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests.Add(1)
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				}),
+				WithAllowedEndpoints(tt.patterns),
+			)
+
+			err := tt.call(client)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if requests.Load() != 1 {
+					t.Errorf("got %d requests, want 1", requests.Load())
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrEndpointNotAllowed) {
+				t.Errorf("error = %v, want ErrEndpointNotAllowed", err)
+			}
+			if requests.Load() != 0 {
+				t.Errorf("got %d requests, want none", requests.Load())
+			}
+		})
+	}
+}
+
+func TestWithAllowedEndpoints_AuthEndpoints(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"POST /fsa/v1.1/auth/create/{oid}/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Success: true,
+				Data: CreateTokenResponse{
+					Token:        createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+					RefreshToken: "refresh-token",
+				},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+		},
+	)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+		WithAccessKey("access-key"),
+		WithAllowedEndpoints([]string{"GET /fsa/v2.0/contact/*/contacts/*/extended"}),
+	)
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+}
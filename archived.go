@@ -0,0 +1,83 @@
+package fairgate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// IsArchived reports whether the contact is archived.
+func (c Contact) IsArchived() bool {
+	return c.Status == ContactStatusArchived
+}
+
+// archivedContactError is returned for a contact request answered with 404 Not
+// Found or 410 Gone, but still returning the contact with status archived.
+type archivedContactError struct {
+	err  error
+	data json.RawMessage
+}
+
+func (e *archivedContactError) Error() string {
+	return e.err.Error()
+}
+
+func (e *archivedContactError) Unwrap() error {
+	return e.err
+}
+
+// archivedEnvelopeError returns an archivedContactError wrapping err if the
+// envelope of the failed contact request resp holds the archived contact.
+func archivedEnvelopeError(
+	resp *http.Response,
+	envelope *Response[json.RawMessage],
+	err error,
+) error {
+	switch resp.StatusCode {
+	case http.StatusNotFound, http.StatusGone:
+	default:
+		return nil
+	}
+	if _, ok := requestedContactID(resp); !ok || envelope == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+
+	var data struct {
+		Basefields struct {
+			ContactID FlexInt `json:"contact_id"`
+		} `json:"basefields"`
+		Status ContactStatus `json:"status"`
+	}
+	if json.Unmarshal(envelope.Data, &data) != nil || data.Basefields.ContactID == 0 ||
+		data.Status != ContactStatusArchived {
+		return nil
+	}
+
+	return &archivedContactError{err: err, data: envelope.Data}
+}
+
+// archivedContact reports whether the contact request failing with err
+// returned an archived contact, and if so sets it as data of result. The
+// server either answers with 404 Not Found or 410 Gone, or with an
+// unsuccessful envelope, holding the contact with status archived.
+func (c *Client) archivedContact(err error, result *Response[Contact]) bool {
+	var archivedErr *archivedContactError
+	var apiErr *APIError
+	switch {
+	case errors.As(err, &archivedErr):
+		var contact Contact
+		if json.Unmarshal(archivedErr.data, &contact) != nil {
+			return false
+		}
+		result.Data = contact
+	case errors.As(err, &apiErr):
+		if !result.Data.IsArchived() || result.Data.Basefields.ContactID == 0 {
+			return false
+		}
+	default:
+		return false
+	}
+
+	c.prepareDecoded(result)
+	return true
+}
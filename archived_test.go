@@ -0,0 +1,119 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// archivingHandler serves contact 1 as live, contact 2 using archived and
+// answers 404 Not Found for other contacts, as if they were deleted.
+func archivingHandler(archived http.HandlerFunc) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+		func(w http.ResponseWriter, r *http.Request) {
+			id, _ := strconv.Atoi(r.PathValue("id"))
+			switch id {
+			case 1:
+				writeJSON(w, http.StatusOK, Response[Contact]{
+					Success: true,
+					Data: Contact{
+						Basefields: ContactBasefields{ContactID: 1},
+						Status:     ContactStatusActive,
+					},
+				})
+			case 2:
+				archived(w, r)
+			default:
+				writeJSON(w, http.StatusNotFound, Response[any]{
+					Code:    http.StatusNotFound,
+					Message: "Contact not found",
+				})
+			}
+		},
+	)
+
+	return mux
+}
+
+func TestClient_Contact_Archived(t *testing.T) {
+	stub := Contact{
+		Basefields: ContactBasefields{ContactID: 2, FirstName: "Anna"},
+		Status:     ContactStatusArchived,
+	}
+
+	tests := []struct {
+		name     string
+		archived http.HandlerFunc
+	}{
+		{
+			name: "returned",
+			archived: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusOK, Response[Contact]{Success: true, Data: stub})
+			},
+		},
+		{
+			name: "unsuccessful envelope",
+			archived: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusOK, Response[Contact]{
+					Code:    http.StatusOK,
+					Message: "Contact is archived",
+					Data:    stub,
+				})
+			},
+		},
+		{
+			name: "not found with record",
+			archived: func(w http.ResponseWriter, _ *http.Request) {
+				writeJSON(w, http.StatusNotFound, Response[Contact]{
+					Code:    http.StatusNotFound,
+					Message: "Contact is archived",
+					Data:    stub,
+				})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, archivingHandler(tt.archived))
+
+			live, err := client.Contact(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Contact(1) error = %v", err)
+			}
+			if live.Data.IsArchived() {
+				t.Error("live contact is archived")
+			}
+
+			archived, err := client.Contact(context.Background(), 2)
+			if err != nil {
+				t.Fatalf("Contact(2) error = %v", err)
+			}
+			if !archived.Data.IsArchived() || archived.Data.Basefields.FirstName != "Anna" {
+				t.Errorf("Contact(2) = %+v, want archived Anna", archived.Data)
+			}
+
+			if _, err := client.Contact(context.Background(), 3); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Contact(3) error = %v, want %v", err, ErrNotFound)
+			}
+
+			var ids []int
+			var gotErr error
+			for contact, err := range client.ContactsByIDs(context.Background(), []int{1, 2, 3}) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				ids = append(ids, contact.Basefields.ContactID.Int())
+			}
+			if !slices.Equal(ids, []int{1, 2}) || !errors.Is(gotErr, ErrNotFound) {
+				t.Errorf("ContactsByIDs() = %v, %v, want [1 2] and ErrNotFound", ids, gotErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ContactAssignmentRow is a single club assignment of a contact.
+type ContactAssignmentRow struct {
+	// ContactID is the ID of the contact.
+	ContactID int
+	// OrganizationID is the oid of the club.
+	OrganizationID string
+	// Organization is the name of the club.
+	Organization string
+	// IsPrimary reports whether the club is the primary club of the contact.
+	IsPrimary bool
+	// Membership is the membership of the contact in the club.
+	Membership *Membership
+	// Roles are the executive board functions of the contact in the club.
+	Roles []ExecutiveBoard
+}
+
+// FlattenAssignments returns one row per club the contact is assigned to,
+// starting with the primary club. Sub federation assignments are not included.
+// It returns nil if the contact has no club assignments.
+func (c Contact) FlattenAssignments() []ContactAssignmentRow {
+	if c.ClubAssignments == nil {
+		return nil
+	}
+
+	var rows []ContactAssignmentRow
+	if c.ClubAssignments.Primary != nil {
+		rows = append(rows, c.assignmentRow(*c.ClubAssignments.Primary, true))
+	}
+	for _, assignment := range c.ClubAssignments.Secondary {
+		rows = append(rows, c.assignmentRow(assignment, false))
+	}
+
+	return rows
+}
+
+// assignmentRow returns the row of a single club assignment.
+func (c Contact) assignmentRow(assignment ClubAssignment, primary bool) ContactAssignmentRow {
+	return ContactAssignmentRow{
+		ContactID:      c.Basefields.ContactID.Int(),
+		OrganizationID: assignment.OrganizationID,
+		Organization:   assignment.Organization,
+		IsPrimary:      primary,
+		Membership:     assignment.Membership,
+		Roles:          assignment.ExecutiveBoard,
+	}
+}
+
+// ContactAssignmentsIter returns an iterator over the club assignments of all
+// contacts. See [Contact.FlattenAssignments] for details.
+func (c *Client) ContactAssignmentsIter(
+	ctx context.Context,
+	opts ...IterOption,
+) iter.Seq2[ContactAssignmentRow, error] {
+	return flattenAssignments(c.ContactsIter(ctx, opts...))
+}
+
+// flattenAssignments yields the club assignments of the contacts of seq.
+func flattenAssignments(
+	seq iter.Seq2[Contact, error],
+) iter.Seq2[ContactAssignmentRow, error] {
+	return func(yield func(ContactAssignmentRow, error) bool) {
+		for contact, err := range seq {
+			if err != nil {
+				yield(ContactAssignmentRow{}, err)
+				return
+			}
+
+			for _, row := range contact.FlattenAssignments() {
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AssignmentOptions configures a club assignment of [Client.ContactAssignClub].
+type AssignmentOptions struct {
+	// Primary makes the club the primary club of the contact. Otherwise, the
+	// contact is assigned as secondary member.
+	Primary bool
+	// Membership is the membership type of the contact in the club.
+	Membership string
+	// JoiningDate is the date the contact joins the club. If zero, the server
+	// uses the current date.
+	JoiningDate Time
+}
+
+// ClubAssignmentRequest represents the request body to assign a contact to a club.
+type ClubAssignmentRequest struct {
+	OrganizationID string `json:"organization_id"`
+	IsPrimary      bool   `json:"is_primary"`
+	Membership     string `json:"membership,omitempty"`
+	JoiningDate    Time   `json:"joining_date,omitzero"`
+}
+
+// ContactAssignClub assigns the contact to the club organizationID, e.g. to
+// transfer a player. Federations only. Business rule violations, such as a
+// contact already assigned to the club or a closed transfer window, are
+// returned as [*APIError] with field errors.
+func (c *Client) ContactAssignClub(
+	ctx context.Context,
+	contactID int,
+	organizationID string,
+	opts AssignmentOptions,
+) error {
+	if organizationID == "" {
+		return errors.New("contact assign club: empty organization ID")
+	}
+
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/%d/clubs", url.PathEscape(c.oid), contactID),
+		nil,
+		ClubAssignmentRequest{
+			OrganizationID: organizationID,
+			IsPrimary:      opts.Primary,
+			Membership:     opts.Membership,
+			JoiningDate:    opts.JoiningDate,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	var result Response[json.RawMessage]
+	_, err = c.doJSON(req, &result)
+	return err
+}
+
+// ContactUnassignClub removes the assignment of the contact to the club
+// organizationID. This ends the membership in the club, so it requires
+// [WithDestructiveOps]. Federations only.
+func (c *Client) ContactUnassignClub(
+	ctx context.Context,
+	contactID int,
+	organizationID string,
+) error {
+	if !c.destructiveOps {
+		return fmt.Errorf("contact unassign club: %w", ErrDestructiveOpsDisabled)
+	}
+	if organizationID == "" {
+		return errors.New("contact unassign club: empty organization ID")
+	}
+
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodDelete,
+		fmt.Sprintf(
+			"/fsa/v2.0/contact/%s/contacts/%d/clubs/%s",
+			url.PathEscape(c.oid),
+			contactID,
+			url.PathEscape(organizationID),
+		),
+		nil,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	var result Response[json.RawMessage]
+	_, err = c.doJSON(req, &result)
+	return err
+}
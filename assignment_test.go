@@ -0,0 +1,272 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContact_FlattenAssignments(t *testing.T) {
+	membership := &Membership{Membership: "Aktivmitglied"}
+	president := ExecutiveBoard{RoleID: 1, RoleName: "President"}
+	cashier := ExecutiveBoard{RoleID: 2, RoleName: "Cashier"}
+
+	tests := []struct {
+		name    string
+		contact Contact
+		want    []ContactAssignmentRow
+	}{
+		{
+			name:    "no club assignments",
+			contact: Contact{Basefields: ContactBasefields{ContactID: 1}},
+		},
+		{
+			name: "primary only",
+			contact: Contact{
+				Basefields: ContactBasefields{ContactID: 1},
+				ClubAssignments: &ClubAssignments{
+					Primary: &ClubAssignment{
+						OrganizationID: "club-a",
+						Organization:   "Club A",
+						Membership:     membership,
+						ExecutiveBoard: []ExecutiveBoard{president, cashier},
+					},
+				},
+			},
+			want: []ContactAssignmentRow{
+				{
+					ContactID:      1,
+					OrganizationID: "club-a",
+					Organization:   "Club A",
+					IsPrimary:      true,
+					Membership:     membership,
+					Roles:          []ExecutiveBoard{president, cashier},
+				},
+			},
+		},
+		{
+			name: "primary and secondaries",
+			contact: Contact{
+				Basefields: ContactBasefields{ContactID: 2},
+				ClubAssignments: &ClubAssignments{
+					Primary: &ClubAssignment{OrganizationID: "club-a"},
+					Secondary: []ClubAssignment{
+						{OrganizationID: "club-b", ExecutiveBoard: []ExecutiveBoard{cashier}},
+						{OrganizationID: "club-c"},
+					},
+				},
+			},
+			want: []ContactAssignmentRow{
+				{ContactID: 2, OrganizationID: "club-a", IsPrimary: true},
+				{ContactID: 2, OrganizationID: "club-b", Roles: []ExecutiveBoard{cashier}},
+				{ContactID: 2, OrganizationID: "club-c"},
+			},
+		},
+		{
+			name: "secondaries only",
+			contact: Contact{
+				Basefields: ContactBasefields{ContactID: 3},
+				ClubAssignments: &ClubAssignments{
+					Secondary: []ClubAssignment{{OrganizationID: "club-b"}},
+				},
+			},
+			want: []ContactAssignmentRow{{ContactID: 3, OrganizationID: "club-b"}},
+		},
+		{
+			name: "sub federation assignments only",
+			contact: Contact{
+				Basefields:        ContactBasefields{ContactID: 4},
+				SubfedAssignments: []SubFedAssignment{{OrganizationID: "subfed-a"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.contact.FlattenAssignments()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FlattenAssignments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenAssignments(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+	seq := func(yield func(Contact, error) bool) {
+		contacts := []Contact{
+			{Basefields: ContactBasefields{ContactID: 1}},
+			{
+				Basefields: ContactBasefields{ContactID: 2},
+				ClubAssignments: &ClubAssignments{
+					Primary:   &ClubAssignment{OrganizationID: "club-a"},
+					Secondary: []ClubAssignment{{OrganizationID: "club-b"}},
+				},
+			},
+		}
+		for _, contact := range contacts {
+			if !yield(contact, nil) {
+				return
+			}
+		}
+		yield(Contact{}, errFetch)
+	}
+
+	var got []string
+	var gotErr error
+	for row, err := range flattenAssignments(seq) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, row.OrganizationID)
+	}
+
+	if want := []string{"club-a", "club-b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got rows %v, want %v", got, want)
+	}
+	if !errors.Is(gotErr, errFetch) {
+		t.Errorf("got error %v, want %v", gotErr, errFetch)
+	}
+}
+
+func TestClient_ContactAssignClub(t *testing.T) {
+	joining := Time{time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name       string
+		opts       AssignmentOptions
+		handler    func(t *testing.T) http.Handler
+		wantBody   string
+		wantFields []string
+	}{
+		{
+			name: "primary",
+			opts: AssignmentOptions{
+				Primary:     true,
+				Membership:  "Aktivmitglied",
+				JoiningDate: joining,
+			},
+			handler: func(t *testing.T) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				})
+			},
+			wantBody: `{"organization_id":"club-2","is_primary":true,"membership":"Aktivmitglied","joining_date":"2025-07-01T00:00:00Z"}`,
+		},
+		{
+			name: "secondary",
+			handler: func(t *testing.T) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				})
+			},
+			wantBody: `{"organization_id":"club-2","is_primary":false}`,
+		},
+		{
+			name: "transfer window closed",
+			opts: AssignmentOptions{Primary: true, JoiningDate: joining},
+			handler: func(t *testing.T) http.Handler {
+				return statusFixtureHandler(
+					t,
+					http.StatusUnprocessableEntity,
+					"error_club_transfer_window.json",
+				)
+			},
+			wantBody:   `{"organization_id":"club-2","is_primary":true,"joining_date":"2025-07-01T00:00:00Z"}`,
+			wantFields: []string{"joining_date"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			handler := tt.handler(t)
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if r.Method != http.MethodPost ||
+						r.URL.Path != "/fsa/v2.0/contact/test-org/contacts/42/clubs" {
+						t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					}
+					body, _ = io.ReadAll(r.Body)
+					handler.ServeHTTP(w, r)
+				}),
+			)
+
+			err := client.ContactAssignClub(context.Background(), 42, "club-2", tt.opts)
+			if string(body) != tt.wantBody {
+				t.Errorf("body = %s, want %s", body, tt.wantBody)
+			}
+
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Fatalf("ContactAssignClub() error = %v", err)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("ContactAssignClub() error = %v, want *APIError", err)
+			}
+			var fields []string
+			for _, fieldErr := range apiErr.Errors {
+				fields = append(fields, fieldErr.Field)
+			}
+			if !slices.Equal(fields, tt.wantFields) {
+				t.Errorf("error fields = %v, want %v", fields, tt.wantFields)
+			}
+			if !strings.Contains(err.Error(), "transfer window is closed") {
+				t.Errorf("error = %q, want the violated rule", err)
+			}
+		})
+	}
+}
+
+func TestClient_ContactUnassignClub(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		wantErr  error
+		wantSent bool
+	}{
+		{name: "destructive ops disabled", wantErr: ErrDestructiveOpsDisabled},
+		{
+			name:     "destructive ops enabled",
+			opts:     []ClientOption{WithDestructiveOps()},
+			wantSent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := false
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					sent = true
+					if r.Method != http.MethodDelete ||
+						r.URL.EscapedPath() != "/fsa/v2.0/contact/test-org/contacts/42/clubs/club%2F2" {
+						t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+					}
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				}),
+				tt.opts...)
+
+			err := client.ContactUnassignClub(context.Background(), 42, "club/2")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ContactUnassignClub() error = %v, want %v", err, tt.wantErr)
+			}
+			if sent != tt.wantSent {
+				t.Errorf("request sent = %v, want %v", sent, tt.wantSent)
+			}
+		})
+	}
+}
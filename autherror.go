@@ -0,0 +1,81 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrAuthFailed is returned when the API rejects the authentication, e.g.
+	// an invalid access key, refresh token or token. The errors below
+	// additionally report known causes.
+	ErrAuthFailed = errors.New("authentication failed")
+	// ErrAccessKeyRevoked is returned when the access key was revoked.
+	// Configure a new access key.
+	ErrAccessKeyRevoked = errors.New("access key revoked")
+	// ErrAccessKeyExpired is returned when the access key expired.
+	// Configure a new access key.
+	ErrAccessKeyExpired = errors.New("access key expired")
+	// ErrOrganisationSuspended is returned when the organisation is suspended
+	// by Fairgate. Changing the access key doesn't help.
+	ErrOrganisationSuspended = errors.New("organisation suspended")
+)
+
+// authErrors maps the errors reported by the auth endpoints to sentinel
+// errors. An error matches if the envelope has the code and one of its
+// messages contains all patterns, ignoring case.
+var authErrors = []struct {
+	code     int
+	patterns []string
+	err      error
+}{
+	{code: 401, patterns: []string{"access key", "revoked"}, err: ErrAccessKeyRevoked},
+	{code: 401, patterns: []string{"access key", "expired"}, err: ErrAccessKeyExpired},
+	{code: 403, patterns: []string{"organi", "suspended"}, err: ErrOrganisationSuspended},
+}
+
+// authError returns err, reported by an auth endpoint with envelope, wrapped
+// with [ErrAuthFailed] if the API reported an auth failure and with the
+// matching sentinel error. Other errors are returned unchanged.
+func authError[T any](envelope Response[T], err error) error {
+	if isAuthFailure(envelope.Code) && !errors.Is(err, ErrAuthFailed) {
+		err = fmt.Errorf("%w: %w", ErrAuthFailed, err)
+	}
+
+	messages := []string{envelope.Message}
+	for _, e := range envelope.Errors {
+		messages = append(messages, e.Message)
+	}
+
+	for _, authErr := range authErrors {
+		if envelope.Code != authErr.code {
+			continue
+		}
+
+		for _, msg := range messages {
+			if containsAll(strings.ToLower(msg), authErr.patterns) {
+				return fmt.Errorf("%w: %w", authErr.err, err)
+			}
+		}
+	}
+
+	return err
+}
+
+// isAuthFailure reports whether code reports a failed authentication.
+func isAuthFailure(code int) bool {
+	return code == http.StatusUnauthorized || code == http.StatusForbidden
+}
+
+// containsAll reports whether s contains all substrings.
+func containsAll(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+
+	return true
+}
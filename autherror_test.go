@@ -0,0 +1,144 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_AuthErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		resp    Response[any]
+		wantErr error
+		// wantAuthFailed reports whether the error wraps ErrAuthFailed.
+		wantAuthFailed bool
+	}{
+		{
+			name:           "revoked",
+			status:         http.StatusUnauthorized,
+			resp:           Response[any]{Code: 401, Message: "The access key has been revoked"},
+			wantErr:        ErrAccessKeyRevoked,
+			wantAuthFailed: true,
+		},
+		{
+			name:   "expired",
+			status: http.StatusUnauthorized,
+			resp: Response[any]{
+				Code:   401,
+				Errors: []Error{{Field: "access_key", Message: "Access key expired"}},
+			},
+			wantErr:        ErrAccessKeyExpired,
+			wantAuthFailed: true,
+		},
+		{
+			name:           "suspended",
+			status:         http.StatusForbidden,
+			resp:           Response[any]{Code: 403, Message: "Organisation is suspended"},
+			wantErr:        ErrOrganisationSuspended,
+			wantAuthFailed: true,
+		},
+		{
+			name:   "unknown code",
+			status: http.StatusUnauthorized,
+			resp:   Response[any]{Code: 499, Message: "The access key has been revoked"},
+			// The HTTP status still reports the auth failure.
+			wantAuthFailed: true,
+		},
+		{
+			name:           "unknown message",
+			status:         http.StatusUnauthorized,
+			resp:           Response[any]{Code: 401, Message: "invalid credentials"},
+			wantAuthFailed: true,
+		},
+	}
+
+	sentinels := []error{ErrAccessKeyRevoked, ErrAccessKeyExpired, ErrOrganisationSuspended}
+
+	for _, tt := range tests {
+		for _, op := range []string{"create", "refresh"} {
+			t.Run(tt.name+"/"+op, func(t *testing.T) {
+				client, _ := newTestClient(t, http.HandlerFunc(
+					func(w http.ResponseWriter, r *http.Request) {
+						if !strings.Contains(r.URL.Path, "/auth/"+op+"/") {
+							t.Errorf("unexpected path: %s", r.URL.Path)
+						}
+						writeJSON(w, tt.status, tt.resp)
+					},
+				))
+
+				var err error
+				switch op {
+				case "create":
+					err = client.TokenCreate(context.Background(), "access-key")
+				case "refresh":
+					// Let the token expire soon, so it is refreshed.
+					client.auth.claim.ExpiresAt.Time = time.Now().Add(time.Minute)
+					err = client.TokenRefresh(context.Background())
+				}
+
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+				if !strings.Contains(err.Error(), tt.resp.Message) {
+					t.Errorf("error = %q, want original message %q", err, tt.resp.Message)
+				}
+				if got := errors.Is(err, ErrAuthFailed); got != tt.wantAuthFailed {
+					t.Errorf(
+						"errors.Is(%v, ErrAuthFailed) = %v, want %v",
+						err,
+						got,
+						tt.wantAuthFailed,
+					)
+				}
+				for _, sentinel := range sentinels {
+					if got, want := errors.Is(err, sentinel), sentinel == tt.wantErr; got != want {
+						t.Errorf("errors.Is(%v, %v) = %v, want %v", err, sentinel, got, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestClient_AuthErrors_Requests(t *testing.T) {
+	t.Run("no token", func(t *testing.T) {
+		client, _ := newTestClient(
+			t,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Errorf("unexpected request: %s", r.URL.Path)
+			}),
+		)
+		client.auth.token = ""
+
+		_, err := client.Contact(context.Background(), 1)
+		if !errors.Is(err, ErrNoToken) {
+			t.Errorf("Contact() error = %v, want %v", err, ErrNoToken)
+		}
+		if errors.Is(err, ErrNoAccessKey) {
+			t.Errorf("Contact() error = %v, should not be %v", err, ErrNoAccessKey)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		client, _ := newTestClient(
+			t,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(
+					w,
+					http.StatusUnauthorized,
+					Response[any]{Code: 401, Message: "invalid token"},
+				)
+			}),
+		)
+
+		_, err := client.Contact(context.Background(), 1)
+		if !errors.Is(err, ErrAuthFailed) || !errors.Is(err, ErrStatus) {
+			t.Errorf("Contact() error = %v, want %v and %v", err, ErrAuthFailed, ErrStatus)
+		}
+	})
+}
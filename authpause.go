@@ -0,0 +1,59 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// authPauseAttempts is the number of times a page is retried after an
+// authentication failure with [PauseOnAuthFailure].
+const authPauseAttempts = 5
+
+// ErrAuthDuringIteration is returned by iterators such as [Client.ContactsIter]
+// when authentication fails while fetching a page, e.g. as the token can no
+// longer be refreshed. It is wrapped in a [*PageError] along with
+// [ErrAuthFailed]. Restarting the iteration won't help until the credentials
+// are fixed.
+var ErrAuthDuringIteration = errors.New("authentication failed during iteration")
+
+// PauseOnAuthFailure retries fetching a page failing authentication up to 5
+// times, waiting maxWait in total, before the iteration fails with
+// [ErrAuthDuringIteration]. This lets long iterations survive an operator
+// rotating keys concurrently, see [Client.SetAccessKey].
+func PauseOnAuthFailure(maxWait time.Duration) IterOption {
+	return func(c *iterConfig) {
+		c.authPause = max(maxWait, 0)
+	}
+}
+
+// fetchPage fetches the page requested with params. Authentication failures
+// are retried as configured by [PauseOnAuthFailure] and wrapped with
+// [ErrAuthDuringIteration].
+func fetchPage[T any](
+	ctx context.Context,
+	cfg iterConfig,
+	fetch paginatorFunc[T],
+	params PageParams,
+) ([]T, Pagination, error) {
+	items, meta, err := fetch(ctx, params)
+	for attempt := 0; attempt < authPauseAttempts && cfg.authPause > 0; attempt++ {
+		if !errors.Is(err, ErrAuthFailed) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, Pagination{}, ctx.Err()
+		case <-time.After(cfg.authPause / authPauseAttempts):
+		}
+		items, meta, err = fetch(ctx, params)
+	}
+
+	if errors.Is(err, ErrAuthFailed) {
+		return nil, Pagination{}, fmt.Errorf("%w: %w", ErrAuthDuringIteration, err)
+	}
+
+	return items, meta, err
+}
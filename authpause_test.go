@@ -0,0 +1,121 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFailingRefreshClient returns a client for a server with 3 contacts. Its
+// tokens expire within a minute, so they are refreshed before every request.
+// The refreshes following the first one fail failures times.
+func newFailingRefreshClient(t *testing.T, failures int32) *Client {
+	privateKey, publicKey := generateTestKeyPair(t)
+	var refreshes atomic.Int32
+	contacts := contactsHandler(3)
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/auth/refresh/") {
+			contacts.ServeHTTP(w, r)
+			return
+		}
+
+		if n := refreshes.Add(1); n > 1 && n <= 1+failures {
+			writeJSON(w, http.StatusUnauthorized, Response[any]{
+				Code:    http.StatusUnauthorized,
+				Message: "Invalid refresh token",
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+			Success: true,
+			Data: CreateTokenResponse{
+				Token:        createTestToken(t, privateKey, time.Now().Add(time.Minute)),
+				RefreshToken: "refresh",
+			},
+		})
+	})
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+	)
+	err := client.auth.updateToken(CreateTokenResponse{
+		Token:        createTestToken(t, privateKey, time.Now().Add(time.Minute)),
+		RefreshToken: "refresh",
+	})
+	if err != nil {
+		t.Fatalf("failed to set up token: %v", err)
+	}
+
+	return client
+}
+
+func TestIterate_AuthFailureDuringIteration(t *testing.T) {
+	tests := []struct {
+		name      string
+		failures  int32
+		opts      []IterOption
+		wantIDs   int
+		wantError bool
+	}{
+		{name: "fails without pause", failures: 2, wantIDs: 1, wantError: true},
+		{
+			name:     "pause recovers",
+			failures: 2,
+			opts:     []IterOption{PauseOnAuthFailure(50 * time.Millisecond)},
+			wantIDs:  3,
+		},
+		{
+			name:      "pause exhausted",
+			failures:  authPauseAttempts + 1,
+			opts:      []IterOption{PauseOnAuthFailure(50 * time.Millisecond)},
+			wantIDs:   1,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFailingRefreshClient(t, tt.failures)
+
+			var ids int
+			var iterErr error
+			opts := append([]IterOption{WithPageLimit(1)}, tt.opts...)
+			for _, err := range client.ContactsIter(context.Background(), opts...) {
+				if err != nil {
+					iterErr = err
+					break
+				}
+				ids++
+			}
+
+			if ids != tt.wantIDs {
+				t.Errorf("iterated %d contacts, want %d", ids, tt.wantIDs)
+			}
+			if !tt.wantError {
+				if iterErr != nil {
+					t.Fatalf("ContactsIter() error = %v", iterErr)
+				}
+				return
+			}
+
+			if !errors.Is(iterErr, ErrAuthDuringIteration) || !errors.Is(iterErr, ErrAuthFailed) {
+				t.Fatalf("ContactsIter() error = %v, want %v and %v",
+					iterErr, ErrAuthDuringIteration, ErrAuthFailed)
+			}
+			var pageErr *PageError
+			if !errors.As(iterErr, &pageErr) || pageErr.PageNo != 2 {
+				t.Errorf("ContactsIter() error = %v, want *PageError of page 2", iterErr)
+			}
+		})
+	}
+}
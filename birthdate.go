@@ -0,0 +1,85 @@
+package fairgate
+
+import "time"
+
+// AgeGroupRule maps an age range to the label of an age group, such as a
+// license category of a sport federation.
+type AgeGroupRule struct {
+	// Label is the name of the age group, e.g. "U15".
+	Label string
+	// MinAge is the minimum age of the group, inclusive.
+	MinAge int
+	// MaxAge is the maximum age of the group, inclusive. Zero means no
+	// maximum.
+	MaxAge int
+	// ByBirthYear determines the age by the year of birth only, as the age
+	// reached in the calendar year of the reference date. This is the common
+	// convention of Swiss federations, where all members born in the same year
+	// play in the same category. Otherwise, the exact age on the reference
+	// date is used, see [Contact.AgeOn].
+	ByBirthYear bool
+}
+
+// matches reports whether a contact born at birthdate belongs to the group on
+// the date on.
+func (r AgeGroupRule) matches(birthdate, on time.Time) bool {
+	age := ageOn(birthdate, on)
+	if r.ByBirthYear {
+		age = on.Year() - birthdate.Year()
+	}
+
+	return age >= r.MinAge && (r.MaxAge == 0 || age <= r.MaxAge)
+}
+
+// AgeOn returns the age of the contact on the date on, in years. It reports
+// false if the birthdate is unknown or after on. Contacts born on 29 February
+// turn a year older on 1 March in common years.
+func (c Contact) AgeOn(on time.Time) (int, bool) {
+	birthdate := c.Basefields.Birthdate
+	if birthdate.IsZero() || dateAfter(birthdate.Time, on) {
+		return 0, false
+	}
+
+	return ageOn(birthdate.Time, on), true
+}
+
+// AgeGroup returns the label of the first rule the contact matches on the date
+// on. It reports false if the birthdate is unknown or after on, or no rule
+// matches.
+func (c Contact) AgeGroup(rules []AgeGroupRule, on time.Time) (string, bool) {
+	birthdate := c.Basefields.Birthdate
+	if birthdate.IsZero() || dateAfter(birthdate.Time, on) {
+		return "", false
+	}
+
+	for _, rule := range rules {
+		if rule.matches(birthdate.Time, on) {
+			return rule.Label, true
+		}
+	}
+
+	return "", false
+}
+
+// ageOn returns the completed years between the calendar dates birthdate and
+// on, each in its own location.
+func ageOn(birthdate, on time.Time) int {
+	birthYear, birthMonth, birthDay := birthdate.Date()
+	year, month, day := on.Date()
+
+	age := year - birthYear
+	if month < birthMonth || month == birthMonth && day < birthDay {
+		age--
+	}
+
+	return age
+}
+
+// dateAfter reports whether the calendar date of a is after the one of b.
+func dateAfter(a, b time.Time) bool {
+	aYear, aMonth, aDay := a.Date()
+	bYear, bMonth, bDay := b.Date()
+
+	return time.Date(aYear, aMonth, aDay, 0, 0, 0, 0, time.UTC).
+		After(time.Date(bYear, bMonth, bDay, 0, 0, 0, 0, time.UTC))
+}
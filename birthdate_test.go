@@ -0,0 +1,119 @@
+package fairgate
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// bornOn returns a contact born on the date in [APILocation].
+func bornOn(year int, month time.Month, day int) Contact {
+	var c Contact
+	c.Basefields.Birthdate = Time{time.Date(year, month, day, 0, 0, 0, 0, APILocation)}
+	return c
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 12, 0, 0, 0, time.UTC)
+}
+
+func TestContact_AgeOn(t *testing.T) {
+	tests := []struct {
+		name    string
+		contact Contact
+		on      time.Time
+		want    int
+		wantOK  bool
+	}{
+		{"before birthday", bornOn(2010, time.June, 15), date(2024, time.June, 14), 13, true},
+		{"on birthday", bornOn(2010, time.June, 15), date(2024, time.June, 15), 14, true},
+		{"after birthday", bornOn(2010, time.June, 15), date(2024, time.December, 1), 14, true},
+		{"day of birth", bornOn(2010, time.June, 15), date(2010, time.June, 15), 0, true},
+		{
+			"leap day in leap year",
+			bornOn(2012, time.February, 29),
+			date(2024, time.February, 29),
+			12,
+			true,
+		},
+		{
+			"leap day before 1 March",
+			bornOn(2012, time.February, 29),
+			date(2023, time.February, 28),
+			10,
+			true,
+		},
+		{
+			"leap day on 1 March",
+			bornOn(2012, time.February, 29),
+			date(2023, time.March, 1),
+			11,
+			true,
+		},
+		{"missing birthdate", Contact{}, date(2024, time.June, 15), 0, false},
+		{"born later", bornOn(2025, time.January, 1), date(2024, time.June, 15), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.contact.AgeOn(tt.on)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("AgeOn() = %d, %v, want %d, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestContact_AgeGroup(t *testing.T) {
+	byBirthYear := []AgeGroupRule{
+		{Label: "U13", MaxAge: 12, ByBirthYear: true},
+		{Label: "U15", MinAge: 13, MaxAge: 14, ByBirthYear: true},
+		{Label: "Aktive", MinAge: 15, ByBirthYear: true},
+	}
+	byExactAge := []AgeGroupRule{
+		{Label: "U13", MaxAge: 12},
+		{Label: "U15", MinAge: 13, MaxAge: 14},
+		{Label: "Aktive", MinAge: 15},
+	}
+	season := date(2024, time.January, 15)
+
+	tests := []struct {
+		name    string
+		contact Contact
+		rules   []AgeGroupRule
+		want    string
+		wantOK  bool
+	}{
+		// Born in 2011, turning 13 in 2024 but still 12 in January.
+		{"year cutoff", bornOn(2011, time.December, 31), byBirthYear, "U15", true},
+		{"exact age", bornOn(2011, time.December, 31), byExactAge, "U13", true},
+		{"year cutoff upper bound", bornOn(2009, time.January, 1), byBirthYear, "Aktive", true},
+		{"exact age upper bound", bornOn(2009, time.January, 1), byExactAge, "Aktive", true},
+		{"exact age before birthday", bornOn(2009, time.February, 1), byExactAge, "U15", true},
+		// Born on 29 February 2012, 11 on 15 January 2024 and 12 in 2024.
+		{"leap day year cutoff", bornOn(2012, time.February, 29), byBirthYear, "U13", true},
+		{"leap day exact age", bornOn(2012, time.February, 29), byExactAge, "U13", true},
+		{"no matching rule", bornOn(2011, time.June, 1), byBirthYear[2:], "", false},
+		{"missing birthdate", Contact{}, byBirthYear, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.contact.AgeGroup(tt.rules, season)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("AgeGroup() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestContactBasefields_Birthdate(t *testing.T) {
+	var c Contact
+	if err := json.Unmarshal([]byte(`{"basefields":{"birthdate":"2012-02-29"}}`), &c); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got, ok := c.AgeOn(date(2023, time.March, 1)); !ok || got != 11 {
+		t.Errorf("AgeOn() = %d, %v, want 11, true", got, ok)
+	}
+}
@@ -0,0 +1,253 @@
+package fairgate
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// defaultBoardConcurrency is the number of organisations fetched at the same
+// time by [Client.ExecutiveBoardIter] by default.
+const defaultBoardConcurrency = 4
+
+// BoardMember is a contact holding an executive board function in an
+// organisation.
+type BoardMember struct {
+	// Organisation is the organisation the contact is a board member of.
+	Organisation Organisation
+	// Role is the executive board function of the contact.
+	Role ExecutiveBoard
+	// Basefields are the base fields of the contact.
+	Basefields ContactBasefields
+}
+
+// BoardIterOptions configures [Client.ExecutiveBoardIter].
+type BoardIterOptions struct {
+	// RoleIDs only yields board members holding one of these functions. It is
+	// combined with RoleNames.
+	RoleIDs []int
+	// RoleNames only yields board members holding a function with one of these
+	// names, compared case-insensitively, e.g. "Präsident".
+	RoleNames []string
+	// ExcludeSubfederations skips the board members of sub federations, so
+	// only the boards of clubs are yielded.
+	ExcludeSubfederations bool
+	// Concurrency is the number of organisations fetched at the same time.
+	// Defaults to 4.
+	Concurrency int
+	// IterOptions configure listing the organisations, see
+	// [Client.OrganisationsIter]. [ContinueOnError] also limits the number of
+	// organisations skipped because their board fails to be fetched, which is
+	// unlimited by default.
+	IterOptions []IterOption
+}
+
+// matches reports whether members holding role are yielded.
+func (o BoardIterOptions) matches(role ExecutiveBoard) bool {
+	if len(o.RoleIDs) == 0 && len(o.RoleNames) == 0 {
+		return true
+	}
+
+	return slices.Contains(o.RoleIDs, role.RoleID) ||
+		slices.ContainsFunc(o.RoleNames, func(name string) bool {
+			return strings.EqualFold(strings.TrimSpace(name), strings.TrimSpace(role.RoleName))
+		})
+}
+
+// boardAssignment is an executive board function of a contact, as listed by
+// the executive board endpoint.
+type boardAssignment struct {
+	ExecutiveBoard
+	Basefields ContactBasefields `json:"basefields,omitzero"`
+}
+
+// boardList is the executive board of an organisation.
+type boardList struct {
+	Members []boardAssignment `json:"members,omitempty"`
+}
+
+// boardResult is the executive board of an organisation fetched by
+// [Client.ExecutiveBoardIter].
+type boardResult struct {
+	members []BoardMember
+	err     error
+}
+
+// ExecutiveBoardIter returns an iterator over the current executive board
+// members of all organisations affiliated with the federation, e.g. the
+// presidents of all clubs, in the order of [Client.OrganisationsIter].
+// Federations only.
+//
+// The boards are fetched concurrently, at most opts.Concurrency ahead of the
+// consumer. The requests share the rate limiting and the limit set by
+// [WithMaxConcurrentRequests] with all other requests of the client.
+// Organisations whose board fails to be fetched are skipped, and their
+// [*OrganisationError] values are yielded last as a single [IterationError].
+// Pass [ContinueOnError] in opts.IterOptions to limit the number of skipped
+// organisations; ContinueOnError(0) stops the iteration at the first
+// organisation failing with its [*OrganisationError].
+func (c *Client) ExecutiveBoardIter(
+	ctx context.Context,
+	opts BoardIterOptions,
+) iter.Seq2[BoardMember, error] {
+	cfg := newIterConfig(append([]IterOption{ContinueOnError(math.MaxInt)}, opts.IterOptions...))
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBoardConcurrency
+	}
+
+	return func(yield func(BoardMember, error) bool) {
+		var organisations []Organisation
+		for organisation, err := range c.OrganisationsIter(ctx, opts.IterOptions...) {
+			if err != nil {
+				yield(BoardMember{}, err)
+				return
+			}
+			if opts.ExcludeSubfederations && organisation.Type == OrganisationTypeSubfederation {
+				continue
+			}
+			organisations = append(organisations, organisation)
+		}
+
+		results := c.fetchBoards(ctx, organisations, concurrency)
+		defer results.stop()
+
+		var failed []*OrganisationError
+		for i, organisation := range organisations {
+			result, err := results.next(ctx, i)
+			if err != nil {
+				yield(BoardMember{}, err)
+				return
+			}
+			if result.err != nil {
+				err := &OrganisationError{
+					OrganizationID: organisation.OrganizationID,
+					Err:            result.err,
+				}
+				if cfg.maxErrors == 0 || ctx.Err() != nil {
+					yield(BoardMember{}, err)
+					return
+				}
+				failed = append(failed, err)
+				if len(failed) > cfg.maxErrors {
+					yield(BoardMember{}, &IterationError{Organisations: failed, Aborted: true})
+					return
+				}
+				continue
+			}
+
+			for _, member := range result.members {
+				if opts.matches(member.Role) && !yield(member, nil) {
+					return
+				}
+			}
+		}
+		if len(failed) > 0 {
+			yield(BoardMember{}, &IterationError{Organisations: failed})
+		}
+	}
+}
+
+// boardResults holds the executive boards fetched by [Client.fetchBoards].
+type boardResults struct {
+	results []chan boardResult
+	// slots holds a slot per board fetched but not yet consumed.
+	slots chan struct{}
+	stop  func()
+}
+
+// next returns the board of the organisation i once fetched, or the error of
+// ctx once done.
+func (r *boardResults) next(ctx context.Context, i int) (boardResult, error) {
+	select {
+	case result := <-r.results[i]:
+		<-r.slots
+		return result, nil
+	case <-ctx.Done():
+		return boardResult{}, ctx.Err()
+	}
+}
+
+// fetchBoards fetches the executive boards of organisations in order, at most
+// concurrency ahead of the consumer of the results. The fetching goroutines
+// end before stop returns.
+func (c *Client) fetchBoards(
+	ctx context.Context,
+	organisations []Organisation,
+	concurrency int,
+) *boardResults {
+	ctx, cancel := context.WithCancel(ctx)
+	results := &boardResults{
+		results: make([]chan boardResult, len(organisations)),
+		slots:   make(chan struct{}, concurrency),
+	}
+	for i := range results.results {
+		results.results[i] = make(chan boardResult, 1)
+	}
+
+	done := make(chan struct{})
+	results.stop = func() {
+		cancel()
+		<-done
+	}
+
+	go func() {
+		defer close(done)
+
+		var wg sync.WaitGroup
+		defer wg.Wait()
+
+		for i, organisation := range organisations {
+			select {
+			case results.slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			wg.Go(func() {
+				members, err := c.executiveBoard(ctx, organisation)
+				results.results[i] <- boardResult{members: members, err: err}
+			})
+		}
+	}()
+
+	return results
+}
+
+// executiveBoard retrieves the current executive board of organisation.
+func (c *Client) executiveBoard(
+	ctx context.Context,
+	organisation Organisation,
+) ([]BoardMember, error) {
+	path := fmt.Sprintf(
+		"/fsa/v2.0/contact/%s/organizations/%s/executive-board",
+		url.PathEscape(c.oid),
+		url.PathEscape(organisation.OrganizationID),
+	)
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[boardList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	members := make([]BoardMember, 0, len(result.Data.Members))
+	for _, assignment := range result.Data.Members {
+		members = append(members, BoardMember{
+			Organisation: organisation,
+			Role:         assignment.ExecutiveBoard,
+			Basefields:   assignment.Basefields,
+		})
+	}
+
+	return members, nil
+}
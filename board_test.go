@@ -0,0 +1,194 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+// boardHandler serves the organisations of a federation with three clubs and a
+// sub federation, and their executive boards. The board of club-b is
+// forbidden, and the ID of club/c needs escaping. inFlight tracks the boards
+// being fetched, and maxInFlight the highest number at a time.
+func boardHandler(inFlight, maxInFlight *atomic.Int32) http.Handler {
+	organisations := []Organisation{
+		{OrganizationID: "club-a", Organization: "FC A", Type: OrganisationTypeClub},
+		{OrganizationID: "club-b", Organization: "FC B", Type: OrganisationTypeClub},
+		{
+			OrganizationID: "subfed",
+			Organization:   "Regionalverband",
+			Type:           OrganisationTypeSubfederation,
+		},
+		{
+			OrganizationID:       "club/c",
+			Organization:         "FC C",
+			Type:                 OrganisationTypeClub,
+			ParentOrganizationID: "subfed",
+		},
+	}
+	member := func(roleID int, role string, contactID int) boardAssignment {
+		return boardAssignment{
+			ExecutiveBoard: ExecutiveBoard{RoleID: roleID, RoleName: role},
+			Basefields:     ContactBasefields{ContactID: FlexInt(contactID)},
+		}
+	}
+	boards := map[string][]boardAssignment{
+		"club-a": {member(1, "Präsident", 11), member(2, "Kassier", 12)},
+		"club/c": {member(1, "Präsident", 31)},
+		"subfed": {member(1, "Präsident", 41)},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/organizations",
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, Response[OrganisationsList]{
+				Success: true,
+				Data: OrganisationsList{
+					Pagination:    Pagination{TotalRecords: FlexInt(len(organisations)), PageNo: 1},
+					Organisations: organisations,
+				},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/organizations/{id}/executive-board",
+		func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				highest := maxInFlight.Load()
+				if n <= highest || maxInFlight.CompareAndSwap(highest, n) {
+					break
+				}
+			}
+
+			board, ok := boards[r.PathValue("id")]
+			if !ok {
+				writeJSON(w, http.StatusForbidden, Response[any]{Message: "forbidden"})
+				return
+			}
+			writeJSON(w, http.StatusOK, Response[boardList]{
+				Success: true,
+				Data:    boardList{Members: board},
+			})
+		},
+	)
+
+	return mux
+}
+
+func TestClient_ExecutiveBoardIter(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        BoardIterOptions
+		wantMembers []int
+		wantErr     bool
+		wantFailed  []string
+	}{
+		{
+			name:        "continues by default",
+			opts:        BoardIterOptions{Concurrency: 2},
+			wantMembers: []int{11, 12, 41, 31},
+			wantErr:     true,
+			wantFailed:  []string{"club-b"},
+		},
+		{
+			name: "stops at forbidden club",
+			opts: BoardIterOptions{
+				Concurrency: 2,
+				IterOptions: []IterOption{ContinueOnError(0)},
+			},
+			wantErr: true,
+			// The members of clubs before the forbidden one are yielded.
+			wantMembers: []int{11, 12},
+			wantFailed:  []string{"club-b"},
+		},
+		{
+			name: "continue on error",
+			opts: BoardIterOptions{
+				Concurrency: 2,
+				IterOptions: []IterOption{ContinueOnError(1)},
+			},
+			wantMembers: []int{11, 12, 41, 31},
+			wantErr:     true,
+			wantFailed:  []string{"club-b"},
+		},
+		{
+			name: "presidents of clubs",
+			opts: BoardIterOptions{
+				RoleNames:             []string{"präsident"},
+				ExcludeSubfederations: true,
+				Concurrency:           1,
+				IterOptions:           []IterOption{ContinueOnError(1)},
+			},
+			wantMembers: []int{11, 31},
+			wantErr:     true,
+			wantFailed:  []string{"club-b"},
+		},
+		{
+			name: "role ID",
+			opts: BoardIterOptions{
+				RoleIDs:     []int{2},
+				IterOptions: []IterOption{ContinueOnError(1)},
+			},
+			wantMembers: []int{12},
+			wantErr:     true,
+			wantFailed:  []string{"club-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var inFlight, maxInFlight atomic.Int32
+			client, _ := newTestClient(t, boardHandler(&inFlight, &maxInFlight))
+
+			var members []int
+			var gotErr error
+			for member, err := range client.ExecutiveBoardIter(context.Background(), tt.opts) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				members = append(members, member.Basefields.ContactID.Int())
+			}
+
+			if !slices.Equal(members, tt.wantMembers) {
+				t.Errorf("ExecutiveBoardIter() members = %v, want %v", members, tt.wantMembers)
+			}
+			if (gotErr != nil) != tt.wantErr || gotErr != nil && !errors.Is(gotErr, ErrForbidden) {
+				t.Fatalf(
+					"ExecutiveBoardIter() error = %v, want ErrForbidden: %v",
+					gotErr,
+					tt.wantErr,
+				)
+			}
+
+			var failed []string
+			var iterErr *IterationError
+			var orgErr *OrganisationError
+			switch {
+			case errors.As(gotErr, &iterErr):
+				for _, err := range iterErr.Organisations {
+					failed = append(failed, err.OrganizationID)
+				}
+			case errors.As(gotErr, &orgErr):
+				failed = append(failed, orgErr.OrganizationID)
+			}
+			if !slices.Equal(failed, tt.wantFailed) {
+				t.Errorf("failed organisations = %v, want %v", failed, tt.wantFailed)
+			}
+
+			concurrency := int32(tt.opts.Concurrency)
+			if concurrency == 0 {
+				concurrency = defaultBoardConcurrency
+			}
+			if got := maxInFlight.Load(); got > concurrency {
+				t.Errorf("fetched %d boards at a time, want at most %d", got, concurrency)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// maxDrainSize limits how much of an unread response body is discarded before
+// closing, so the connection can be reused.
+const maxDrainSize = 64 << 10
+
+// closeBody drains and closes a response body. Bodies with more unread data
+// than maxDrainSize are closed without draining, dropping the connection.
+func closeBody(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+
+	_, _ = io.CopyN(io.Discard, body, maxDrainSize)
+	_ = body.Close()
+}
+
+// contextBody is a response body that is closed when its context is done.
+//
+// Response bodies handed out by [Client.do] are wrapped, so a body abandoned by
+// its consumer, e.g. an iterator whose yield returned false, doesn't pin a
+// connection beyond the lifetime of the request context. Consumers must still
+// close the body as soon as they are done.
+//
+// Once the context is done, Read returns the error of the context, so decoding
+// a large response stops promptly with [context.Canceled] or
+// [context.DeadlineExceeded] instead of a generic read error.
+type contextBody struct {
+	ctx  context.Context
+	body io.ReadCloser
+	stop func() bool
+
+	once sync.Once
+	err  error
+}
+
+// newContextBody returns body, closing it once ctx is done.
+func newContextBody(ctx context.Context, body io.ReadCloser) io.ReadCloser {
+	b := &contextBody{ctx: ctx, body: body}
+	b.stop = context.AfterFunc(ctx, func() {
+		b.close(false)
+	})
+
+	return b
+}
+
+// Read implements [io.Reader].
+func (b *contextBody) Read(p []byte) (int, error) {
+	if err := b.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := b.body.Read(p)
+	if err != nil && err != io.EOF {
+		// The body was closed as the context is done.
+		if ctxErr := b.ctx.Err(); ctxErr != nil {
+			return n, ctxErr
+		}
+	}
+
+	return n, err
+}
+
+// Close implements [io.Closer]. It drains the body to allow reusing the
+// connection.
+func (b *contextBody) Close() error {
+	b.stop()
+	return b.close(true)
+}
+
+// close closes the body once, draining it first if drain is set.
+func (b *contextBody) close(drain bool) error {
+	b.once.Do(func() {
+		if drain {
+			_, _ = io.CopyN(io.Discard, b.body, maxDrainSize)
+		}
+		b.err = b.body.Close()
+	})
+
+	return b.err
+}
+
+// bytesBody is a request body of known length.
+type bytesBody struct {
+	*bytes.Reader
+}
+
+// Close implements [io.Closer].
+func (bytesBody) Close() error {
+	return nil
+}
+
+// setBody sets data as the body of req. GetBody returns a fresh reader of the
+// same length, so the body can be resent on retries.
+func setBody(req *http.Request, data []byte) {
+	req.ContentLength = int64(len(data))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return bytesBody{bytes.NewReader(data)}, nil
+	}
+	req.Body, _ = req.GetBody()
+}
+
+// readerBody is a request body of size bytes read from a seekable reader.
+type readerBody struct {
+	io.Reader
+	size int64
+}
+
+// Close implements [io.Closer].
+func (readerBody) Close() error {
+	return nil
+}
+
+// Len returns the length of the body.
+func (b readerBody) Len() int {
+	return int(b.size)
+}
+
+// setReaderBody sets size bytes of r, starting at its current offset, as the
+// body of req. GetBody seeks back to that offset, so the body can be resent on
+// retries.
+func setReaderBody(req *http.Request, r io.ReadSeeker, size int64) error {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("seek body: %w", err)
+	}
+
+	req.ContentLength = size
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := r.Seek(start, io.SeekStart); err != nil {
+			return nil, fmt.Errorf("seek body: %w", err)
+		}
+		return readerBody{io.LimitReader(r, size), size}, nil
+	}
+	req.Body, err = req.GetBody()
+
+	return err
+}
@@ -0,0 +1,390 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// connTracker tracks the connection states of a test server.
+type connTracker struct {
+	mu     sync.Mutex
+	states map[net.Conn]http.ConnState
+}
+
+// newTrackedTestClient returns a client talking to a test server backed by
+// handler, and the tracker of the server's connections.
+func newTrackedTestClient(t *testing.T, handler http.Handler) (*Client, *connTracker) {
+	t.Helper()
+
+	conns := &connTracker{states: map[net.Conn]http.ConnState{}}
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.ConnState = conns.track
+	server.Start()
+	t.Cleanup(server.Close)
+
+	return newTestClientForServer(t, server), conns
+}
+
+func (c *connTracker) track(conn net.Conn, state http.ConnState) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.states[conn] = state
+}
+
+// count returns the number of connections seen and how many are active.
+func (c *connTracker) count() (total, active int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, state := range c.states {
+		if state == http.StateActive || state == http.StateNew {
+			active++
+		}
+	}
+	return len(c.states), active
+}
+
+// waitIdle waits until no connection is active.
+func (c *connTracker) waitIdle(t *testing.T) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, active := c.count(); active == 0 {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("connections still active after iteration")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestClient_ContactsIter_EarlyBreakReleasesConnection(t *testing.T) {
+	client, conns := newTrackedTestClient(
+		t,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+			writeJSON(w, http.StatusOK, Response[ContactsList]{
+				Success: true,
+				Data: ContactsList{
+					Pagination: Pagination{
+						TotalRecords: 300,
+						TotalPages:   3,
+						PageNo:       FlexInt(pageNo),
+					},
+					Contacts: []Contact{
+						{Basefields: ContactBasefields{ContactID: FlexInt(pageNo*2 - 1)}},
+						{Basefields: ContactBasefields{ContactID: FlexInt(pageNo * 2)}},
+					},
+				},
+			})
+		}),
+	)
+
+	for range 3 {
+		for _, err := range client.ContactsIter(context.Background()) {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			break
+		}
+	}
+
+	conns.waitIdle(t)
+	if total, _ := conns.count(); total != 1 {
+		t.Errorf("server saw %d connections, want the connection to be reused", total)
+	}
+}
+
+func TestClient_doJSON_UnreadBodyReusesConnection(t *testing.T) {
+	client, conns := newTrackedTestClient(
+		t,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Trailing whitespace is not consumed by the JSON decoder.
+			_, _ = io.WriteString(w, `{"success":true}`+strings.Repeat(" ", 48<<10))
+		}),
+	)
+
+	for range 3 {
+		if _, err := client.Contact(context.Background(), 1); err != nil {
+			t.Fatalf("Contact() error = %v", err)
+		}
+	}
+
+	conns.waitIdle(t)
+	if total, _ := conns.count(); total != 1 {
+		t.Errorf("server saw %d connections, want the connection to be reused", total)
+	}
+}
+
+func TestClient_do_ContextCancelClosesBody(t *testing.T) {
+	handlerDone := make(chan struct{})
+	client, conns := newTrackedTestClient(
+		t,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer close(handlerDone)
+
+			_, _ = io.WriteString(w, `{"success":true,`)
+			w.(http.Flusher).Flush()
+
+			// Only return once the client dropped the connection.
+			<-r.Context().Done()
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := client.newRequest(ctx, http.MethodGet, "/stream", nil, nil)
+	if err != nil {
+		t.Fatalf("newRequest() error = %v", err)
+	}
+
+	resp, err := client.do(req)
+	if err != nil {
+		t.Fatalf("do() error = %v", err)
+	}
+
+	// The body is abandoned without closing it; cancellation must release it.
+	cancel()
+
+	select {
+	case <-handlerDone:
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection not released after context cancellation")
+	}
+
+	if _, err := io.ReadAll(resp.Body); err == nil {
+		t.Error("reading the body after cancellation succeeded, want error")
+	}
+	conns.waitIdle(t)
+}
+
+func TestClient_ContactsIter_CancelMidDecode(t *testing.T) {
+	// The page is streamed by a custom transport, which doesn't watch the
+	// request context itself.
+	started := make(chan struct{})
+	transport := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		pr, pw := io.Pipe()
+		go func() {
+			_, _ = io.WriteString(pw, `{"success":true,"data":{"totalRecords":100000,"contacts":[`)
+			for i := range 1000 {
+				_, _ = fmt.Fprintf(pw, `{"basefields":{"contact_id":%d}},`, i+1)
+			}
+			close(started)
+			// Stall mid-page until the body is closed.
+		}()
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       pr,
+			Request:    r,
+		}, nil
+	})
+	client, _ := newTestClient(t, http.NotFoundHandler(),
+		WithHTTPClient(&http.Client{Transport: transport}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		for _, err := range client.ContactsIter(ctx) {
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	<-started
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("ContactsIter() error = %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("ContactsIter() didn't return after cancelling mid-decode")
+	}
+}
+
+func TestContextBody_CloseStopsContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	closed := 0
+	body := newContextBody(ctx, readCloser{
+		Reader: strings.NewReader("data"),
+		close:  func() error { closed++; return nil },
+	})
+
+	if err := body.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	cancel()
+	_ = body.Close()
+
+	if closed != 1 {
+		t.Errorf("underlying body closed %d times, want 1", closed)
+	}
+}
+
+// readCloser is an [io.ReadCloser] with a custom close func.
+type readCloser struct {
+	io.Reader
+	close func() error
+}
+
+func (r readCloser) Close() error {
+	return r.close()
+}
+
+func TestClient_do_RetryResendsIdenticalBody(t *testing.T) {
+	type payload struct {
+		Note string `json:"note"`
+	}
+
+	tests := []struct {
+		name       string
+		opts       []ClientOption
+		newRequest func(c *Client, baseURL string) (*http.Request, error)
+	}{
+		{
+			name: "json",
+			newRequest: func(c *Client, _ string) (*http.Request, error) {
+				return c.newRequest(
+					context.Background(),
+					http.MethodPost,
+					"/test",
+					nil,
+					payload{"note"},
+				)
+			},
+		},
+		{
+			name: "compressed json",
+			opts: []ClientOption{WithCompression()},
+			newRequest: func(c *Client, _ string) (*http.Request, error) {
+				note := strings.Repeat("compressible ", compressionThreshold)
+				return c.newRequest(
+					context.Background(),
+					http.MethodPost,
+					"/test",
+					nil,
+					payload{note},
+				)
+			},
+		},
+		{
+			name: "form",
+			newRequest: func(_ *Client, baseURL string) (*http.Request, error) {
+				form := url.Values{"note": {"a note"}, "tags": {"x", "y"}}
+				req, err := http.NewRequestWithContext(
+					context.Background(),
+					http.MethodPost,
+					baseURL+"/test",
+					strings.NewReader(form.Encode()),
+				)
+				if err != nil {
+					return nil, err
+				}
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+				return req, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var bodies [][]byte
+			var lengths []int64
+			client, server := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					body, err := io.ReadAll(r.Body)
+					if err != nil {
+						t.Errorf("failed to read body: %v", err)
+					}
+					bodies = append(bodies, body)
+					lengths = append(lengths, r.ContentLength)
+
+					if len(bodies) == 1 {
+						w.Header().
+							Set("X-Ratelimit-Retry-After", strconv.FormatInt(time.Now().Unix(), 10))
+						w.WriteHeader(http.StatusTooManyRequests)
+						return
+					}
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				}),
+				tt.opts...,
+			)
+
+			req, err := tt.newRequest(client, server.URL)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			resp, err := client.do(req)
+			if err != nil {
+				t.Fatalf("do() error = %v", err)
+			}
+			closeBody(resp.Body)
+
+			if len(bodies) != 2 {
+				t.Fatalf("server received %d attempts, want 2", len(bodies))
+			}
+			if len(bodies[0]) == 0 || !bytes.Equal(bodies[0], bodies[1]) {
+				t.Errorf("attempt 2 body %q differs from attempt 1 body %q", bodies[1], bodies[0])
+			}
+			for i, length := range lengths {
+				if length != int64(len(bodies[i])) {
+					t.Errorf(
+						"attempt %d: Content-Length = %d, want %d",
+						i+1,
+						length,
+						len(bodies[i]),
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_rewindBody_RecomputesContentLength(t *testing.T) {
+	c := &Client{}
+	req, err := http.NewRequest(http.MethodPost, "http://example.com", nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	data := []byte(`{"note":"a note"}`)
+	setBody(req, data)
+	_, _ = io.ReadAll(req.Body)
+
+	// Simulate a length that no longer matches the body.
+	req.ContentLength = 3
+	if err := c.rewindBody(req); err != nil {
+		t.Fatalf("rewindBody() error = %v", err)
+	}
+
+	if req.ContentLength != int64(len(data)) {
+		t.Errorf("ContentLength = %d, want %d", req.ContentLength, len(data))
+	}
+	body, _ := io.ReadAll(req.Body)
+	if !bytes.Equal(body, data) {
+		t.Errorf("rewound body = %q, want %q", body, data)
+	}
+}
@@ -0,0 +1,191 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExhausted is returned when a request exceeds the request budget.
+// See [WithRequestBudget].
+var ErrBudgetExhausted = errors.New("request budget exhausted")
+
+// budgetBuckets is the number of buckets a request budget window is divided
+// into.
+const budgetBuckets = 64
+
+// BudgetExhaustedError is returned when the request budget rejects a request.
+// It matches [ErrBudgetExhausted] using [errors.Is].
+type BudgetExhaustedError struct {
+	// ResetAt is the time after which requests are let through again.
+	ResetAt time.Time
+}
+
+// Error implements the error interface.
+func (e *BudgetExhaustedError) Error() string {
+	return fmt.Sprintf("%s: resets at %s", ErrBudgetExhausted, e.ResetAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is [ErrBudgetExhausted].
+func (e *BudgetExhaustedError) Is(target error) bool {
+	return target == ErrBudgetExhausted
+}
+
+// BudgetOption configures the request budget enabled by [WithRequestBudget].
+type BudgetOption func(*requestBudget)
+
+// BlockOnBudgetExhausted makes requests wait until the request budget resets
+// instead of failing with a [BudgetExhaustedError]. Waiting is aborted once the
+// request context is done.
+func BlockOnBudgetExhausted() BudgetOption {
+	return func(b *requestBudget) {
+		b.block = true
+	}
+}
+
+// ExcludeAuthFromBudget doesn't count token requests against the request
+// budget. They are counted by default.
+func ExcludeAuthFromBudget() BudgetOption {
+	return func(b *requestBudget) {
+		b.excludeAuth = true
+	}
+}
+
+// WithRequestBudget limits the client to n requests in any window of length
+// per, e.g. to keep a nightly batch job from using up the daily quota of the
+// access key. Every request sent counts, including retries and token requests.
+// Requests exceeding the budget fail with a [BudgetExhaustedError] carrying
+// the reset time, or wait using [BlockOnBudgetExhausted].
+//
+// The window slides in steps of per/64. [Stats.BudgetUsed] reports the
+// requests counted in the current window. A non-positive n disables the budget.
+func WithRequestBudget(n int, per time.Duration, opts ...BudgetOption) ClientOption {
+	return func(c *Client) {
+		c.budget = nil
+		if n <= 0 || per <= 0 {
+			return
+		}
+
+		c.budget = &requestBudget{
+			limit: n,
+			width: max(per/budgetBuckets, 1),
+			now:   c.clock.localNow,
+			after: time.After,
+		}
+		for _, opt := range opts {
+			opt(c.budget)
+		}
+	}
+}
+
+// requestBudget counts requests in a sliding window using a ring of buckets.
+// It is safe for concurrent use.
+type requestBudget struct {
+	limit       int
+	width       time.Duration
+	block       bool
+	excludeAuth bool
+	now         func() time.Time
+	after       func(time.Duration) <-chan time.Time
+
+	mu      sync.Mutex
+	buckets [budgetBuckets]budgetBucket
+}
+
+// budgetBucket counts the requests of the time slot with the index idx.
+type budgetBucket struct {
+	idx   int64
+	count int
+}
+
+// slot returns the index of the time slot of t.
+func (b *requestBudget) slot(t time.Time) int64 {
+	return t.UnixNano() / int64(b.width)
+}
+
+// bucket returns the bucket of the time slot idx.
+func (b *requestBudget) bucket(idx int64) *budgetBucket {
+	return &b.buckets[(idx%budgetBuckets+budgetBuckets)%budgetBuckets]
+}
+
+// usage returns the number of requests in the window ending at now, and the
+// time the window has room for another request again. b.mu must be held.
+func (b *requestBudget) usage(now time.Time) (int, time.Time) {
+	current := b.slot(now)
+
+	used := 0
+	for idx := current - budgetBuckets + 1; idx <= current; idx++ {
+		if bucket := b.bucket(idx); bucket.idx == idx {
+			used += bucket.count
+		}
+	}
+
+	reset := now
+	remaining := used
+	for idx := current - budgetBuckets + 1; idx <= current && remaining >= b.limit; idx++ {
+		if bucket := b.bucket(idx); bucket.idx == idx && bucket.count > 0 {
+			remaining -= bucket.count
+			reset = time.Unix(0, (idx+budgetBuckets)*int64(b.width))
+		}
+	}
+
+	return used, reset
+}
+
+// used returns the number of requests in the current window.
+func (b *requestBudget) used() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	used, _ := b.usage(b.now())
+	return used
+}
+
+// take counts a request at now if the budget allows it. Otherwise, it returns
+// the reset time.
+func (b *requestBudget) take(now time.Time) (time.Time, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	used, reset := b.usage(now)
+	if used >= b.limit {
+		return reset, false
+	}
+
+	idx := b.slot(now)
+	bucket := b.bucket(idx)
+	if bucket.idx != idx {
+		*bucket = budgetBucket{idx: idx}
+	}
+	bucket.count++
+
+	return time.Time{}, true
+}
+
+// spendBudget counts a request against the request budget, if enabled. auth
+// reports whether it is a token request.
+func (c *Client) spendBudget(ctx context.Context, auth bool) error {
+	b := c.budget
+	if b == nil || (auth && b.excludeAuth) {
+		return nil
+	}
+
+	for {
+		now := b.now()
+		reset, ok := b.take(now)
+		if ok {
+			return nil
+		}
+		if !b.block {
+			return &BudgetExhaustedError{ResetAt: reset}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-b.after(reset.Sub(now)):
+		}
+	}
+}
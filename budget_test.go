@@ -0,0 +1,145 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newBudgetTestClient returns a client with a request budget of n requests
+// per 64 minutes, so the window slides in steps of a minute, and a budget clock
+// set to *now. It also returns the number of requests reaching the server.
+func newBudgetTestClient(
+	t *testing.T,
+	n int,
+	now *time.Time,
+	opts ...BudgetOption,
+) (*Client, *atomic.Int64) {
+	t.Helper()
+
+	var requests atomic.Int64
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}), WithRequestBudget(n, 64*time.Minute, opts...))
+	client.budget.now = func() time.Time { return *now }
+
+	return client, &requests
+}
+
+func TestWithRequestBudget(t *testing.T) {
+	start := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	now := start
+	client, requests := newBudgetTestClient(t, 2, &now)
+
+	call := func(at time.Duration) error {
+		now = start.Add(at)
+		_, err := client.Contact(context.Background(), 1)
+		return err
+	}
+	assertExhausted := func(at, wantReset time.Duration) {
+		t.Helper()
+
+		before := requests.Load()
+		err := call(at)
+		var budgetErr *BudgetExhaustedError
+		if !errors.As(err, &budgetErr) || !errors.Is(err, ErrBudgetExhausted) {
+			t.Fatalf("error at %v = %v, want BudgetExhaustedError", at, err)
+		}
+		if want := start.Add(wantReset); !budgetErr.ResetAt.Equal(want) {
+			t.Errorf("ResetAt = %v, want %v", budgetErr.ResetAt, want)
+		}
+		if requests.Load() != before {
+			t.Error("request reached the server with the budget exhausted")
+		}
+	}
+
+	for _, at := range []time.Duration{0, 10 * time.Minute} {
+		if err := call(at); err != nil {
+			t.Fatalf("error at %v = %v", at, err)
+		}
+	}
+	if got := client.Stats().BudgetUsed; got != 2 {
+		t.Errorf("Stats().BudgetUsed = %d, want 2", got)
+	}
+
+	// The first request leaves the window after 64 minutes.
+	assertExhausted(20*time.Minute, 64*time.Minute)
+	assertExhausted(63*time.Minute, 64*time.Minute)
+	if err := call(64 * time.Minute); err != nil {
+		t.Fatalf("error after reset = %v", err)
+	}
+
+	// The second request leaves the window 10 minutes later.
+	assertExhausted(65*time.Minute, 74*time.Minute)
+	if err := call(74 * time.Minute); err != nil {
+		t.Fatalf("error after reset = %v", err)
+	}
+
+	now = start.Add(200 * time.Minute)
+	if got := client.Stats().BudgetUsed; got != 0 {
+		t.Errorf("Stats().BudgetUsed after the window = %d, want 0", got)
+	}
+}
+
+func TestBlockOnBudgetExhausted(t *testing.T) {
+	start := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	now := start
+	client, requests := newBudgetTestClient(t, 1, &now, BlockOnBudgetExhausted())
+
+	var waited time.Duration
+	client.budget.after = func(d time.Duration) <-chan time.Time {
+		waited += d
+		now = now.Add(d)
+		ch := make(chan time.Time, 1)
+		ch <- now
+		return ch
+	}
+
+	for range 2 {
+		if _, err := client.Contact(context.Background(), 1); err != nil {
+			t.Fatalf("Contact() error = %v", err)
+		}
+	}
+	if waited != 64*time.Minute {
+		t.Errorf("waited %v, want %v", waited, 64*time.Minute)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+
+	// Waiting is aborted once the context is done.
+	client.budget.after = func(time.Duration) <-chan time.Time { return nil }
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.Contact(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("Contact() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestExcludeAuthFromBudget(t *testing.T) {
+	now := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		opts     []BudgetOption
+		wantUsed int64
+	}{
+		{"counted", nil, 1},
+		{"excluded", []BudgetOption{ExcludeAuthFromBudget()}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newBudgetTestClient(t, 1, &now, tt.opts...)
+			if err := client.spendBudget(context.Background(), true); err != nil {
+				t.Fatalf("spendBudget() error = %v", err)
+			}
+			if got := client.Stats().BudgetUsed; got != tt.wantUsed {
+				t.Errorf("Stats().BudgetUsed = %d, want %d", got, tt.wantUsed)
+			}
+		})
+	}
+}
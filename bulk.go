@@ -0,0 +1,153 @@
+package fairgate
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"slices"
+)
+
+// defaultBulkBatchSize is the default number of records sent per request by
+// [Client.ContactsBulkUpsert].
+const defaultBulkBatchSize = 100
+
+// BulkOption configures bulk operations such as [Client.ContactsBulkUpsert].
+type BulkOption func(*bulkConfig)
+
+// bulkConfig holds the configuration of a bulk operation.
+type bulkConfig struct {
+	batchSize int
+}
+
+// WithBatchSize sets the number of records sent per request. Defaults to 100.
+func WithBatchSize(n int) BulkOption {
+	return func(c *bulkConfig) {
+		if n > 0 {
+			c.batchSize = n
+		}
+	}
+}
+
+// ContactUpsert is a contact to create or update using [Client.ContactsBulkUpsert].
+type ContactUpsert struct {
+	// ContactID is the ID of the contact to update, or zero to create a contact.
+	ContactID     int `json:"contact_id,omitempty"`
+	ContactUpdate `json:",inline"`
+}
+
+// BulkResult is the result of a single record of a bulk operation.
+type BulkResult struct {
+	// Index is the position of the record in the input, starting at zero.
+	Index int `json:"index"`
+	// ContactID is the ID of the created or updated contact. It is zero if
+	// the record was rejected.
+	ContactID int `json:"contact_id,omitempty"`
+	// Created reports whether a contact was created.
+	Created bool `json:"created,omitempty"`
+	// Errors are the field errors the record was rejected with, if any.
+	Errors []Error `json:"errors,omitempty"`
+}
+
+// Failed reports whether the record was rejected.
+func (r BulkResult) Failed() bool {
+	return len(r.Errors) > 0
+}
+
+// ContactsBulkUpsertRequest represents a batch of contacts to create or update.
+type ContactsBulkUpsertRequest struct {
+	Contacts []ContactUpsert `json:"contacts"`
+}
+
+// BulkResultsList represents the results of a batch.
+type BulkResultsList struct {
+	// Results holds a result per record of the batch, indexed within the batch.
+	Results []BulkResult `json:"results"`
+}
+
+// ContactsBulkUpsert creates or updates the contacts of records in batches,
+// yielding a result per record in input order. Rejected records are reported
+// using [BulkResult.Errors] without stopping the iteration. If a batch fails
+// as a whole, the error is yielded and the iteration stops.
+func (c *Client) ContactsBulkUpsert(
+	ctx context.Context,
+	records iter.Seq[ContactUpsert],
+	opts ...BulkOption,
+) iter.Seq2[BulkResult, error] {
+	cfg := bulkConfig{batchSize: defaultBulkBatchSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return func(yield func(BulkResult, error) bool) {
+		offset := 0
+		batch := make([]ContactUpsert, 0, cfg.batchSize)
+		flush := func() bool {
+			results, err := c.contactsBulkUpsert(ctx, batch)
+			if err != nil {
+				yield(BulkResult{}, fmt.Errorf("bulk upsert of records %d to %d: %w",
+					offset, offset+len(batch)-1, err))
+				return false
+			}
+			for _, result := range results {
+				result.Index += offset
+				if !yield(result, nil) {
+					return false
+				}
+			}
+			offset += len(batch)
+			batch = batch[:0]
+			return true
+		}
+
+		for record := range records {
+			batch = append(batch, record)
+			if len(batch) == cfg.batchSize && !flush() {
+				return
+			}
+		}
+		if len(batch) > 0 {
+			flush()
+		}
+	}
+}
+
+// contactsBulkUpsert sends a single batch, returning the results ordered by
+// their index within the batch.
+func (c *Client) contactsBulkUpsert(
+	ctx context.Context,
+	batch []ContactUpsert,
+) ([]BulkResult, error) {
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/bulk", url.PathEscape(c.oid)),
+		nil,
+		ContactsBulkUpsertRequest{Contacts: batch},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[BulkResultsList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	results := result.Data.Results
+	slices.SortFunc(results, func(a, b BulkResult) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+	for i, r := range results {
+		if r.Index != i {
+			return nil, fmt.Errorf("missing result for record %d of %d", i, len(batch))
+		}
+	}
+	if len(results) != len(batch) {
+		return nil, fmt.Errorf("got %d results for %d records", len(results), len(batch))
+	}
+
+	return results, nil
+}
@@ -0,0 +1,175 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"iter"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+// bulkHandler creates contacts for records without ID and updates the others,
+// rejecting records without last name. Results are returned in reverse order.
+// Requests for batches in failBatches fail.
+func bulkHandler(t *testing.T, requests *int, failBatches ...int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/fsa/v2.0/contact/test-org/contacts/bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if slices.Contains(failBatches, *requests) {
+			writeJSON(w, http.StatusInternalServerError, Response[any]{Message: "import failed"})
+			return
+		}
+
+		var body ContactsBulkUpsertRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		var results []BulkResult
+		for i, record := range body.Contacts {
+			result := BulkResult{Index: i}
+			switch {
+			case record.Basefields == nil || record.Basefields.LastName == "":
+				result.Errors = []Error{{Field: "last_name", Message: "is required"}}
+			case record.ContactID == 0:
+				result.ContactID, result.Created = 1000+record.Basefields.ContactID.Int(), true
+			default:
+				result.ContactID = record.ContactID
+			}
+			results = append([]BulkResult{result}, results...)
+		}
+		writeJSON(w, http.StatusOK, Response[BulkResultsList]{
+			Success: true,
+			Data:    BulkResultsList{Results: results},
+		})
+	})
+}
+
+// bulkRecords returns n records, using the contact ID of the base fields to
+// identify created records. Records in invalid lack a last name; odd records
+// update existing contacts.
+func bulkRecords(n int, invalid ...int) iter.Seq[ContactUpsert] {
+	return func(yield func(ContactUpsert) bool) {
+		for i := range n {
+			record := ContactUpsert{ContactUpdate: ContactUpdate{
+				Basefields: &ContactBasefields{ContactID: FlexInt(i), LastName: "Muster"},
+			}}
+			if slices.Contains(invalid, i) {
+				record.Basefields.LastName = ""
+			}
+			if i%2 == 1 {
+				record.ContactID = 500 + i
+			}
+			if !yield(record) {
+				return
+			}
+		}
+	}
+}
+
+func TestClient_ContactsBulkUpsert(t *testing.T) {
+	var requests int
+	client, _ := newTestClient(t, bulkHandler(t, &requests))
+
+	var results []BulkResult
+	for result, err := range client.ContactsBulkUpsert(
+		context.Background(),
+		bulkRecords(7, 2, 5),
+		WithBatchSize(3),
+	) {
+		if err != nil {
+			t.Fatalf("ContactsBulkUpsert() error = %v", err)
+		}
+		results = append(results, result)
+	}
+
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+	if len(results) != 7 {
+		t.Fatalf("got %d results, want 7", len(results))
+	}
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("results[%d].Index = %d", i, result.Index)
+		}
+
+		var want BulkResult
+		switch {
+		case i == 2 || i == 5:
+			want = BulkResult{
+				Index:  i,
+				Errors: []Error{{Field: "last_name", Message: "is required"}},
+			}
+		case i%2 == 1:
+			want = BulkResult{Index: i, ContactID: 500 + i}
+		default:
+			want = BulkResult{Index: i, ContactID: 1000 + i, Created: true}
+		}
+		if result.Failed() != want.Failed() || result.ContactID != want.ContactID ||
+			result.Created != want.Created || !slices.Equal(result.Errors, want.Errors) {
+			t.Errorf("results[%d] = %+v, want %+v", i, result, want)
+		}
+	}
+}
+
+func TestClient_ContactsBulkUpsert_BatchFailure(t *testing.T) {
+	var requests int
+	client, _ := newTestClient(t, bulkHandler(t, &requests, 2))
+
+	var results []BulkResult
+	var errs []error
+	for result, err := range client.ContactsBulkUpsert(
+		context.Background(),
+		bulkRecords(7),
+		WithBatchSize(3),
+	) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != 3 || requests != 2 {
+		t.Errorf("got %d results after %d requests, want 3 after 2", len(results), requests)
+	}
+	var apiErr *APIError
+	if len(errs) != 1 || !errors.Is(errs[0], ErrStatus) || !errors.As(errs[0], &apiErr) {
+		t.Errorf("errors = %v, want a single status error", errs)
+	}
+}
+
+func TestClient_ContactsBulkUpsert_EarlyBreak(t *testing.T) {
+	var requests int
+	client, _ := newTestClient(t, bulkHandler(t, &requests))
+
+	for range client.ContactsBulkUpsert(context.Background(), bulkRecords(7), WithBatchSize(3)) {
+		break
+	}
+
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestContactUpsert_MarshalJSON(t *testing.T) {
+	body, err := json.Marshal(ContactUpsert{
+		ContactID: 42,
+		ContactUpdate: ContactUpdate{
+			Basefields: &ContactBasefields{FirstName: "Anna"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := `{"contact_id":42,"basefields":{"first_name":"Anna"}}`
+	if string(body) != want {
+		t.Errorf("Marshal() = %s, want %s", body, want)
+	}
+}
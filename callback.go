@@ -0,0 +1,52 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrCallbackPanic is returned when a user callback called by the client,
+// such as a [WarningHandler], a [Tracer], a function passed to [WithProgress],
+// or the mutate function of [Client.ContactUpdateWithRetry], panics. The operation calling the callback fails with a
+// [CallbackPanicError] instead of crashing, and the client remains usable.
+var ErrCallbackPanic = errors.New("callback panicked")
+
+// CallbackPanicError describes a panic recovered from a user callback. It wraps
+// [ErrCallbackPanic] and the recovered value, if it is an error.
+type CallbackPanicError struct {
+	// Callback names the callback, e.g. "WarningHandler".
+	Callback string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic.
+	Stack []byte
+}
+
+// Error returns the callback name, [ErrCallbackPanic] and the panic value.
+func (e *CallbackPanicError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Callback, ErrCallbackPanic, e.Value)
+}
+
+// Unwrap returns [ErrCallbackPanic] and, if the panic value is an error, the
+// panic value too, so [errors.Is] and [errors.As] match both.
+func (e *CallbackPanicError) Unwrap() []error {
+	if err, ok := e.Value.(error); ok {
+		return []error{ErrCallbackPanic, err}
+	}
+
+	return []error{ErrCallbackPanic}
+}
+
+// callSafely calls the user callback fn, named callback, and returns a
+// [CallbackPanicError] if it panics.
+func callSafely(callback string, fn func()) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &CallbackPanicError{Callback: callback, Value: v, Stack: debug.Stack()}
+		}
+	}()
+
+	fn()
+	return nil
+}
@@ -0,0 +1,268 @@
+package fairgate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"iter"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// panicOnce panics on its first call only.
+type panicOnce struct {
+	done atomic.Bool
+}
+
+func (p *panicOnce) call() {
+	if p.done.CompareAndSwap(false, true) {
+		panic("boom")
+	}
+}
+
+// panickingTracer panics once in the method named hook.
+type panickingTracer struct {
+	hook string
+	p    *panicOnce
+}
+
+func (t panickingTracer) Start(ctx context.Context, _ Operation) (context.Context, Span) {
+	if t.hook == "Start" {
+		t.p.call()
+	}
+	return ctx, t
+}
+
+func (t panickingTracer) RateLimited(time.Time) {}
+
+func (t panickingTracer) End(int, error) {
+	if t.hook == "End" {
+		t.p.call()
+	}
+}
+
+// panickingCoordinator panics once when loading the shared token.
+type panickingCoordinator struct {
+	LocalTokenCoordinator
+	p *panicOnce
+}
+
+func (c *panickingCoordinator) Load(ctx context.Context) (CreateTokenResponse, error) {
+	c.p.call()
+	return c.LocalTokenCoordinator.Load(ctx)
+}
+
+// callbackHandler serves tokens, a capped contacts listing with a deprecated
+// field, a forbidden contact 1, and contact 2 redirecting to contact 3.
+func callbackHandler(t *testing.T, privateKey *ecdsa.PrivateKey) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/auth/create/"):
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Success: true,
+				Data: CreateTokenResponse{
+					Token:        createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+					RefreshToken: "refresh",
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/contacts/extended"):
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"success":true,"data":{` +
+				`"totalRecords":2,"totalPages":2,"pageNo":` + r.URL.Query().Get("pageNo") +
+				`,"pageLimit":1,"contacts":[{"communication":{"handy2":"079 123 45 67"}}]}}`))
+		case strings.HasSuffix(r.URL.Path, "/contacts/1/extended"):
+			writeJSON(w, http.StatusForbidden, Response[any]{Code: http.StatusForbidden})
+		case strings.HasSuffix(r.URL.Path, "/contacts/2/extended"):
+			http.Redirect(w, r, strings.Replace(r.URL.Path, "/2/", "/3/", 1), http.StatusFound)
+		default:
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+		}
+	})
+}
+
+// firstErr returns the first error of seq.
+func firstErr[T any](seq iter.Seq2[T, error]) error {
+	for _, err := range seq {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestClient_CallbackPanics(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		callback string
+		opts     func(p *panicOnce) []ClientOption
+		// coordinated uses an unauthenticated client with a coordinator
+		// panicking once.
+		coordinated bool
+		op          func(c *Client, p *panicOnce) error
+	}{
+		{
+			name:     "warning handler",
+			callback: "WarningHandler",
+			opts: func(p *panicOnce) []ClientOption {
+				return []ClientOption{WithWarningHandler(func(string) { p.call() })}
+			},
+			op: func(c *Client, _ *panicOnce) error {
+				return firstErr(c.ContactsIter(ctx))
+			},
+		},
+		{
+			name:     "deprecation handler",
+			callback: "DeprecationHandler",
+			opts: func(p *panicOnce) []ClientOption {
+				return []ClientOption{WithDeprecationHandler(func(string, string) { p.call() })}
+			},
+			op: func(c *Client, _ *panicOnce) error {
+				_, err := c.Contacts(ctx, PageParams{PageNo: 1})
+				return err
+			},
+		},
+		{
+			name:     "progress",
+			callback: "progress",
+			op: func(c *Client, p *panicOnce) error {
+				return firstErr(c.ContactsIter(ctx, WithProgress(func(Progress) { p.call() })))
+			},
+		},
+		{
+			name:     "skipped",
+			callback: "skipped",
+			op: func(c *Client, p *panicOnce) error {
+				return firstErr(c.ContactsByIDs(ctx, []int{1, 3},
+					SkipForbidden(true),
+					WithSkipped(func(int, error) { p.call() }),
+				))
+			},
+		},
+		{
+			name:     "mutate",
+			callback: "mutate",
+			op: func(c *Client, p *panicOnce) error {
+				return c.ContactUpdateWithRetry(ctx, 3, func(Contact) (ContactUpdate, error) {
+					p.call()
+					return ContactUpdate{}, nil
+				}, 1)
+			},
+		},
+		{
+			name:     "extract",
+			callback: "extract",
+			op: func(c *Client, p *panicOnce) error {
+				_, err := AggregateContacts(ctx, c, func(Contact) string {
+					p.call()
+					return "value"
+				})
+				return err
+			},
+		},
+		{
+			name:     "tracer start",
+			callback: "Tracer.Start",
+			opts: func(p *panicOnce) []ClientOption {
+				return []ClientOption{WithTracer(panickingTracer{hook: "Start", p: p})}
+			},
+			op: func(c *Client, _ *panicOnce) error {
+				_, err := c.Contact(ctx, 3)
+				return err
+			},
+		},
+		{
+			name:     "span end",
+			callback: "Span.End",
+			opts: func(p *panicOnce) []ClientOption {
+				return []ClientOption{WithTracer(panickingTracer{hook: "End", p: p})}
+			},
+			op: func(c *Client, _ *panicOnce) error {
+				_, err := c.Contact(ctx, 3)
+				return err
+			},
+		},
+		{
+			name:     "check redirect",
+			callback: "CheckRedirect",
+			opts: func(p *panicOnce) []ClientOption {
+				return []ClientOption{WithHTTPClient(&http.Client{
+					CheckRedirect: func(*http.Request, []*http.Request) error {
+						p.call()
+						return nil
+					},
+				})}
+			},
+			op: func(c *Client, _ *panicOnce) error {
+				_, err := c.Contact(ctx, 2)
+				return err
+			},
+		},
+		{
+			name:        "token coordinator",
+			callback:    "TokenCoordinator.Load",
+			coordinated: true,
+			op: func(c *Client, _ *panicOnce) error {
+				_, err := c.Contact(ctx, 3)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p panicOnce
+			privateKey, publicKey := generateTestKeyPair(t)
+			client, server := newTestClient(t, callbackHandler(t, privateKey))
+			switch {
+			case tt.coordinated:
+				client = newCoordinatedClient(server.URL, server.Client(), publicKey,
+					&panickingCoordinator{p: &p})
+			case tt.opts != nil:
+				client = newTestClientForServer(t, server, tt.opts(&p)...)
+			}
+
+			err := tt.op(client, &p)
+			if !errors.Is(err, ErrCallbackPanic) {
+				t.Fatalf("first call error = %v, want %v", err, ErrCallbackPanic)
+			}
+			var panicErr *CallbackPanicError
+			if !errors.As(err, &panicErr) {
+				t.Fatalf("first call error = %T, want *CallbackPanicError", err)
+			}
+			if panicErr.Callback != tt.callback {
+				t.Errorf("Callback = %q, want %q", panicErr.Callback, tt.callback)
+			}
+			if panicErr.Value != "boom" {
+				t.Errorf("Value = %v, want boom", panicErr.Value)
+			}
+			if len(panicErr.Stack) == 0 {
+				t.Error("Stack is empty")
+			}
+
+			if err := tt.op(client, &p); err != nil {
+				t.Errorf("second call error = %v, want client to remain usable", err)
+			}
+		})
+	}
+}
+
+func TestCallbackPanicError_UnwrapsErrorValue(t *testing.T) {
+	cause := errors.New("cause")
+	err := callSafely("test", func() { panic(cause) })
+
+	if !errors.Is(err, ErrCallbackPanic) || !errors.Is(err, cause) {
+		t.Errorf("error = %v, want both %v and %v", err, ErrCallbackPanic, cause)
+	}
+	if want := "test: callback panicked: cause"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if err := callSafely("test", func() {}); err != nil {
+		t.Errorf("callSafely() without panic = %v, want nil", err)
+	}
+}
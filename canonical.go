@@ -0,0 +1,169 @@
+package fairgate
+
+import (
+	"cmp"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CanonicalVersion is the version of the encoding produced by
+// [Contact.CanonicalJSON]. It is incremented whenever the encoding of a value
+// changes, e.g. as fields are added, so hashes of different versions must not
+// be compared.
+const CanonicalVersion = 2
+
+// CanonicalJSON returns a stable JSON encoding of the contact, suitable for
+// hashing to detect changes. Unlike [json.Marshal], the encoding
+//   - contains all fields, using null for missing values and [] for missing lists,
+//   - orders object keys alphabetically,
+//   - formats times in UTC as RFC 3339, dropping fractional seconds,
+//   - sorts club and sub federation assignments by OrganizationID and
+//     executive board functions by RoleID.
+//
+// Fields only populated by the client, such as [Communication.NormalizedMobile],
+// are not included. See [CanonicalVersion].
+func (c Contact) CanonicalJSON() ([]byte, error) {
+	if c.ClubAssignments != nil {
+		assignments := *c.ClubAssignments
+		if assignments.Primary != nil {
+			primary := sortedClubAssignment(*assignments.Primary)
+			assignments.Primary = &primary
+		}
+		assignments.Secondary = sortedByOrganization(assignments.Secondary, sortedClubAssignment)
+		c.ClubAssignments = &assignments
+	}
+	c.SubfedAssignments = sortedByOrganization(c.SubfedAssignments, sortedSubFedAssignment)
+
+	return canonicalJSON(c)
+}
+
+// sortedClubAssignment returns a with sorted executive board functions.
+func sortedClubAssignment(a ClubAssignment) ClubAssignment {
+	a.ExecutiveBoard = sortedExecutiveBoard(a.ExecutiveBoard)
+	return a
+}
+
+// sortedSubFedAssignment returns a with sorted executive board functions.
+func sortedSubFedAssignment(a SubFedAssignment) SubFedAssignment {
+	a.ExecutiveBoard = sortedExecutiveBoard(a.ExecutiveBoard)
+	return a
+}
+
+// sortedExecutiveBoard returns a copy of functions sorted by RoleID.
+func sortedExecutiveBoard(functions []ExecutiveBoard) []ExecutiveBoard {
+	return slices.SortedStableFunc(slices.Values(functions), func(a, b ExecutiveBoard) int {
+		return cmp.Compare(a.RoleID, b.RoleID)
+	})
+}
+
+// sortedByOrganization returns a copy of assignments with sort applied to each
+// assignment, sorted by OrganizationID.
+func sortedByOrganization[T ClubAssignment | SubFedAssignment](
+	assignments []T,
+	sort func(T) T,
+) []T {
+	sorted := make([]T, 0, len(assignments))
+	for _, a := range assignments {
+		sorted = append(sorted, sort(a))
+	}
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		return cmp.Compare(organizationID(a), organizationID(b))
+	})
+
+	return sorted
+}
+
+// organizationID returns the OrganizationID of an assignment.
+func organizationID[T ClubAssignment | SubFedAssignment](a T) string {
+	switch a := any(a).(type) {
+	case ClubAssignment:
+		return a.OrganizationID
+	case SubFedAssignment:
+		return a.OrganizationID
+	}
+
+	return ""
+}
+
+// canonicalJSON returns the canonical encoding of v, as described by
+// [Contact.CanonicalJSON]. Slices are encoded in their given order.
+func canonicalJSON(v any) ([]byte, error) {
+	return json.Marshal(canonicalValue(reflect.ValueOf(v)))
+}
+
+// canonicalValue converts v to values whose JSON encoding is canonical. JSON
+// objects are converted to maps, which are encoded with sorted keys.
+func canonicalValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+
+	switch t := v.Interface().(type) {
+	case Time:
+		return canonicalTime(t.Time)
+	case time.Time:
+		return canonicalTime(t)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return canonicalValue(v.Elem())
+	case reflect.Struct:
+		obj := map[string]any{}
+		canonicalFields(v, obj)
+		return obj
+	case reflect.Map:
+		obj := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			obj[iter.Key().String()] = canonicalValue(iter.Value())
+		}
+		return obj
+	case reflect.Slice, reflect.Array:
+		list := make([]any, 0, v.Len())
+		for i := range v.Len() {
+			list = append(list, canonicalValue(v.Index(i)))
+		}
+		return list
+	default:
+		return v.Interface()
+	}
+}
+
+// canonicalFields adds the exported fields of the struct v to obj, flattening
+// embedded structs without JSON name like [json.Marshal].
+func canonicalFields(v reflect.Value, obj map[string]any) {
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" && field.Anonymous && field.Type.Kind() == reflect.Struct {
+			canonicalFields(v.Field(i), obj)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		obj[name] = canonicalValue(v.Field(i))
+	}
+}
+
+// canonicalTime formats t in UTC as RFC 3339, or returns nil if t is zero.
+func canonicalTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
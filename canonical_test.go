@@ -0,0 +1,101 @@
+package fairgate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+)
+
+const canonicalContactA = `{
+	"basefields": {
+		"contact_id": 42,
+		"first_name": "Jane",
+		"last_name": "",
+		"last_update": "2024-03-01 10:30:00"
+	},
+	"status": "active",
+	"club_assignments": {
+		"primary": {
+			"organization_id": "club-b",
+			"executive_board": [{"role_id": 3, "role_name": "Treasurer"}, {"role_id": 1, "role_name": "President"}]
+		},
+		"secondary": [
+			{"organization_id": "club-z", "organization": "Zurich"},
+			{"organization_id": "club-a", "organization": "Aarau"}
+		]
+	},
+	"subfed_assignments": [
+		{"organization_id": "sub-2"},
+		{"organization_id": "sub-1", "executive_board": [{"role_id": 9}, {"role_id": 4}]}
+	]
+}`
+
+const canonicalContactB = `{
+	"subfed_assignments": [
+		{"executive_board": [{"role_id": 4}, {"role_id": 9}], "organization_id": "sub-1"},
+		{"organization_id": "sub-2", "executive_board": []}
+	],
+	"club_assignments": {
+		"secondary": [
+			{"organization": "Aarau", "organization_id": "club-a"},
+			{"organization": "Zurich", "organization_id": "club-z"}
+		],
+		"primary": {
+			"executive_board": [{"role_name": "President", "role_id": 1}, {"role_name": "Treasurer", "role_id": 3}],
+			"organization_id": "club-b"
+		}
+	},
+	"status": "active",
+	"basefields": {
+		"last_update": "2024-03-01T09:30:00Z",
+		"first_name": "Jane",
+		"contact_id": 42
+	}
+}`
+
+func TestContact_CanonicalJSON(t *testing.T) {
+	hashes := map[string][sha256.Size]byte{}
+	var encoded []byte
+	for name, doc := range map[string]string{"a": canonicalContactA, "b": canonicalContactB} {
+		var contact Contact
+		if err := json.Unmarshal([]byte(doc), &contact); err != nil {
+			t.Fatalf("json.Unmarshal(%s) error = %v", name, err)
+		}
+
+		b, err := contact.CanonicalJSON()
+		if err != nil {
+			t.Fatalf("CanonicalJSON(%s) error = %v", name, err)
+		}
+		hashes[name] = sha256.Sum256(b)
+		encoded = b
+	}
+
+	if hashes["a"] != hashes["b"] {
+		t.Errorf("hashes differ for semantically identical contacts")
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, encoded, "", "  "); err != nil {
+		t.Fatalf("json.Indent() error = %v", err)
+	}
+	assertGolden(t, "contact_canonical.golden", indented.Bytes())
+}
+
+func TestContact_CanonicalJSON_DoesNotModifyContact(t *testing.T) {
+	var contact Contact
+	if err := json.Unmarshal([]byte(canonicalContactA), &contact); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	if _, err := contact.CanonicalJSON(); err != nil {
+		t.Fatalf("CanonicalJSON() error = %v", err)
+	}
+
+	if got := contact.ClubAssignments.Secondary[0].OrganizationID; got != "club-z" {
+		t.Errorf("Secondary[0].OrganizationID = %q, want club-z", got)
+	}
+	if got := contact.ClubAssignments.Primary.ExecutiveBoard[0].RoleID; got != 3 {
+		t.Errorf("Primary.ExecutiveBoard[0].RoleID = %d, want 3", got)
+	}
+}
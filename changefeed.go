@@ -0,0 +1,131 @@
+package fairgate
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// defaultChangeFeedOverlap is the default overlap of change feed windows.
+const defaultChangeFeedOverlap = 5 * time.Minute
+
+// ChangeKey identifies a contact in the state after a change.
+type ChangeKey struct {
+	ContactID  int
+	LastUpdate time.Time
+}
+
+// changeFeedConfig holds the configuration of [Client.ContactsChangedBetween].
+type changeFeedConfig struct {
+	overlap time.Duration
+	seen    map[ChangeKey]bool
+	cursor  *SyncCursor
+	opts    []IterOption
+}
+
+// ChangeFeedOption configures [Client.ContactsChangedBetween].
+type ChangeFeedOption func(*changeFeedConfig)
+
+// ChangeFeedOverlap extends the start of the window into the past by d, so
+// contacts updated while the previous window was fetched aren't missed.
+// Defaults to 5 minutes; use 0 for no overlap.
+func ChangeFeedOverlap(d time.Duration) ChangeFeedOption {
+	return func(c *changeFeedConfig) {
+		c.overlap = max(d, 0)
+	}
+}
+
+// ChangeFeedSeen skips the changes contained in seen and adds the yielded
+// changes to it. Pass the same map to consecutive calls to skip the changes
+// already yielded for the overlap of the previous window.
+func ChangeFeedSeen(seen map[ChangeKey]bool) ChangeFeedOption {
+	return func(c *changeFeedConfig) {
+		c.seen = seen
+	}
+}
+
+// ChangeFeedCursor observes the LastUpdate of the contacts within the window
+// with cursor, converted to local time like the bounds of the window. Pass
+// cursor.Next to the next window as from, see [SyncCursor.Next].
+func ChangeFeedCursor(cursor *SyncCursor) ChangeFeedOption {
+	return func(c *changeFeedConfig) {
+		c.cursor = cursor
+	}
+}
+
+// ChangeFeedIterOptions configures the iteration over all contacts, e.g. to
+// report the progress using [WithProgress].
+func ChangeFeedIterOptions(opts ...IterOption) ChangeFeedOption {
+	return func(c *changeFeedConfig) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+// ContactsChangedBetween returns an iterator over the contacts last updated
+// within [from, to), i.e. including from and excluding to, so consecutive
+// windows sharing a bound don't overlap. The bounds are local times, adjusted
+// by the estimated clock skew of the server, see [Client.ClockSkew].
+//
+// The start of the window is extended by an overlap, see [ChangeFeedOverlap],
+// as contacts may be updated while a window is fetched. Contacts are thus
+// delivered at least once: a change may be yielded by two consecutive windows.
+// For exactly once delivery, pass the same map to [ChangeFeedSeen] for all
+// windows. Each change is identified by contact ID and LastUpdate; contacts
+// yielded within a window are never yielded twice.
+//
+// Only the current state of a contact is returned: a contact updated again
+// after to is returned by the window containing its last update.
+// As the API can't filter by LastUpdate, all contacts are fetched. If
+// [WithContactFields] restricts the sections, the base fields are still
+// requested.
+func (c *Client) ContactsChangedBetween(
+	ctx context.Context,
+	from, to time.Time,
+	opts ...ChangeFeedOption,
+) iter.Seq2[Contact, error] {
+	cfg := changeFeedConfig{overlap: defaultChangeFeedOverlap}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.opts = append(cfg.opts[:len(cfg.opts):len(cfg.opts)], requireContactFields(FieldBasefields))
+
+	return func(yield func(Contact, error) bool) {
+		seen := cfg.seen
+		if seen == nil {
+			seen = map[ChangeKey]bool{}
+		}
+
+		for contact, err := range c.ContactsIter(ctx, cfg.opts...) {
+			if err != nil {
+				yield(Contact{}, err)
+				return
+			}
+
+			lastUpdate := contact.Basefields.LastUpdate.Time
+			if lastUpdate.IsZero() {
+				continue
+			}
+
+			skew := c.ClockSkew()
+			if lastUpdate.Before(from.Add(skew-cfg.overlap)) || !lastUpdate.Before(to.Add(skew)) {
+				continue
+			}
+			if cfg.cursor != nil {
+				cfg.cursor.Observe(Time{lastUpdate.Add(-skew)})
+			}
+
+			key := ChangeKey{
+				ContactID:  contact.Basefields.ContactID.Int(),
+				LastUpdate: lastUpdate.UTC(),
+			}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			if !yield(contact, nil) {
+				return
+			}
+		}
+	}
+}
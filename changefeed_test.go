@@ -0,0 +1,219 @@
+package fairgate
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// changedContact returns a contact with the given ID and last update time.
+func changedContact(id int, lastUpdate time.Time) Contact {
+	return Contact{
+		Basefields: ContactBasefields{ContactID: FlexInt(id), LastUpdate: Time{lastUpdate}},
+	}
+}
+
+// contactsPageHandler serves contacts as a single page. No Date header is
+// sent, so the clock skew isn't changed by the response.
+func contactsPageHandler(contacts ...Contact) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header()["Date"] = nil
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{
+					TotalRecords: FlexInt(len(contacts)),
+					TotalPages:   1,
+					PageNo:       1,
+				},
+				Contacts: contacts,
+			},
+		})
+	})
+}
+
+// changedIDs returns the IDs of the contacts yielded by seq.
+func changedIDs(t *testing.T, seq iter.Seq2[Contact, error]) []int {
+	t.Helper()
+
+	var ids []int
+	for contact, err := range seq {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, contact.Basefields.ContactID.Int())
+	}
+
+	return ids
+}
+
+func TestClient_ContactsChangedBetween(t *testing.T) {
+	from := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	overlap := defaultChangeFeedOverlap
+
+	tests := []struct {
+		name     string
+		contacts []Contact
+		skew     time.Duration
+		opts     []ChangeFeedOption
+		want     []int
+	}{
+		{
+			name: "bounds",
+			contacts: []Contact{
+				changedContact(1, from.Add(-overlap-time.Nanosecond)),
+				changedContact(2, from.Add(-overlap)),
+				changedContact(3, from),
+				changedContact(4, to.Add(-time.Nanosecond)),
+				changedContact(5, to),
+				changedContact(6, time.Time{}),
+			},
+			want: []int{2, 3, 4},
+		},
+		{
+			name: "without overlap",
+			contacts: []Contact{
+				changedContact(1, from.Add(-time.Nanosecond)),
+				changedContact(2, from),
+			},
+			opts: []ChangeFeedOption{ChangeFeedOverlap(0)},
+			want: []int{2},
+		},
+		{
+			name: "offset",
+			contacts: []Contact{
+				changedContact(1, from.In(APILocation)),
+				changedContact(2, to.In(APILocation)),
+			},
+			want: []int{1},
+		},
+		{
+			name: "server clock ahead",
+			contacts: []Contact{
+				changedContact(1, from.Add(-overlap+30*time.Second)),
+				changedContact(2, to.Add(30*time.Second)),
+			},
+			skew: time.Minute,
+			want: []int{2},
+		},
+		{
+			name: "duplicates",
+			contacts: []Contact{
+				changedContact(1, from),
+				changedContact(1, from),
+				changedContact(1, from.Add(time.Second)),
+			},
+			want: []int{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, contactsPageHandler(tt.contacts...))
+			client.clock.skew = tt.skew
+
+			got := changedIDs(t, client.ContactsChangedBetween(
+				context.Background(),
+				from,
+				to,
+				tt.opts...,
+			))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("changed contacts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ContactsChangedBetween_ConsecutiveWindows(t *testing.T) {
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	windows := []time.Time{start, start.Add(time.Hour), start.Add(2 * time.Hour)}
+
+	client, _ := newTestClient(t, contactsPageHandler(
+		changedContact(1, windows[1].Add(-time.Minute)),
+		changedContact(2, windows[1].Add(time.Minute)),
+	))
+
+	tests := []struct {
+		name string
+		seen map[ChangeKey]bool
+		want [][]int
+	}{
+		{name: "at least once", want: [][]int{{1}, {1, 2}}},
+		{name: "exactly once", seen: map[ChangeKey]bool{}, want: [][]int{{1}, {2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, want := range tt.want {
+				var opts []ChangeFeedOption
+				if tt.seen != nil {
+					opts = append(opts, ChangeFeedSeen(tt.seen))
+				}
+
+				got := changedIDs(t, client.ContactsChangedBetween(
+					context.Background(),
+					windows[i],
+					windows[i+1],
+					opts...,
+				))
+				if !slices.Equal(got, want) {
+					t.Errorf("window %d: changed contacts = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ContactsChangedBetween_Cursor(t *testing.T) {
+	from := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	client, _ := newTestClient(t, contactsPageHandler(
+		changedContact(1, from.Add(10*time.Minute)),
+		changedContact(2, from.Add(40*time.Minute)),
+		// Updated after the window, so the next window yields it.
+		changedContact(3, to.Add(time.Minute)),
+	))
+
+	cursor := NewSyncCursor(from)
+	got := changedIDs(t, client.ContactsChangedBetween(
+		context.Background(),
+		from,
+		to,
+		ChangeFeedCursor(cursor),
+	))
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("changed contacts = %v, want [1 2]", got)
+	}
+	if next, want := cursor.Next(time.Minute), from.Add(39*time.Minute); !next.Equal(want) {
+		t.Errorf("Next() = %v, want %v", next, want)
+	}
+}
+
+func TestClient_ContactsChangedBetween_ContactFields(t *testing.T) {
+	now := time.Now()
+	var fields string
+	handler := contactsPageHandler(changedContact(1, now))
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		handler.ServeHTTP(w, r)
+	}))
+
+	ids := changedIDs(t, client.ContactsChangedBetween(
+		context.Background(),
+		now.Add(-time.Hour),
+		now.Add(time.Hour),
+		ChangeFeedIterOptions(WithContactFields(FieldCommunication)),
+	))
+
+	if !slices.Equal(ids, []int{1}) {
+		t.Errorf("ContactsChangedBetween() = %v, want [1]", ids)
+	}
+	if fields != "communication,basefields" {
+		t.Errorf("fields = %q, want communication,basefields", fields)
+	}
+}
@@ -0,0 +1,199 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned when requests are rejected because the circuit
+// breaker is open. See [WithCircuitBreaker].
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Default circuit breaker options.
+const (
+	defaultFailureThreshold = 5
+	defaultOpenDuration     = 30 * time.Second
+	defaultHalfOpenMaxCalls = 1
+)
+
+// CBOptions configures the circuit breaker enabled by [WithCircuitBreaker].
+type CBOptions struct {
+	// FailureThreshold is the number of consecutive failures opening the
+	// circuit. Defaults to 5.
+	FailureThreshold int
+	// OpenDuration is the time requests are rejected before the circuit is
+	// half-open. Defaults to 30 seconds.
+	OpenDuration time.Duration
+	// HalfOpenMaxCalls is the number of requests let through while the circuit
+	// is half-open. The circuit is closed if all of them succeed. Defaults to 1.
+	HalfOpenMaxCalls int
+}
+
+// CircuitOpenError is returned when the circuit breaker rejects a request.
+// It matches [ErrCircuitOpen] using [errors.Is].
+type CircuitOpenError struct {
+	// HalfOpenAt is the time after which requests are let through again.
+	HalfOpenAt time.Time
+}
+
+// Error implements the error interface.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: half-open at %s", ErrCircuitOpen, e.HalfOpenAt.Format(time.RFC3339))
+}
+
+// Is reports whether target is [ErrCircuitOpen].
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// WithCircuitBreaker enables a circuit breaker protecting callers during
+// outages. After opts.FailureThreshold consecutive failures, i.e. transport
+// errors or 5xx responses, requests fail with a [CircuitOpenError] for
+// opts.OpenDuration. Afterwards, the circuit is half-open and up to
+// opts.HalfOpenMaxCalls requests probe whether the API recovered.
+// Token requests share the state of the circuit breaker.
+func WithCircuitBreaker(opts CBOptions) ClientOption {
+	if opts.FailureThreshold <= 0 {
+		opts.FailureThreshold = defaultFailureThreshold
+	}
+	if opts.OpenDuration <= 0 {
+		opts.OpenDuration = defaultOpenDuration
+	}
+	if opts.HalfOpenMaxCalls <= 0 {
+		opts.HalfOpenMaxCalls = defaultHalfOpenMaxCalls
+	}
+
+	return func(c *Client) {
+		c.breaker = &circuitBreaker{opts: opts}
+	}
+}
+
+// circuitState is the state of a circuit breaker.
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker tracks the failures of requests. It is safe for concurrent use.
+type circuitBreaker struct {
+	mu   sync.Mutex
+	opts CBOptions
+
+	state      circuitState
+	failures   int
+	halfOpenAt time.Time
+	// probes and successes count the requests let through and succeeded
+	// while half-open.
+	probes    int
+	successes int
+}
+
+// allow returns an error if a request at now is rejected. Otherwise the
+// outcome of the request must be passed to done.
+func (b *circuitBreaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen {
+		if now.Before(b.halfOpenAt) {
+			return &CircuitOpenError{HalfOpenAt: b.halfOpenAt}
+		}
+		b.state = circuitHalfOpen
+		b.probes = 0
+		b.successes = 0
+	}
+
+	if b.state == circuitHalfOpen {
+		if b.probes >= b.opts.HalfOpenMaxCalls {
+			return &CircuitOpenError{HalfOpenAt: b.halfOpenAt}
+		}
+		b.probes++
+	}
+
+	return nil
+}
+
+// circuitOutcome is the outcome of a request let through by a circuit breaker.
+type circuitOutcome int
+
+const (
+	// circuitSuccess means the API responded, even if with a client error.
+	circuitSuccess circuitOutcome = iota
+	// circuitFailure means the request failed due to the API.
+	circuitFailure
+	// circuitIgnored means the outcome says nothing about the API, e.g. as
+	// the request was canceled.
+	circuitIgnored
+)
+
+// done records the outcome of a request allowed at now.
+func (b *circuitBreaker) done(now time.Time, outcome circuitOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch {
+	case outcome == circuitIgnored:
+		if b.state == circuitHalfOpen {
+			b.probes--
+		}
+	case outcome == circuitFailure && b.state == circuitClosed:
+		b.failures++
+		if b.failures >= b.opts.FailureThreshold {
+			b.open(now)
+		}
+	case outcome == circuitFailure && b.state == circuitHalfOpen:
+		b.open(now)
+	case b.state == circuitClosed:
+		b.failures = 0
+	case b.state == circuitHalfOpen:
+		b.successes++
+		if b.successes >= b.opts.HalfOpenMaxCalls {
+			b.state = circuitClosed
+			b.failures = 0
+		}
+	}
+}
+
+// open opens the circuit at now.
+func (b *circuitBreaker) open(now time.Time) {
+	b.state = circuitOpen
+	b.failures = 0
+	b.halfOpenAt = now.Add(b.opts.OpenDuration)
+}
+
+// circuitOutcomeOf classifies the result of sending a request with ctx.
+func circuitOutcomeOf(ctx context.Context, resp *http.Response, err error) circuitOutcome {
+	switch {
+	case err != nil && ctx.Err() != nil:
+		return circuitIgnored
+	case err != nil, resp.StatusCode >= 500:
+		return circuitFailure
+	default:
+		return circuitSuccess
+	}
+}
+
+// guardedRoundTrip sends req using the HTTP client, guarded by the circuit
+// breaker if enabled.
+func (c *Client) guardedRoundTrip(req *http.Request) (*http.Response, error) {
+	req = c.traceConnections(req)
+	if c.breaker == nil {
+		return c.httpClient.Do(req)
+	}
+
+	if err := c.breaker.allow(c.clock.localNow()); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	c.breaker.done(c.clock.localNow(), circuitOutcomeOf(req.Context(), resp, err))
+
+	return resp, err
+}
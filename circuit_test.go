@@ -0,0 +1,158 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithCircuitBreaker(t *testing.T) {
+	var status, requests atomic.Int64
+	status.Store(http.StatusInternalServerError)
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(w, int(status.Load()), Response[Contact]{Success: status.Load() == 200})
+	}), WithCircuitBreaker(CBOptions{
+		FailureThreshold: 2,
+		OpenDuration:     time.Minute,
+		HalfOpenMaxCalls: 1,
+	}))
+
+	now := time.Now()
+	client.clock.now = func() time.Time { return now }
+
+	// call requests a contact and returns whether the request reached the
+	// server and the error.
+	call := func() (bool, error) {
+		before := requests.Load()
+		_, err := client.Contact(context.Background(), 1)
+		return requests.Load() > before, err
+	}
+	assertOpen := func(wantHalfOpenAt time.Time) {
+		t.Helper()
+
+		sent, err := call()
+		var openErr *CircuitOpenError
+		if !errors.As(err, &openErr) || !errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("error = %v, want CircuitOpenError", err)
+		}
+		if !openErr.HalfOpenAt.Equal(wantHalfOpenAt) {
+			t.Errorf("HalfOpenAt = %v, want %v", openErr.HalfOpenAt, wantHalfOpenAt)
+		}
+		if sent {
+			t.Error("request reached the server while the circuit is open")
+		}
+	}
+
+	// Client errors don't open the circuit.
+	status.Store(http.StatusNotFound)
+	for range 3 {
+		if _, err := call(); !errors.Is(err, ErrStatus) {
+			t.Fatalf("error = %v, want ErrStatus", err)
+		}
+	}
+
+	// Consecutive server errors open the circuit.
+	status.Store(http.StatusInternalServerError)
+	for range 2 {
+		if sent, err := call(); !errors.Is(err, ErrStatus) || !sent {
+			t.Fatalf("error = %v, sent = %v, want ErrStatus", err, sent)
+		}
+	}
+	assertOpen(now.Add(time.Minute))
+
+	// A successful probe closes the circuit.
+	now = now.Add(time.Minute)
+	status.Store(http.StatusOK)
+	for range 3 {
+		if sent, err := call(); err != nil || !sent {
+			t.Fatalf("error = %v, sent = %v, want success", err, sent)
+		}
+	}
+
+	// A failed probe reopens the circuit.
+	status.Store(http.StatusInternalServerError)
+	for range 2 {
+		_, _ = call()
+	}
+	assertOpen(now.Add(time.Minute))
+
+	now = now.Add(time.Minute)
+	if sent, err := call(); !errors.Is(err, ErrStatus) || !sent {
+		t.Fatalf("error = %v, sent = %v, want ErrStatus", err, sent)
+	}
+	assertOpen(now.Add(time.Minute))
+}
+
+func TestWithCircuitBreaker_TokenRequests(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+
+	var requests atomic.Int64
+	_, server := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+		WithAccessKey("access-key"),
+		WithCircuitBreaker(CBOptions{FailureThreshold: 1, OpenDuration: time.Minute}),
+	)
+
+	if err := client.TokenCreate(context.Background(), "access-key"); err == nil {
+		t.Fatal("expected error, got nil")
+	}
+
+	// The failed token creation opened the circuit for all requests.
+	_, err := client.Contact(context.Background(), 1)
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("error = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server got %d requests, want 1", got)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenMaxCalls(t *testing.T) {
+	now := time.Now()
+	b := &circuitBreaker{opts: CBOptions{
+		FailureThreshold: 1,
+		OpenDuration:     time.Minute,
+		HalfOpenMaxCalls: 2,
+	}}
+
+	if err := b.allow(now); err != nil {
+		t.Fatalf("allow() error = %v", err)
+	}
+	b.done(now, circuitFailure)
+
+	now = now.Add(time.Minute)
+	for i := range 2 {
+		if err := b.allow(now); err != nil {
+			t.Fatalf("probe %d: allow() error = %v", i, err)
+		}
+	}
+	if err := b.allow(now); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() error = %v, want ErrCircuitOpen beyond HalfOpenMaxCalls", err)
+	}
+
+	// A canceled probe frees its slot.
+	b.done(now, circuitIgnored)
+	if err := b.allow(now); err != nil {
+		t.Fatalf("allow() error = %v", err)
+	}
+
+	b.done(now, circuitSuccess)
+	if b.state != circuitHalfOpen {
+		t.Errorf("state = %v, want half-open until all probes succeeded", b.state)
+	}
+	b.done(now, circuitSuccess)
+	if b.state != circuitClosed {
+		t.Errorf("state = %v, want closed", b.state)
+	}
+}
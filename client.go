@@ -4,11 +4,11 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"runtime"
 	"runtime/debug"
-	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -21,32 +21,102 @@ const (
 	TestURL = "https://fsa-test.fairgate.ch/"
 
 	modulePath = "thde.io/fairgate"
+
+	// defaultMaxRateLimitRetries is the default number of retries of rate
+	// limited requests.
+	defaultMaxRateLimitRetries = 5
 )
 
 var (
 	// ErrStatus is returned when the API returns an unexpected status code.
 	ErrStatus = errors.New("unexpected status code")
-	// ErrNoAccessKey is returned when no access key is available.
-	ErrNoAccessKey = errors.New("no token available")
+	// ErrNoAccessKey is returned when a token is created without an access key.
+	// Requests without a token used to return it as well and now return
+	// [ErrNoToken] instead.
+	ErrNoAccessKey = errors.New("no access key configured")
+	// ErrNoToken is returned by requests when no token was created and no
+	// access key is configured with [WithAccessKey] to create one lazily.
+	ErrNoToken = errors.New("no token available and lazy creation disabled")
 	// ErrNoRefreshToken is returned when no refresh token is available.
 	ErrNoRefreshToken = errors.New("no refresh token available")
+	// ErrInvalidBaseURL is returned by requests of a client configured with
+	// an invalid base URL.
+	ErrInvalidBaseURL = errors.New("invalid base URL")
+	// ErrInsecureBaseURL is returned by requests of a client configured with
+	// a plain HTTP base URL to a host other than a loopback address, unless
+	// allowed with [WithAllowInsecureHTTP].
+	ErrInsecureBaseURL = errors.New("insecure base URL")
+	// ErrForbidden is returned when the access key lacks the permission to
+	// access a resource.
+	ErrForbidden = errors.New("access forbidden")
+	// ErrNotFound is returned when a resource doesn't exist.
+	ErrNotFound = errors.New("not found")
 	// ErrRateLimit is returned when the rate limit is exceeded.
 	ErrRateLimit = errors.New("rate limit exceeded")
+	// ErrDestructiveOpsDisabled is returned when a destructive operation is called
+	// on a client without [WithDestructiveOps].
+	ErrDestructiveOpsDisabled = errors.New("destructive operations are disabled")
 )
 
 // Client holds configuration needed to call the Fairgate Standard API.
 // Use [New] to create a new client.
 type Client struct {
 	baseURL *url.URL
+	// allowInsecureHTTP allows plain HTTP base URLs, see
+	// [WithAllowInsecureHTTP].
+	allowInsecureHTTP bool
 
 	oid        string
 	httpClient *http.Client
-	userAgent  string
+	// customHTTPClient reports whether httpClient was set by [WithHTTPClient].
+	customHTTPClient bool
+	// transportSettings change the default HTTP client, see [WithTimeout].
+	transportSettings transportSettings
+	// documentSizes are the sizes of listed documents, see
+	// [Client.DocumentDownload].
+	documentSizes documentSizes
+	userAgent     string
+	headers       http.Header
+	language      Language
+
+	destructiveOps bool
+	compression    bool
+	phoneRegion    string
+	loc            *time.Location
+	codec          JSONCodec
+
+	sensitiveFields bool
+	followMerges    bool
+	scopeChecks     bool
+	dryRun          io.Writer
+
+	allowedEndpoints []endpointPattern
+	pins             [][]byte
 
-	auth *tokenStore
+	auth               *tokenStore
+	coordinator        TokenCoordinator
+	coordinatorTimeout time.Duration
+	refreshWarning     time.Duration
+	clock              clock
+	breaker            *circuitBreaker
+	budget             *requestBudget
+	slots              requestSlots
 
-	retryAftertMU sync.Mutex
-	retryAfter    time.Time
+	deprecations   deprecationTracker
+	schemas        *schemaTracker
+	pageLimits     pageLimits
+	etags          *etagCache
+	warningHandler WarningHandler
+	stats          stats
+	// state is the observable state, see [Client.Snapshot].
+	state  clientState
+	tracer Tracer
+
+	maxRateLimitRetries int
+	failFastOnRateLimit bool
+
+	// err is reported by all requests, e.g. due to an invalid option.
+	err error
 }
 
 // ClientOption configures a Client before use.
@@ -59,19 +129,50 @@ func WithBaseURL(baseURL *url.URL) ClientOption {
 	}
 }
 
+// WithBaseURLString sets a custom base URL, such as "https://fsa.example.com/".
+// If the URL is invalid or lacks a scheme or host, requests fail with
+// [ErrInvalidBaseURL].
+func WithBaseURLString(baseURL string) ClientOption {
+	return func(c *Client) {
+		u, err := parseBaseURL(baseURL)
+		if err != nil {
+			c.err = errors.Join(c.err, err)
+			return
+		}
+
+		c.baseURL = u
+	}
+}
+
+// WithAllowInsecureHTTP allows plain HTTP base URLs to any host. By default,
+// requests to a plain HTTP base URL fail with [ErrInsecureBaseURL] unless the
+// host is a loopback address, such as the one of an [net/http/httptest.Server], so
+// tokens aren't sent in cleartext due to a mistyped base URL. Use it for local
+// fakes reached by another name only.
+func WithAllowInsecureHTTP() ClientOption {
+	return func(c *Client) {
+		c.allowInsecureHTTP = true
+	}
+}
+
 // WithTest configures the client to use the Fairgate test endpoint.
 func WithTest() ClientOption {
 	return func(c *Client) {
-		testURL, _ := url.Parse(TestURL)
-
-		c.baseURL = testURL
+		c.baseURL = cloneURL(testURL)
 	}
 }
 
-// WithHTTPClient sets a custom HTTP client.
+// WithHTTPClient sets a custom HTTP client. The client uses a copy of
+// httpClient which refuses redirects to other hosts with
+// [ErrCrossHostRedirect] and from HTTPS to HTTP with [ErrInsecureRedirect], so
+// the token isn't leaked. Its transport is used as is. By default, the client
+// uses a transport keeping connections to the API open for reuse, as many as
+// [WithMaxConcurrentRequests] allows, which options such as [WithTimeout]
+// change.
 func WithHTTPClient(httpClient *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = httpClient
+		c.customHTTPClient = true
 	}
 }
 
@@ -98,38 +199,103 @@ func WithUserAgent(userAgent string) ClientOption {
 	}
 }
 
+// WithLanguage sets the language of messages reported by the API, such as
+// [APIError.Message]. Defaults to [LanguageEN].
+func WithLanguage(lang Language) ClientOption {
+	return func(c *Client) {
+		c.language = lang
+	}
+}
+
+// WithDestructiveOps allows calling operations that irreversibly modify data,
+// such as [Client.ContactMerge].
+func WithDestructiveOps() ClientOption {
+	return func(c *Client) {
+		c.destructiveOps = true
+	}
+}
+
+// WithMaxRateLimitRetries sets how often a rate limited request is retried
+// before a [RateLimitError] is returned. Defaults to 5; zero disables retries.
+func WithMaxRateLimitRetries(n int) ClientOption {
+	return func(c *Client) {
+		c.maxRateLimitRetries = n
+	}
+}
+
+// WithFailFastOnRateLimit returns a [RateLimitError] right away when the
+// client is rate limited, instead of waiting and retrying. Subsequent requests
+// fail the same way until the retry-after time has passed.
+func WithFailFastOnRateLimit() ClientOption {
+	return func(c *Client) {
+		c.failFastOnRateLimit = true
+	}
+}
+
 // New creates a Fairgate API client for the provided organisation.
 // The client defaults to the production Fairgate endpoint and applies any
 // provided options.
 func New(oid string, key *ecdsa.PublicKey, opts ...ClientOption) *Client {
-	productionURL, _ := url.Parse(ProductionURL)
+	c := newClient(oid, key)
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.applyDefaults()
+
+	return c
+}
 
+// newClient returns a client with the default configuration, before options
+// are applied.
+func newClient(oid string, key *ecdsa.PublicKey) *Client {
 	c := &Client{
-		baseURL: productionURL,
+		baseURL: cloneURL(productionURL),
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		oid: oid,
-		auth: &tokenStore{
-			parser: jwt.NewParser(
-				jwt.WithValidMethods([]string{signingMethod.Alg()}),
-				jwt.WithLeeway(2*time.Minute),
-			),
-			keyFunc: func(verifyKey *jwt.Token) (any, error) {
-				return key, nil
-			},
+		oid:                 oid,
+		maxRateLimitRetries: defaultMaxRateLimitRetries,
+		refreshWarning:      defaultRefreshTokenWarning,
+	}
+	c.auth = &tokenStore{
+		state: &c.state,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{signingMethod.Alg()}),
+			jwt.WithLeeway(2*time.Minute),
+			jwt.WithTimeFunc(c.clock.serverNow),
+		),
+		keyFunc: func(verifyKey *jwt.Token) (any, error) {
+			return key, nil
 		},
 	}
 
-	for _, opt := range opts {
-		opt(c)
-	}
+	return c
+}
 
+// applyDefaults completes the configuration once all options are applied.
+func (c *Client) applyDefaults() {
+	if c.baseURL != nil {
+		c.auth.environment.host = knownEnvironmentHost(c.baseURL.Hostname())
+	}
 	if c.userAgent == "" {
 		c.userAgent = userAgent()
 	}
-
-	return c
+	if c.language == "" {
+		c.language = LanguageEN
+	}
+	if c.httpClient != nil && !c.customHTTPClient && c.httpClient.Transport == nil {
+		transport := newTransport(c.maxIdleConns())
+		for _, apply := range c.transportSettings.apply {
+			apply(c.httpClient, transport)
+		}
+		c.httpClient.Transport = transport
+	}
+	if c.httpClient != nil {
+		c.httpClient = redirectSafeHTTPClient(c.httpClient)
+	}
+	if c.pins != nil {
+		c.httpClient = pinnedHTTPClient(c.httpClient, c.pins)
+	}
 }
 
 // version returns the module version of the fairgate package.
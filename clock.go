@@ -0,0 +1,77 @@
+package fairgate
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// skewSmoothing is the weight given to a new skew sample once an estimate exists.
+const skewSmoothing = 0.25
+
+// clock tracks the estimated offset between the local clock and the server clock.
+// The zero value uses [time.Now] and assumes no skew.
+type clock struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	skew    time.Duration
+	samples int
+}
+
+// localNow returns the current local time.
+func (cl *clock) localNow() time.Time {
+	if cl.now == nil {
+		return time.Now()
+	}
+
+	return cl.now()
+}
+
+// serverNow returns the current time as estimated on the server.
+func (cl *clock) serverNow() time.Time {
+	cl.mu.Lock()
+	skew := cl.skew
+	cl.mu.Unlock()
+
+	return cl.localNow().Add(skew)
+}
+
+// Skew returns the estimated server time minus local time.
+func (cl *clock) Skew() time.Duration {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	return cl.skew
+}
+
+// observe updates the skew estimate from the Date header of a response.
+// Responses without a valid Date header are ignored.
+func (cl *clock) observe(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+
+	sample := serverTime.Sub(cl.localNow())
+
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+
+	if cl.samples == 0 {
+		cl.skew = sample
+	} else {
+		cl.skew += time.Duration(float64(sample-cl.skew) * skewSmoothing)
+	}
+	cl.samples++
+}
+
+// ClockSkew returns the estimated offset between the Fairgate server clock
+// and the local clock, derived from the Date header of API responses.
+// A negative value means the local clock is ahead of the server.
+func (c *Client) ClockSkew() time.Duration {
+	return c.clock.Skew()
+}
@@ -0,0 +1,143 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClock_observe(t *testing.T) {
+	local := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		dates    []string
+		wantSkew time.Duration
+	}{
+		{
+			name:     "no samples",
+			wantSkew: 0,
+		},
+		{
+			name:     "missing date header",
+			dates:    []string{""},
+			wantSkew: 0,
+		},
+		{
+			name:     "invalid date header",
+			dates:    []string{"yesterday"},
+			wantSkew: 0,
+		},
+		{
+			name:     "server ahead",
+			dates:    []string{local.Add(5 * time.Minute).Format(http.TimeFormat)},
+			wantSkew: 5 * time.Minute,
+		},
+		{
+			name:     "server behind",
+			dates:    []string{local.Add(-5 * time.Minute).Format(http.TimeFormat)},
+			wantSkew: -5 * time.Minute,
+		},
+		{
+			name: "outlier is smoothed",
+			dates: []string{
+				local.Add(-4 * time.Minute).Format(http.TimeFormat),
+				local.Add(4 * time.Minute).Format(http.TimeFormat),
+			},
+			wantSkew: -2 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := &clock{now: func() time.Time { return local }}
+
+			for _, date := range tt.dates {
+				resp := &http.Response{Header: http.Header{}}
+				if date != "" {
+					resp.Header.Set("Date", date)
+				}
+				cl.observe(resp)
+			}
+
+			if got := cl.Skew(); got != tt.wantSkew {
+				t.Errorf("Skew() = %v, want %v", got, tt.wantSkew)
+			}
+		})
+	}
+}
+
+func TestClient_ClockSkew_TokenRefresh(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	serverTime := time.Now().Truncate(time.Second)
+
+	tests := []struct {
+		name             string
+		skew             time.Duration
+		expiresAt        time.Time
+		wantRefresh      bool
+		wantLocalVerdict bool
+	}{
+		{
+			name:             "local clock 5 minutes fast",
+			skew:             -5 * time.Minute,
+			expiresAt:        serverTime.Add(4 * time.Minute),
+			wantRefresh:      false,
+			wantLocalVerdict: true,
+		},
+		{
+			name:             "local clock 5 minutes slow",
+			skew:             5 * time.Minute,
+			expiresAt:        serverTime.Add(1 * time.Minute),
+			wantRefresh:      true,
+			wantLocalVerdict: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokenString := createTestToken(t, privateKey, tt.expiresAt)
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Date", serverTime.UTC().Format(http.TimeFormat))
+					_ = json.NewEncoder(w).Encode(Response[CreateTokenResponse]{
+						Code:    200,
+						Success: true,
+						Data: CreateTokenResponse{
+							Token:        tokenString,
+							RefreshToken: "refresh-token-123",
+						},
+					})
+				}),
+			)
+			defer server.Close()
+
+			localTime := serverTime.Add(-tt.skew)
+			client := New("test-org", publicKey,
+				WithHTTPClient(server.Client()),
+				WithBaseURL(mustParseURL(server.URL)),
+			)
+			client.clock.now = func() time.Time { return localTime }
+
+			if err := client.TokenCreate(context.Background(), "access-key"); err != nil {
+				t.Fatalf("TokenCreate() error = %v", err)
+			}
+
+			if got := client.ClockSkew(); got != tt.skew {
+				t.Errorf("ClockSkew() = %v, want %v", got, tt.skew)
+			}
+
+			if got := client.auth.shouldRefresh(client.clock.serverNow()); got != tt.wantRefresh {
+				t.Errorf("shouldRefresh(server time) = %v, want %v", got, tt.wantRefresh)
+			}
+
+			if got := client.auth.shouldRefresh(localTime); got != tt.wantLocalVerdict {
+				t.Errorf("shouldRefresh(local time) = %v, want %v", got, tt.wantLocalVerdict)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Command fairgate is a small command line client for the Fairgate Standard API,
+// useful to manually verify credentials and connectivity.
+//
+// Usage:
+//
+//	fairgate [flags] token
+//	fairgate [flags] contact get <id>
+//	fairgate [flags] contacts list [--limit N] [--status STATUS]
+//	fairgate [flags] contacts export --csv
+//
+// The organisation ID, access key, and public key file can also be provided
+// using the FAIRGATE_OID, FAIRGATE_ACCESS_KEY, and FAIRGATE_PUBLIC_KEY_FILE
+// environment variables.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"thde.io/fairgate"
+)
+
+var errUsage = errors.New(
+	"usage: fairgate [flags] token | contact get <id> | contacts list | contacts export --csv",
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:], os.Stdout, os.Stderr, os.Getenv); err != nil {
+		fmt.Fprintln(os.Stderr, "fairgate:", err)
+		stop()
+		os.Exit(1)
+	}
+}
+
+// run executes the command line given by args.
+func run(
+	ctx context.Context,
+	args []string,
+	stdout, stderr io.Writer,
+	getenv func(string) string,
+) error {
+	fs := flag.NewFlagSet("fairgate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	oid := fs.String("oid", getenv("FAIRGATE_OID"), "organisation ID")
+	accessKey := fs.String("access-key", getenv("FAIRGATE_ACCESS_KEY"), "access key")
+	keyFile := fs.String(
+		"public-key",
+		getenv("FAIRGATE_PUBLIC_KEY_FILE"),
+		"path to the PEM encoded public key",
+	)
+	test := fs.Bool("test", false, "use the Fairgate test endpoint")
+	baseURL := fs.String("base-url", "", "custom API base URL")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	client, err := newClient(*oid, *accessKey, *keyFile, *test, *baseURL)
+	if err != nil {
+		return err
+	}
+
+	err = dispatch(ctx, client, *accessKey, fs.Args(), stdout)
+	printRateLimit(stderr, client.RateLimit())
+
+	return err
+}
+
+// newClient creates the API client from the global flags.
+func newClient(
+	oid, accessKey, keyFile string,
+	test bool,
+	baseURL string,
+) (*fairgate.Client, error) {
+	if oid == "" {
+		return nil, errors.New("missing organisation ID: set -oid or FAIRGATE_OID")
+	}
+	if accessKey == "" {
+		return nil, errors.New("missing access key: set -access-key or FAIRGATE_ACCESS_KEY")
+	}
+	if keyFile == "" {
+		return nil, errors.New("missing public key: set -public-key or FAIRGATE_PUBLIC_KEY_FILE")
+	}
+
+	pem, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("read public key: %w", err)
+	}
+	key, err := jwt.ParseECPublicKeyFromPEM(pem)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+
+	opts := []fairgate.ClientOption{
+		fairgate.WithAccessKey(accessKey),
+		fairgate.WithUserAgent("fairgate-cli"),
+	}
+	if test {
+		opts = append(opts, fairgate.WithTest())
+	}
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("parse base URL: %w", err)
+		}
+		opts = append(opts, fairgate.WithBaseURL(u))
+	}
+
+	return fairgate.New(oid, key, opts...), nil
+}
+
+// dispatch runs the subcommand given by args.
+func dispatch(
+	ctx context.Context,
+	client *fairgate.Client,
+	accessKey string,
+	args []string,
+	stdout io.Writer,
+) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch {
+	case args[0] == "token":
+		return runToken(ctx, client, accessKey, stdout)
+	case len(args) >= 2 && args[0] == "contact" && args[1] == "get":
+		return runContactGet(ctx, client, args[2:], stdout)
+	case len(args) >= 2 && args[0] == "contacts" && args[1] == "list":
+		return runContactsList(ctx, client, args[2:], stdout)
+	case len(args) >= 2 && args[0] == "contacts" && args[1] == "export":
+		return runContactsExport(ctx, client, args[2:], stdout)
+	default:
+		return errUsage
+	}
+}
+
+// runToken creates a token to verify the credentials.
+func runToken(
+	ctx context.Context,
+	client *fairgate.Client,
+	accessKey string,
+	stdout io.Writer,
+) error {
+	if err := client.TokenCreate(ctx, accessKey); err != nil {
+		return fmt.Errorf("create token: %w", err)
+	}
+
+	_, err := fmt.Fprintln(stdout, "token created")
+	return err
+}
+
+// runContactGet prints a single contact as JSON.
+func runContactGet(
+	ctx context.Context,
+	client *fairgate.Client,
+	args []string,
+	stdout io.Writer,
+) error {
+	if len(args) != 1 {
+		return errors.New("usage: fairgate contact get <id>")
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid contact ID %q: %w", args[0], err)
+	}
+
+	resp, err := client.Contact(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get contact: %w", err)
+	}
+
+	enc := json.NewEncoder(stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(resp.Data)
+}
+
+// runContactsList prints one line per contact.
+func runContactsList(
+	ctx context.Context,
+	client *fairgate.Client,
+	args []string,
+	stdout io.Writer,
+) error {
+	fs := flag.NewFlagSet("contacts list", flag.ContinueOnError)
+	limit := fs.Int("limit", 10, "maximum number of contacts to list, 0 for all")
+	status := fs.String("status", "", "only list contacts with this status")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	n := 0
+	for contact, err := range client.ContactsIter(ctx) {
+		if err != nil {
+			return fmt.Errorf("list contacts: %w", err)
+		}
+		if *status != "" && string(contact.Status) != *status {
+			continue
+		}
+
+		_, err := fmt.Fprintf(stdout, "%d\t%s\t%s\t%s\n",
+			contact.Basefields.ContactID,
+			contact.Basefields.FirstName,
+			contact.Basefields.LastName,
+			contact.Status,
+		)
+		if err != nil {
+			return err
+		}
+
+		n++
+		if *limit > 0 && n >= *limit {
+			break
+		}
+	}
+
+	return nil
+}
+
+// runContactsExport writes all contacts to stdout.
+func runContactsExport(
+	ctx context.Context,
+	client *fairgate.Client,
+	args []string,
+	stdout io.Writer,
+) error {
+	fs := flag.NewFlagSet("contacts export", flag.ContinueOnError)
+	csv := fs.Bool("csv", false, "export as CSV")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !*csv {
+		return errors.New("usage: fairgate contacts export --csv")
+	}
+
+	if _, err := client.ContactsWriteCSV(ctx, stdout); err != nil {
+		return fmt.Errorf("export contacts: %w", err)
+	}
+
+	return nil
+}
+
+// printRateLimit reports whether the client is currently rate limited.
+func printRateLimit(w io.Writer, rl fairgate.RateLimit) {
+	if !rl.Limited(time.Now()) {
+		return
+	}
+
+	fmt.Fprintf(w, "rate limited until %s\n", rl.RetryAfter.Format(time.RFC3339))
+}
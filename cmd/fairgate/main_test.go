@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"thde.io/fairgate"
+	"thde.io/fairgate/fairgatetest"
+)
+
+// newFakeServer returns a fake Fairgate API and the path to its public key.
+func newFakeServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+
+	privateKey, publicKey, err := fairgatetest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "public.pem")
+	err = os.WriteFile(
+		keyFile,
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		0o600,
+	)
+	if err != nil {
+		t.Fatalf("failed to write public key: %v", err)
+	}
+
+	contacts := []fairgate.Contact{
+		{
+			Basefields: fairgate.ContactBasefields{
+				ContactID: 1,
+				FirstName: "Anna",
+				LastName:  "Muster",
+			},
+			Status: fairgate.ContactStatusActive,
+		},
+		{
+			Basefields: fairgate.ContactBasefields{
+				ContactID: 2,
+				FirstName: "Beat",
+				LastName:  "Beispiel",
+			},
+			Status: fairgate.ContactStatusArchived,
+		},
+		{
+			Basefields: fairgate.ContactBasefields{
+				ContactID: 3,
+				FirstName: "Carla",
+				LastName:  "Test",
+			},
+			Status: fairgate.ContactStatusActive,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"POST /fsa/v1.1/auth/create/{oid}/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			var req fairgate.CreateTokenRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.AccessKey != "secret" {
+				_ = json.NewEncoder(w).
+					Encode(fairgate.Response[any]{Code: 401, Message: "invalid access key"})
+				return
+			}
+
+			token, err := fairgatetest.SignToken(
+				privateKey,
+				fairgatetest.Claims(),
+				time.Now().Add(time.Hour),
+			)
+			if err != nil {
+				t.Errorf("failed to sign token: %v", err)
+			}
+
+			_ = json.NewEncoder(w).Encode(fairgate.Response[fairgate.CreateTokenResponse]{
+				Code:    200,
+				Success: true,
+				Data:    fairgate.CreateTokenResponse{Token: token, RefreshToken: "refresh"},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+		func(w http.ResponseWriter, r *http.Request) {
+			id, _ := strconv.Atoi(r.PathValue("id"))
+			for _, contact := range contacts {
+				if contact.Basefields.ContactID.Int() == id {
+					_ = json.NewEncoder(w).
+						Encode(fairgate.Response[fairgate.Contact]{Success: true, Data: contact})
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		},
+	)
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/contacts/extended",
+		func(w http.ResponseWriter, r *http.Request) {
+			// Serve a single contact per page.
+			pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+			var page []fairgate.Contact
+			if pageNo >= 1 && pageNo <= len(contacts) {
+				page = contacts[pageNo-1 : pageNo]
+			}
+
+			_ = json.NewEncoder(w).Encode(fairgate.Response[fairgate.ContactsList]{
+				Success: true,
+				Data: fairgate.ContactsList{
+					Pagination: fairgate.Pagination{
+						TotalRecords: fairgate.FlexInt(len(contacts)),
+						TotalPages:   fairgate.FlexInt(len(contacts)),
+						PageNo:       fairgate.FlexInt(pageNo),
+						PageLimit:    1,
+					},
+					Contacts: page,
+				},
+			})
+		},
+	)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server, keyFile
+}
+
+func TestRun(t *testing.T) {
+	server, keyFile := newFakeServer(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		env     map[string]string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "token",
+			args: []string{"token"},
+			want: "token created\n",
+		},
+		{
+			name:    "token with invalid access key",
+			args:    []string{"-access-key", "wrong", "token"},
+			wantErr: "invalid access key",
+		},
+		{
+			name: "contact get",
+			args: []string{"contact", "get", "2"},
+			want: `"first_name": "Beat"`,
+		},
+		{
+			name:    "contact get invalid id",
+			args:    []string{"contact", "get", "two"},
+			wantErr: "invalid contact ID",
+		},
+		{
+			name: "contacts list with limit",
+			args: []string{"contacts", "list", "--limit", "2"},
+			want: "1\tAnna\tMuster\tactive\n2\tBeat\tBeispiel\tarchived\n",
+		},
+		{
+			name: "contacts list with status",
+			args: []string{"contacts", "list", "--status", "active"},
+			want: "1\tAnna\tMuster\tactive\n3\tCarla\tTest\tactive\n",
+		},
+		{
+			name: "contacts export",
+			args: []string{"contacts", "export", "--csv"},
+			want: "3,Carla,Test,,,active,",
+		},
+		{
+			name:    "contacts export without format",
+			args:    []string{"contacts", "export"},
+			wantErr: "--csv",
+		},
+		{
+			name:    "missing oid",
+			args:    []string{"token"},
+			env:     map[string]string{"FAIRGATE_OID": ""},
+			wantErr: "FAIRGATE_OID",
+		},
+		{
+			name:    "unknown command",
+			args:    []string{"invoices"},
+			wantErr: "usage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{
+				"FAIRGATE_OID":             "test-org",
+				"FAIRGATE_ACCESS_KEY":      "secret",
+				"FAIRGATE_PUBLIC_KEY_FILE": keyFile,
+			}
+			for k, v := range tt.env {
+				env[k] = v
+			}
+
+			args := append([]string{"-base-url", server.URL}, tt.args...)
+			var stdout, stderr bytes.Buffer
+			err := run(context.Background(), args, &stdout, &stderr, func(key string) string {
+				return env[key]
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("run() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("run() error = %v, stderr: %s", err, stderr.String())
+			}
+
+			if !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("run() output = %q, want %q", stdout.String(), tt.want)
+			}
+		})
+	}
+}
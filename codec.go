@@ -0,0 +1,142 @@
+package fairgate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// JSONCodec encodes request bodies and decodes response bodies, see
+// [WithJSONCodec]. The codec must honor [json.Marshaler] and [json.Unmarshaler]
+// as well as the struct tags of [encoding/json], as types such as [Time] and
+// [FlexInt] rely on them. Use [CheckJSONCodec] to verify a codec.
+//
+// If the codec also implements Unmarshal(data []byte, v any) error, it is used
+// instead of NewDecoder to decode buffered data.
+type JSONCodec interface {
+	Marshal(v any) ([]byte, error)
+	NewDecoder(r io.Reader) JSONDecoder
+}
+
+// JSONDecoder decodes a JSON value, such as [json.Decoder].
+type JSONDecoder interface {
+	Decode(v any) error
+}
+
+// WithJSONCodec sets the codec used to encode requests and decode responses,
+// e.g. to use a faster JSON library for large syncs. Defaults to
+// [encoding/json].
+func WithJSONCodec(codec JSONCodec) ClientOption {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
+
+// standardJSONCodec is the [JSONCodec] using [encoding/json].
+type standardJSONCodec struct{}
+
+func (standardJSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (standardJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	return json.NewDecoder(r)
+}
+
+func (standardJSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// jsonCodec returns the configured codec.
+func (c *Client) jsonCodec() JSONCodec {
+	if c.codec == nil {
+		return standardJSONCodec{}
+	}
+	return c.codec
+}
+
+// unmarshalJSON decodes data into v using codec.
+func unmarshalJSON(codec JSONCodec, data []byte, v any) error {
+	if u, ok := codec.(interface{ Unmarshal([]byte, any) error }); ok {
+		return u.Unmarshal(data, v)
+	}
+
+	return codec.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// codecConformance are the responses a [JSONCodec] must decode like
+// [encoding/json], with the path of their request.
+var codecConformance = []struct {
+	path string
+	data string
+}{
+	{
+		path: "/fsa/v2.0/contact/org/contacts/1/extended",
+		data: `{"code":"200","success":true,"message":"","data":{` +
+			`"basefields":{"contact_id":"1","first_name":"Anna","last_name":"Muster",` +
+			`"birthdate":"1990-05-17","last_update":"2024-03-01 12:30:00",` +
+			`"first_joining_date":"2010-01-01T00:00:00Z","unknown":[1,{"a":null}]},` +
+			`"status":"active","corr_address":{"postale_code":"8000","country":"CH"},` +
+			`"membership":null}}`,
+	},
+	{
+		path: "/fsa/v2.0/contact/org/contacts/2/extended",
+		data: `{"code":404,"success":false,"message":"Kontakt nicht gefunden ä",` +
+			`"data":[],"errors":[{"field":"contact_id","message":"unknown"}]}`,
+	},
+	{
+		path: "/fsa/v1.1/contact/org/contacts/3",
+		data: `{"code":400,"success":false,"message":"Validation failed",` +
+			`"data":[],"errors":{"last_name":["is required"]}}`,
+	},
+}
+
+// CheckJSONCodec verifies that codec decodes responses and encodes requests
+// like [encoding/json], e.g. in a test of the codec passed to
+// [WithJSONCodec].
+func CheckJSONCodec(codec JSONCodec) error {
+	for _, tt := range codecConformance {
+		var got, want Response[Contact]
+		if err := unmarshalResponse(codec, tt.path, []byte(tt.data), &got); err != nil {
+			return fmt.Errorf("decode %s: %w", tt.path, err)
+		}
+		if err := unmarshalResponse(
+			standardJSONCodec{},
+			tt.path,
+			[]byte(tt.data),
+			&want,
+		); err != nil {
+			return fmt.Errorf("decode %s with encoding/json: %w", tt.path, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			return fmt.Errorf("decode %s: got %+v, want %+v", tt.path, got, want)
+		}
+	}
+
+	var contact Response[Contact]
+	if err := json.Unmarshal([]byte(codecConformance[0].data), &contact); err != nil {
+		return err
+	}
+	got, err := codec.Marshal(contact.Data)
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+	want, err := json.Marshal(contact.Data)
+	if err != nil {
+		return fmt.Errorf("encode with encoding/json: %w", err)
+	}
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		return fmt.Errorf("encode: invalid JSON %s: %w", got, err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		return fmt.Errorf("encode: got %s, want %s", got, want)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"thde.io/fairgate/fairgatetest"
+)
+
+// decoderJSONCodec is [encoding/json] without Unmarshal, so buffered data is
+// decoded using NewDecoder.
+type decoderJSONCodec struct{}
+
+func (decoderJSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (decoderJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	return json.NewDecoder(r)
+}
+
+// countingJSONCodec counts the encoded and decoded values.
+type countingJSONCodec struct {
+	marshaled, decoded atomic.Int64
+}
+
+func (c *countingJSONCodec) Marshal(v any) ([]byte, error) {
+	c.marshaled.Add(1)
+	return json.Marshal(v)
+}
+
+func (c *countingJSONCodec) NewDecoder(r io.Reader) JSONDecoder {
+	c.decoded.Add(1)
+	return json.NewDecoder(r)
+}
+
+// benchmarkCodecs are the codecs compared by [BenchmarkJSONCodec]. Add an
+// alternate codec here to compare it locally.
+var benchmarkCodecs = []struct {
+	name  string
+	codec JSONCodec
+}{
+	{"encoding/json", standardJSONCodec{}},
+	{"encoding/json decoder", decoderJSONCodec{}},
+}
+
+func TestCheckJSONCodec(t *testing.T) {
+	for _, tt := range benchmarkCodecs {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckJSONCodec(tt.codec); err != nil {
+				t.Errorf("CheckJSONCodec() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestClient_WithJSONCodec(t *testing.T) {
+	var gotBody map[string]any
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		writeJSON(w, http.StatusOK, Response[Contact]{
+			Success: true,
+			Data:    Contact{Basefields: ContactBasefields{ContactID: 1, FirstName: "Anna"}},
+		})
+	}))
+	codec := &countingJSONCodec{}
+	WithJSONCodec(codec)(client)
+
+	got, err := Do[Contact](
+		context.Background(),
+		client,
+		http.MethodPost,
+		"/fsa/v2.0/contact/test-org/contacts",
+		nil,
+		map[string]string{"first_name": "Anna"},
+	)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if got.Data.Basefields.FirstName != "Anna" || gotBody["first_name"] != "Anna" {
+		t.Errorf("Do() = %+v with body %v, want Anna", got.Data, gotBody)
+	}
+	// The envelope and its data are decoded separately.
+	if codec.marshaled.Load() != 1 || codec.decoded.Load() != 2 {
+		t.Errorf("codec marshaled %d and decoded %d values, want 1 and 2",
+			codec.marshaled.Load(), codec.decoded.Load())
+	}
+}
+
+// BenchmarkJSONCodec compares decoding a page of contacts of about 1 MB by the
+// codecs of benchmarkCodecs.
+func BenchmarkJSONCodec(b *testing.B) {
+	var data bytes.Buffer
+	data.WriteString(`{"code":200,"success":true,"message":"","data":[`)
+	for i, contact := range fairgatetest.NewContactGenerator(1).GenerateContacts(5000) {
+		if i > 0 {
+			data.WriteByte(',')
+		}
+		data.Write(contact)
+		if data.Len() >= 1<<20 {
+			break
+		}
+	}
+	data.WriteString(`]}`)
+
+	for _, bc := range benchmarkCodecs {
+		b.Run(bc.name, func(b *testing.B) {
+			b.SetBytes(int64(data.Len()))
+			b.ReportAllocs()
+			for b.Loop() {
+				var resp Response[[]Contact]
+				err := unmarshalResponse(
+					bc.codec,
+					"/fsa/v2.0/contact/org/contacts/extended",
+					data.Bytes(),
+					&resp,
+				)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package fairgate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressionThreshold is the request body size in bytes above which bodies
+// are compressed when compression is enabled.
+const compressionThreshold = 1024
+
+// WithCompression enables gzip compression of responses and of request bodies
+// larger than 1 KiB.
+func WithCompression() ClientOption {
+	return func(c *Client) {
+		c.compression = true
+	}
+}
+
+// gzipBytes compresses data using gzip.
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// gzipReadCloser decompresses a response body and closes the underlying body.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close closes the gzip reader and the underlying body.
+func (g *gzipReadCloser) Close() error {
+	zerr := g.Reader.Close()
+	if err := g.body.Close(); err != nil {
+		return err
+	}
+
+	return zerr
+}
+
+// decompressResponse transparently decompresses gzip encoded response bodies.
+// Go only decompresses automatically if the transport added the
+// Accept-Encoding header itself, which is not the case for explicit requests.
+func decompressResponse(resp *http.Response) error {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return nil
+	}
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if errors.Is(err, io.EOF) {
+		// Empty body, nothing to decompress.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("decompress response: %w", err)
+	}
+
+	resp.Body = &gzipReadCloser{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package fairgate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_WithCompression_Response(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+
+		zw := gzip.NewWriter(w)
+		_ = json.NewEncoder(zw).Encode(Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{TotalRecords: 2, TotalPages: 1, PageNo: 1},
+				Contacts: []Contact{
+					{Basefields: ContactBasefields{ContactID: 1}},
+					{Basefields: ContactBasefields{ContactID: 2}},
+				},
+			},
+		})
+		_ = zw.Close()
+	}), WithCompression())
+
+	list, err := client.Contacts(context.Background(), PageParams{PageNo: 1})
+	if err != nil {
+		t.Fatalf("Contacts() error = %v", err)
+	}
+
+	if len(list.Contacts) != 2 {
+		t.Fatalf("expected 2 contacts, got %d", len(list.Contacts))
+	}
+	if list.Contacts[1].Basefields.ContactID != 2 {
+		t.Errorf("ContactID = %d, want 2", list.Contacts[1].Basefields.ContactID)
+	}
+}
+
+func TestClient_WithCompression_RequestBody(t *testing.T) {
+	sourceIDs := make([]int, 500)
+	for i := range sourceIDs {
+		sourceIDs[i] = i + 2
+	}
+
+	var bodies [][]byte
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Encoding"); got != "gzip" {
+			t.Errorf("Content-Encoding = %q, want gzip", got)
+		}
+
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("failed to read compressed body: %v", err)
+			return
+		}
+		body, err := io.ReadAll(zr)
+		if err != nil {
+			t.Errorf("failed to decompress body: %v", err)
+			return
+		}
+		bodies = append(bodies, body)
+
+		if len(bodies) == 1 {
+			w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Response[any]{Success: true})
+	}), WithCompression(), WithDestructiveOps())
+
+	if err := client.ContactMerge(context.Background(), 1, sourceIDs); err != nil {
+		t.Fatalf("ContactMerge() error = %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(bodies))
+	}
+	if !bytes.Equal(bodies[0], bodies[1]) {
+		t.Error("retried body differs from original body")
+	}
+
+	var req ContactMergeRequest
+	if err := json.Unmarshal(bodies[1], &req); err != nil {
+		t.Fatalf("failed to decode request: %v", err)
+	}
+	if len(req.SourceContactIDs) != len(sourceIDs) {
+		t.Errorf("got %d source IDs, want %d", len(req.SourceContactIDs), len(sourceIDs))
+	}
+}
+
+func TestClient_WithCompression_SmallRequestBody(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Content-Encoding"); got != "" {
+			t.Errorf("Content-Encoding = %q, want none for small bodies", got)
+		}
+
+		var req ContactMergeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+
+		writeJSON(w, http.StatusOK, Response[any]{Success: true})
+	}), WithCompression(), WithDestructiveOps())
+
+	if err := client.ContactMerge(context.Background(), 1, []int{2}); err != nil {
+		t.Fatalf("ContactMerge() error = %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package fairgate
+
+import (
+	"io"
+	"net/http"
+	"sync"
+)
+
+// WithMaxConcurrentRequests limits the number of requests in flight to n,
+// shared by all calls and iterators of the client. A request holds its slot
+// from sending until its response body is closed, and waiting for a slot is
+// aborted once the request context is done.
+//
+// Requests waiting for a rate limit to pass don't hold a slot, so once the
+// limit passes at most n of them are sent at the same time.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		c.slots = nil
+		if n > 0 {
+			c.slots = make(requestSlots, n)
+		}
+	}
+}
+
+// requestSlots is a semaphore limiting the number of requests in flight.
+// The nil value doesn't limit requests.
+type requestSlots chan struct{}
+
+// roundTrip sends req using the HTTP client once a request slot is available.
+// The slot is released when the response body is closed.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	if c.slots == nil {
+		return c.guardedRoundTrip(req)
+	}
+
+	select {
+	case c.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	resp, err := c.guardedRoundTrip(req)
+	if err != nil || resp == nil || resp.Body == nil {
+		<-c.slots
+		return resp, err
+	}
+	resp.Body = &slotBody{ReadCloser: resp.Body, release: func() { <-c.slots }}
+
+	return resp, nil
+}
+
+// slotBody releases a request slot once the body is closed.
+type slotBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+// Close implements [io.Closer].
+func (b *slotBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
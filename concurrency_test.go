@@ -0,0 +1,100 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// inFlightHandler wraps handler, recording the maximum number of requests
+// served concurrently.
+func inFlightHandler(handler http.Handler, maxInFlight *atomic.Int32) http.Handler {
+	var inFlight atomic.Int32
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			m := maxInFlight.Load()
+			if n <= m || maxInFlight.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func TestWithMaxConcurrentRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		iterators int
+	}{
+		{name: "one slot", limit: 1, iterators: 2},
+		{name: "two slots", limit: 2, iterators: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var maxInFlight atomic.Int32
+			client, _ := newTestClient(
+				t,
+				inFlightHandler(contactsHandler(50), &maxInFlight),
+				WithMaxConcurrentRequests(tt.limit),
+			)
+
+			var wg sync.WaitGroup
+			for range tt.iterators {
+				wg.Go(func() {
+					n := 0
+					for _, err := range client.ContactsIter(context.Background(), WithPageLimit(5)) {
+						if err != nil {
+							t.Errorf("ContactsIter() error = %v", err)
+							return
+						}
+						n++
+					}
+					if n != 50 {
+						t.Errorf("ContactsIter() yielded %d contacts, want 50", n)
+					}
+				})
+			}
+			wg.Wait()
+
+			if got := maxInFlight.Load(); got > int32(tt.limit) {
+				t.Errorf("max in-flight requests = %d, want at most %d", got, tt.limit)
+			}
+		})
+	}
+}
+
+func TestWithMaxConcurrentRequests_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}), WithMaxConcurrentRequests(1))
+	defer close(release)
+
+	go func() {
+		_, _ = client.Contact(context.Background(), 1)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Wait until the first request holds the slot.
+	for len(client.slots) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	if _, err := client.Contact(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Contact() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
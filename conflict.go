@@ -0,0 +1,144 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ErrConflict is returned when the API rejects a modification because the
+// resource changed since it was fetched.
+var ErrConflict = errors.New("conflicting modification")
+
+// ConflictError is returned when the API rejects a modification because the
+// resource changed since it was fetched. It matches [ErrConflict] and [ErrStatus].
+type ConflictError struct {
+	// ServerLastUpdate is the current last update time of the resource on the server.
+	ServerLastUpdate Time
+	// Fields lists the conflicting fields, if reported by the API.
+	Fields []string
+
+	err error
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	msg := e.err.Error()
+	if !e.ServerLastUpdate.IsZero() {
+		msg += fmt.Sprintf(" (server last update %s)", e.ServerLastUpdate.Format(time.RFC3339))
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying errors.
+func (e *ConflictError) Unwrap() []error {
+	return []error{ErrConflict, e.err}
+}
+
+// conflictDetails represents the data of a conflict response.
+type conflictDetails struct {
+	LastUpdate        Time     `json:"last_update"`
+	ConflictingFields []string `json:"conflicting_fields,omitempty"`
+}
+
+// newConflictError creates a [ConflictError] from a conflict response envelope.
+func newConflictError(err error, envelope Response[json.RawMessage]) *ConflictError {
+	conflictErr := &ConflictError{err: err}
+
+	var details conflictDetails
+	if len(envelope.Data) > 0 && json.Unmarshal(envelope.Data, &details) == nil {
+		conflictErr.ServerLastUpdate = details.LastUpdate
+		conflictErr.Fields = details.ConflictingFields
+	}
+
+	if len(conflictErr.Fields) == 0 {
+		for _, e := range envelope.Errors {
+			if e.Field != "" {
+				conflictErr.Fields = append(conflictErr.Fields, e.Field)
+			}
+		}
+	}
+
+	return conflictErr
+}
+
+// ContactUpdate represents the changes to apply to a contact.
+// Sections left nil are not modified.
+type ContactUpdate struct {
+	// LastUpdate is the last update time of the contact the changes are based on.
+	// If set, the update is rejected with a [ConflictError] if the contact
+	// changed since.
+	LastUpdate Time `json:"last_update,omitzero"`
+	// Basefields are the base fields of the contact.
+	Basefields *ContactBasefields `json:"basefields,omitempty"`
+	// Communication is the contact's communication information.
+	Communication *Communication `json:"communication,omitempty"`
+	// CorrAddress is the contact's correspondence address.
+	CorrAddress *Address `json:"corr_address,omitempty"`
+	// InvoiceAddress is the contact's invoice address.
+	InvoiceAddress *Address `json:"invoice_address,omitempty"`
+}
+
+// ContactUpdate updates a contact.
+func (c *Client) ContactUpdate(ctx context.Context, contactID int, update ContactUpdate) error {
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/%d", url.PathEscape(c.oid), contactID),
+		nil,
+		update,
+	)
+	if err != nil {
+		return err
+	}
+
+	var result Response[json.RawMessage]
+	_, err = c.doJSON(req, &result)
+	return err
+}
+
+// ContactUpdateWithRetry fetches a contact, applies the changes returned by mutate,
+// and updates the contact. If the contact was modified concurrently, the contact
+// is fetched again and mutate is reapplied, up to maxAttempts times.
+// Unless mutate sets it, the update is based on the LastUpdate of the fetched contact.
+// If mutate panics, a [CallbackPanicError] is returned.
+func (c *Client) ContactUpdateWithRetry(
+	ctx context.Context,
+	contactID int,
+	mutate func(current Contact) (ContactUpdate, error),
+	maxAttempts int,
+) error {
+	var err error
+	for range max(maxAttempts, 1) {
+		var current *Response[Contact]
+		current, err = c.Contact(ctx, contactID)
+		if err != nil {
+			return err
+		}
+
+		var update ContactUpdate
+		if panicErr := callSafely("mutate", func() {
+			update, err = mutate(current.Data)
+		}); panicErr != nil {
+			return panicErr
+		}
+		if err != nil {
+			return err
+		}
+		if update.LastUpdate.IsZero() {
+			update.LastUpdate = current.Data.Basefields.LastUpdate
+		}
+
+		err = c.ContactUpdate(ctx, contactID, update)
+		if !errors.Is(err, ErrConflict) {
+			return err
+		}
+	}
+
+	return err
+}
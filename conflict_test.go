@@ -0,0 +1,143 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestClient_ContactUpdate_Conflict(t *testing.T) {
+	serverLastUpdate := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusConflict, Response[conflictDetails]{
+			Code:    409,
+			Message: "contact was modified",
+			Data: conflictDetails{
+				LastUpdate:        Time{serverLastUpdate},
+				ConflictingFields: []string{"basefields.last_name"},
+			},
+		})
+	}))
+
+	err := client.ContactUpdate(context.Background(), 1, ContactUpdate{
+		Basefields: &ContactBasefields{LastName: "Muster"},
+	})
+
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("ContactUpdate() error = %v, want ErrConflict", err)
+	}
+	if !errors.Is(err, ErrStatus) {
+		t.Errorf("ContactUpdate() error = %v, want ErrStatus", err)
+	}
+
+	var conflictErr *ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Fatalf("ContactUpdate() error = %v, want *ConflictError", err)
+	}
+	if !conflictErr.ServerLastUpdate.Equal(serverLastUpdate) {
+		t.Errorf("ServerLastUpdate = %v, want %v", conflictErr.ServerLastUpdate, serverLastUpdate)
+	}
+	if !slices.Equal(conflictErr.Fields, []string{"basefields.last_name"}) {
+		t.Errorf("Fields = %v, want [basefields.last_name]", conflictErr.Fields)
+	}
+}
+
+func TestClient_ContactUpdateWithRetry(t *testing.T) {
+	versions := []Contact{
+		{Basefields: ContactBasefields{
+			ContactID:  1,
+			FirstName:  "Anna",
+			LastUpdate: Time{time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)},
+		}},
+		{Basefields: ContactBasefields{
+			ContactID:  1,
+			FirstName:  "Anne",
+			LastUpdate: Time{time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+		}},
+	}
+
+	fetches, updates := 0, 0
+	var updatesSent []ContactUpdate
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true, Data: versions[fetches]})
+			fetches++
+		case http.MethodPut:
+			var update ContactUpdate
+			_ = json.NewDecoder(r.Body).Decode(&update)
+			updatesSent = append(updatesSent, update)
+			updates++
+
+			if updates == 1 {
+				writeJSON(w, http.StatusConflict, Response[conflictDetails]{
+					Code: 409,
+					Data: conflictDetails{LastUpdate: versions[1].Basefields.LastUpdate},
+				})
+				return
+			}
+			writeJSON(w, http.StatusOK, Response[any]{Code: 200, Success: true})
+		}
+	}))
+
+	var seen []string
+	err := client.ContactUpdateWithRetry(
+		context.Background(),
+		1,
+		func(current Contact) (ContactUpdate, error) {
+			seen = append(seen, current.Basefields.FirstName)
+			return ContactUpdate{
+				Communication: &Communication{PrimaryEmail: "anna@example.com"},
+			}, nil
+		},
+		3,
+	)
+	if err != nil {
+		t.Fatalf("ContactUpdateWithRetry() error = %v", err)
+	}
+
+	if !slices.Equal(seen, []string{"Anna", "Anne"}) {
+		t.Errorf("mutate saw %v, want [Anna Anne]", seen)
+	}
+	if len(updatesSent) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(updatesSent))
+	}
+	if !updatesSent[1].LastUpdate.Equal(versions[1].Basefields.LastUpdate.Time) {
+		t.Errorf(
+			"retry LastUpdate = %v, want %v",
+			updatesSent[1].LastUpdate,
+			versions[1].Basefields.LastUpdate,
+		)
+	}
+}
+
+func TestClient_ContactUpdateWithRetry_MaxAttempts(t *testing.T) {
+	updates := 0
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+			return
+		}
+
+		updates++
+		writeJSON(w, http.StatusConflict, Response[any]{Code: 409})
+	}))
+
+	err := client.ContactUpdateWithRetry(
+		context.Background(),
+		1,
+		func(current Contact) (ContactUpdate, error) { return ContactUpdate{}, nil },
+		2,
+	)
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("ContactUpdateWithRetry() error = %v, want ErrConflict", err)
+	}
+	if updates != 2 {
+		t.Errorf("expected 2 update attempts, got %d", updates)
+	}
+}
@@ -2,11 +2,12 @@ package fairgate
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"iter"
 	"net/http"
-
-	"github.com/google/go-querystring/query"
+	"net/url"
+	"slices"
 )
 
 // Contact represents the extended contact data structure.
@@ -28,10 +29,17 @@ type Contact struct {
 	ClubAssignments *ClubAssignments `json:"club_assignments,omitempty"`
 	// SubfedAssignments are available for federation only.
 	SubfedAssignments []SubFedAssignment `json:"subfed_assignments,omitempty"`
+
+	// fields holds the standard fields, see [Contact.LicenseNumber].
+	fields *standardFieldValues
 }
 
+// ErrCursorUnsupported is returned by [Client.ContactsIterByID] when the server
+// does not honour the contact ID sort order and lower bound.
+var ErrCursorUnsupported = errors.New("server does not support contact id cursor")
+
 type ContactsList struct {
-	Pagination `json:",inline"`
+	Pagination `          json:",inline"`
 	Contacts   []Contact `json:"contacts,omitempty"`
 }
 
@@ -51,7 +59,9 @@ type Communication struct {
 	PrimaryEmail string `json:"primary_email,omitempty"`
 	// Mobile is the mobile number of the contact.
 	Mobile string `json:"mobile,omitempty"`
-	// Handy2 is an additional handy number of the contact.
+	// Handy2 is an additional handy number of the contact. The API marks the
+	// field as deprecated without a replacement, and [WithDeprecationHandler]
+	// reports responses containing it.
 	Handy2 string `json:"handy2,omitempty"`
 	// EmailParent1 is the email address of the first parent.
 	EmailParent1 string `json:"email_parent_1,omitempty"`
@@ -61,6 +71,13 @@ type Communication struct {
 	Website string `json:"website,omitempty"`
 	// CorrespondenceLanguage is the language used for correspondence with the contact.
 	CorrespondenceLanguage Language `json:"correspondence_language,omitempty"`
+
+	// NormalizedMobile is Mobile in E.164 format.
+	// It is only populated by clients using [WithPhoneNormalization].
+	NormalizedMobile string `json:"-"`
+	// NormalizedHandy2 is Handy2 in E.164 format.
+	// It is only populated by clients using [WithPhoneNormalization].
+	NormalizedHandy2 string `json:"-"`
 }
 
 // ContactType defines the type of a contact.
@@ -100,7 +117,7 @@ const (
 // ContactBasefields represents the base fields of a contact.
 type ContactBasefields struct {
 	// ContactID is the unique ID per contact of an organisation like a club or a federation.
-	ContactID int `json:"contact_id,omitempty"`
+	ContactID FlexInt `json:"contact_id,omitempty"`
 	// FirstName is the first name of the contact.
 	FirstName string `json:"first_name,omitempty"`
 	// LastName is the last name of the contact.
@@ -115,8 +132,11 @@ type ContactBasefields struct {
 	CorrespondenceLanguage Language `json:"correspondence_language,omitempty"`
 	// Gender is the gender of the contact.
 	Gender Gender `json:"gender,omitempty"`
-	// LastUpdate is the date when the contact was last updated.
-	LastUpdate Time `json:"last_update"`
+	// Birthdate is the date of birth of the contact, if known.
+	Birthdate Time `json:"birthdate,omitzero"`
+	// LastUpdate is the date when the contact was last updated. It is omitted
+	// when zero, so updates do not send it.
+	LastUpdate Time `json:"last_update,omitzero"`
 }
 
 // Membership represents membership information.
@@ -166,7 +186,7 @@ type ClubAssignments struct {
 // Federation represents federation-specific data.
 type Federation struct {
 	// FederationContactID is the contact ID within the federation.
-	FederationContactID *int `json:"federation_contact_id,string,omitempty"`
+	FederationContactID *FlexInt `json:"federation_contact_id,omitempty"`
 	// FederationMembership is the fed membership of the contact.
 	FederationMembership string `json:"federation_membership,omitempty"`
 	// FederationFirstJoiningDate is the date and time when the contact first joined the federation.
@@ -191,48 +211,293 @@ type Address struct {
 	PostOfficeBox string `json:"post_office_box,omitempty"`
 }
 
-// Contact retrieves basic contact details
+// Contact retrieves basic contact details. For a contact merged into another
+// contact, it returns a [ContactMergedError], or the other contact if
+// [WithFollowMerges] is set.
+//
+// Archived contacts are returned, see [Contact.IsArchived], even if the server
+// reports them as failure but still returns the record with status
+// [ContactStatusArchived]. An error matching [ErrNotFound] is only returned if the
+// contact is gone.
 func (c *Client) Contact(ctx context.Context, contactID int) (*Response[Contact], error) {
-	path := fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/%d/extended", c.oid, contactID)
+	return c.contact(ctx, contactID, 0)
+}
 
-	req, err := c.newRequest(ctx, http.MethodGet, path, nil, nil)
+// contact implements Contact, following at most maxMergeHops-hops merges.
+func (c *Client) contact(ctx context.Context, contactID, hops int) (*Response[Contact], error) {
+	path := fmt.Sprintf(
+		"/fsa/v2.0/contact/%s/contacts/%d/extended",
+		url.PathEscape(c.oid),
+		contactID,
+	)
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, nil, nil)
 	if err != nil {
 		return nil, err
 	}
 
 	var result Response[Contact]
 	if _, err := c.doJSON(req, &result); err != nil {
-		return nil, err
+		if c.archivedContact(err, &result) {
+			return &result, nil
+		}
+		return c.followMerge(ctx, err, hops)
 	}
 
 	return &result, nil
 }
 
-// ContactsIter returns an iterator over all contacts.
-func (c *Client) ContactsIter(ctx context.Context) iter.Seq2[Contact, error] {
+// ContactsByIDs returns an iterator over the contacts with the given IDs,
+// fetching them one by one. It stops at the first error, unless the error is
+// skipped using [SkipForbidden]. Contacts failing with [ErrRecordForbidden] are
+// always skipped. Archived contacts are returned, see [Client.Contact].
+func (c *Client) ContactsByIDs(
+	ctx context.Context,
+	ids []int,
+	opts ...IterOption,
+) iter.Seq2[Contact, error] {
+	cfg := newIterConfig(opts)
+
+	return func(yield func(Contact, error) bool) {
+		for _, id := range ids {
+			resp, err := c.Contact(ctx, id)
+			if err != nil {
+				skip, skipErr := cfg.skip(id, err)
+				if skip {
+					continue
+				}
+				if skipErr != nil {
+					err = skipErr
+				}
+				yield(Contact{}, err)
+				return
+			}
+
+			if !yield(resp.Data, nil) {
+				return
+			}
+		}
+	}
+}
+
+// ContactsFilter restricts the contacts listed by [Client.ContactsFiltered] and
+// [Client.ContactsIter].
+type ContactsFilter struct {
+	// IncludeArchived also lists archived contacts, which are excluded by default.
+	IncludeArchived bool `url:"includeArchived,omitempty"`
+	// GroupID only lists members of the group. It is combined with GroupIDs.
+	GroupID int `url:"-"`
+	// GroupIDs only lists members of any of the groups.
+	GroupIDs []int `url:"groupIds,comma,omitempty"`
+}
+
+// normalized returns the filter with GroupID merged into GroupIDs.
+func (f ContactsFilter) normalized() ContactsFilter {
+	if f.GroupID != 0 && !slices.Contains(f.GroupIDs, f.GroupID) {
+		f.GroupIDs = append([]int{f.GroupID}, f.GroupIDs...)
+	}
+	f.GroupID = 0
+
+	return f
+}
+
+// ContactField is a section of the extended contact data.
+type ContactField string
+
+const (
+	FieldBasefields    ContactField = "basefields"
+	FieldCommunication ContactField = "communication"
+	FieldMembership    ContactField = "membership"
+	FieldAssignments   ContactField = "club_assignments"
+)
+
+// ContactsParams represents the parameters for listing contacts.
+type ContactsParams struct {
+	PageParams
+	ContactsFilter
+	// Fields restricts the sections returned for each contact to reduce the
+	// payload size. Omitted sections decode as zero values. All sections are
+	// returned if Fields is empty.
+	Fields []ContactField `url:"fields,comma,omitempty"`
+}
+
+// WithContactsFilter restricts the contacts yielded by [Client.ContactsIter]
+// and iterators built on it to filter.
+func WithContactsFilter(filter ContactsFilter) IterOption {
+	return func(c *iterConfig) {
+		c.contactsFilter = filter
+	}
+}
+
+// WithContactFields restricts the sections returned for the contacts yielded
+// by [Client.ContactsIter] and iterators built on it, see
+// [ContactsParams.Fields].
+func WithContactFields(fields ...ContactField) IterOption {
+	return func(c *iterConfig) {
+		c.contactFields = fields
+	}
+}
+
+// requireContactFields adds fields to the sections restricted by
+// [WithContactFields], if any, for iterators that depend on them.
+func requireContactFields(fields ...ContactField) IterOption {
+	return func(c *iterConfig) {
+		if len(c.contactFields) == 0 {
+			return
+		}
+		for _, field := range fields {
+			if !slices.Contains(c.contactFields, field) {
+				c.contactFields = append(slices.Clip(c.contactFields), field)
+			}
+		}
+	}
+}
+
+// ContactsIter returns an iterator over all contacts, restricted by
+// [WithContactsFilter].
+func (c *Client) ContactsIter(ctx context.Context, opts ...IterOption) iter.Seq2[Contact, error] {
+	cfg := newIterConfig(opts)
+
 	return iterate(ctx, func(ctx context.Context, p PageParams) ([]Contact, Pagination, error) {
-		list, err := c.Contacts(ctx, p)
+		list, err := c.ContactsFiltered(ctx, ContactsParams{
+			PageParams:     p,
+			ContactsFilter: cfg.contactsFilter,
+			Fields:         cfg.contactFields,
+		})
 		if err != nil {
 			return nil, Pagination{}, err
 		}
 		return list.Contacts, list.Pagination, nil
-	})
+	}, c.iterOptions(opts)...)
 }
 
-// Contacts retrieves contacts with extended data for an organization.
+// Contacts retrieves a page of contacts with extended data for an organization.
 func (c *Client) Contacts(ctx context.Context, params PageParams) (*ContactsList, error) {
-	v, err := query.Values(params)
+	return c.ContactsFiltered(ctx, ContactsParams{PageParams: params})
+}
+
+// ContactsFiltered retrieves a page of contacts like [Client.Contacts],
+// restricted by the filter and fields of params.
+func (c *Client) ContactsFiltered(
+	ctx context.Context,
+	params ContactsParams,
+) (*ContactsList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/extended", url.PathEscape(c.oid))
+	params.ContactsFilter = params.ContactsFilter.normalized()
+	v, err := encodeParams(path, params)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := c.newRequest(
-		ctx,
-		http.MethodGet,
-		fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/extended", c.oid),
-		v,
-		nil,
-	)
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[ContactsList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// ContactsCount returns the number of contacts matching filter. It requests a
+// single contact and returns the total reported by the server, without
+// decoding the contact.
+func (c *Client) ContactsCount(ctx context.Context, filter ContactsFilter) (int, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/extended", url.PathEscape(c.oid))
+	params := ContactsParams{
+		PageParams:     PageParams{PageNo: 1, PageLimit: 1},
+		ContactsFilter: filter.normalized(),
+	}
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var result Response[Pagination]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return 0, err
+	}
+
+	return result.Data.TotalRecords.Int(), nil
+}
+
+// contactsCursorParams represents the parameters for keyset pagination by contact ID.
+type contactsCursorParams struct {
+	PageLimit      int    `url:"pageLimit,omitempty"`
+	SortBy         string `url:"sortBy"`
+	SortOrder      string `url:"sortOrder"`
+	AfterContactID int    `url:"afterContactId,omitempty"`
+}
+
+// ContactsIterByID returns an iterator over all contacts ordered by contact ID.
+// Unlike [Client.ContactsIter], pages are requested by the last seen contact ID
+// instead of page number, so contacts added or removed during the iteration
+// don't cause other contacts to be skipped.
+// If the server ignores the sort order or lower bound, the iterator yields
+// [ErrCursorUnsupported].
+func (c *Client) ContactsIterByID(ctx context.Context) iter.Seq2[Contact, error] {
+	return func(yield func(Contact, error) bool) {
+		params := contactsCursorParams{
+			PageLimit: 100,
+			SortBy:    "contact_id",
+			SortOrder: "asc",
+		}
+
+		for {
+			list, err := c.contactsByCursor(ctx, params)
+			if err != nil {
+				yield(Contact{}, err)
+				return
+			}
+
+			for _, contact := range list.Contacts {
+				id := contact.Basefields.ContactID.Int()
+				if id <= params.AfterContactID {
+					yield(Contact{}, fmt.Errorf(
+						"%w: got contact %d after %d",
+						ErrCursorUnsupported,
+						id,
+						params.AfterContactID,
+					))
+					return
+				}
+				params.AfterContactID = id
+
+				if !yield(contact, nil) {
+					return
+				}
+			}
+
+			if len(list.Contacts) == 0 {
+				return
+			}
+			if list.TotalRecords.Int() > 0 && len(list.Contacts) >= list.TotalRecords.Int() {
+				return
+			}
+		}
+	}
+}
+
+// contactsByCursor retrieves the contacts following params.AfterContactID.
+func (c *Client) contactsByCursor(
+	ctx context.Context,
+	params contactsCursorParams,
+) (*ContactsList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/extended", url.PathEscape(c.oid))
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,65 @@
+package fairgate_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"thde.io/fairgate"
+	"thde.io/fairgate/fairgatetest"
+)
+
+func TestContact_DecodeGenerated(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []fairgatetest.GeneratorOption
+	}{
+		{"club", nil},
+		{"federation", []fairgatetest.GeneratorOption{fairgatetest.FederationMode()}},
+		{
+			"anomalies",
+			[]fairgatetest.GeneratorOption{fairgatetest.WithAnomalies(
+				fairgatetest.AnomalyMissingDates,
+				fairgatetest.AnomalyStringIDs,
+			)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator := fairgatetest.NewContactGenerator(7, tt.opts...)
+			for i, data := range generator.GenerateContacts(200) {
+				var contact fairgate.Contact
+				if err := json.Unmarshal(data, &contact); err != nil {
+					t.Fatalf("failed to decode %s: %v", data, err)
+				}
+
+				if want := fairgate.FlexInt(1001 + i); contact.Basefields.ContactID != want {
+					t.Errorf("ContactID = %d, want %d", contact.Basefields.ContactID, want)
+				}
+				if contact.Basefields.LastUpdate.IsZero() != (tt.name == "anomalies") {
+					t.Errorf("LastUpdate = %v in %s", contact.Basefields.LastUpdate, data)
+				}
+
+				person := contact.Basefields.ContactType == fairgate.ContactTypeSinglePerson
+				if person && contact.Basefields.Gender == "" {
+					t.Errorf("person without gender in %s", data)
+				}
+				if _, ok := contact.AgeOn(contact.Basefields.LastUpdate.Time); ok && !person {
+					t.Errorf("company with age in %s", data)
+				}
+
+				if federation := contact.FederationData != nil; federation != (tt.name == "federation") {
+					t.Errorf("FederationData = %+v in %s", contact.FederationData, data)
+				}
+				if contact.FederationData != nil {
+					if contact.ClubAssignments == nil || contact.ClubAssignments.Primary == nil {
+						t.Errorf("federation contact without primary club in %s", data)
+					}
+					if contact.Membership != nil {
+						t.Errorf("federation contact with club membership in %s", data)
+					}
+				}
+			}
+		})
+	}
+}
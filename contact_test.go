@@ -0,0 +1,523 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// mutatingContactsHandler serves contacts in pages of at most two items and
+// deletes contact 1 and inserts contact 7 after the first page was served.
+func mutatingContactsHandler(t *testing.T) http.Handler {
+	t.Helper()
+
+	var mu sync.Mutex
+	ids := []int{1, 2, 3, 4, 5, 6}
+	served := 0
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		const pageLimit = 2
+		q := r.URL.Query()
+
+		var page []int
+		var remaining int
+		if q.Get("sortBy") == "contact_id" {
+			after, _ := strconv.Atoi(q.Get("afterContactId"))
+			i := 0
+			for i < len(ids) && ids[i] <= after {
+				i++
+			}
+			remaining = len(ids) - i
+			page = ids[i:min(i+pageLimit, len(ids))]
+		} else {
+			pageNo, _ := strconv.Atoi(q.Get("pageNo"))
+			start := min((pageNo-1)*pageLimit, len(ids))
+			remaining = len(ids)
+			page = ids[start:min(start+pageLimit, len(ids))]
+		}
+
+		contacts := make([]Contact, 0, len(page))
+		for _, id := range page {
+			contacts = append(
+				contacts,
+				Contact{Basefields: ContactBasefields{ContactID: FlexInt(id)}},
+			)
+		}
+
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{
+					TotalRecords: FlexInt(remaining),
+					TotalPages:   FlexInt((remaining + pageLimit - 1) / pageLimit),
+				},
+				Contacts: contacts,
+			},
+		})
+
+		served++
+		if served == 1 {
+			ids = append(slices.Clone(ids[1:]), 7)
+		}
+	})
+}
+
+func TestClient_ContactsIterByID_Mutation(t *testing.T) {
+	tests := []struct {
+		name string
+		seq  func(*Client) iter.Seq2[Contact, error]
+		want []int
+	}{
+		{
+			name: "offset pagination skips contacts",
+			seq: func(c *Client) iter.Seq2[Contact, error] {
+				return c.ContactsIter(context.Background())
+			},
+			want: []int{1, 2, 4, 5, 6, 7},
+		},
+		{
+			name: "cursor pagination does not skip contacts",
+			seq: func(c *Client) iter.Seq2[Contact, error] {
+				return c.ContactsIterByID(context.Background())
+			},
+			want: []int{1, 2, 3, 4, 5, 6, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, mutatingContactsHandler(t))
+
+			var got []int
+			for contact, err := range tt.seq(client) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				got = append(got, contact.Basefields.ContactID.Int())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got contacts %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ContactsIterByID_Unsupported(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Ignores the cursor and always returns the first page.
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{TotalRecords: 4, TotalPages: 2},
+				Contacts: []Contact{
+					{Basefields: ContactBasefields{ContactID: 1}},
+					{Basefields: ContactBasefields{ContactID: 2}},
+				},
+			},
+		})
+	}))
+
+	var got []int
+	var gotErr error
+	for contact, err := range client.ContactsIterByID(context.Background()) {
+		if err != nil {
+			gotErr = err
+			break
+		}
+		got = append(got, contact.Basefields.ContactID.Int())
+	}
+
+	if !errors.Is(gotErr, ErrCursorUnsupported) {
+		t.Errorf("error = %v, want ErrCursorUnsupported", gotErr)
+	}
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("got contacts %v, want [1 2]", got)
+	}
+}
+
+func TestClient_ContactsByIDs_Forbidden(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+		func(w http.ResponseWriter, r *http.Request) {
+			id, _ := strconv.Atoi(r.PathValue("id"))
+			if id%2 == 0 {
+				writeJSON(w, http.StatusForbidden, Response[any]{Code: 403, Message: "forbidden"})
+				return
+			}
+
+			writeJSON(w, http.StatusOK, Response[Contact]{
+				Success: true,
+				Data:    Contact{Basefields: ContactBasefields{ContactID: FlexInt(id)}},
+			})
+		},
+	)
+
+	tests := []struct {
+		name        string
+		opts        []IterOption
+		wantIDs     []int
+		wantSkipped []int
+		wantErr     error
+	}{
+		{
+			name:    "fail fast",
+			wantIDs: []int{1},
+			wantErr: ErrForbidden,
+		},
+		{
+			name:        "skip forbidden",
+			opts:        []IterOption{SkipForbidden(true)},
+			wantIDs:     []int{1, 3, 5},
+			wantSkipped: []int{2, 4},
+		},
+		{
+			name:    "skip disabled",
+			opts:    []IterOption{SkipForbidden(false)},
+			wantIDs: []int{1},
+			wantErr: ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, mux)
+
+			var skipped []int
+			opts := append(slices.Clone(tt.opts), WithSkipped(func(id int, err error) {
+				if !errors.Is(err, ErrForbidden) {
+					t.Errorf("skipped %d with error %v, want ErrForbidden", id, err)
+				}
+				skipped = append(skipped, id)
+			}))
+
+			var ids []int
+			var gotErr error
+			for contact, err := range client.ContactsByIDs(
+				context.Background(),
+				[]int{1, 2, 3, 4, 5},
+				opts...,
+			) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				ids = append(ids, contact.Basefields.ContactID.Int())
+			}
+
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("got error %v, want %v", gotErr, tt.wantErr)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("got contacts %v, want %v", ids, tt.wantIDs)
+			}
+			if !slices.Equal(skipped, tt.wantSkipped) {
+				t.Errorf("got skipped %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestClient_ContactsIter_IncludeArchived(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []IterOption
+		want []string
+	}{
+		{name: "default"},
+		{
+			name: "include archived",
+			opts: []IterOption{WithContactsFilter(ContactsFilter{IncludeArchived: true})},
+			want: []string{"true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			pages := map[string][]string{}
+			handler := contactsHandler(5)
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					mu.Lock()
+					pages[r.URL.Query().Get("pageNo")] = r.URL.Query()["includeArchived"]
+					mu.Unlock()
+					handler.ServeHTTP(w, r)
+				}),
+			)
+
+			opts := append([]IterOption{WithPageLimit(3)}, tt.opts...)
+			for _, err := range client.ContactsIter(context.Background(), opts...) {
+				if err != nil {
+					t.Fatalf("ContactsIter() error = %v", err)
+				}
+			}
+
+			if len(pages) != 2 {
+				t.Fatalf("requested pages %v, want 2 pages", pages)
+			}
+			for pageNo, values := range pages {
+				if !slices.Equal(values, tt.want) {
+					t.Errorf("page %s: includeArchived = %v, want %v", pageNo, values, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ContactsIter_Groups(t *testing.T) {
+	groupSizes := map[string]int{"7": 0, "8": 5, "7,8": 5}
+
+	tests := []struct {
+		name      string
+		filter    ContactsFilter
+		wantQuery string
+		wantIDs   []int
+		wantPages int
+	}{
+		{
+			name:      "empty group",
+			filter:    ContactsFilter{GroupID: 7},
+			wantQuery: "7",
+			wantPages: 1,
+		},
+		{
+			name:      "group spanning pages",
+			filter:    ContactsFilter{GroupIDs: []int{8}},
+			wantQuery: "8",
+			wantIDs:   []int{1, 2, 3, 4, 5},
+			wantPages: 2,
+		},
+		{
+			name:      "group id merged into group ids",
+			filter:    ContactsFilter{GroupID: 7, GroupIDs: []int{8}},
+			wantQuery: "7,8",
+			wantIDs:   []int{1, 2, 3, 4, 5},
+			wantPages: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var queries []string
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					query := r.URL.Query().Get("groupIds")
+					mu.Lock()
+					queries = append(queries, query)
+					mu.Unlock()
+					contactsHandler(groupSizes[query]).ServeHTTP(w, r)
+				}),
+			)
+
+			var ids []int
+			for contact, err := range client.ContactsIter(
+				context.Background(),
+				WithPageLimit(3),
+				WithContactsFilter(tt.filter),
+			) {
+				if err != nil {
+					t.Fatalf("ContactsIter() error = %v", err)
+				}
+				ids = append(ids, contact.Basefields.ContactID.Int())
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("got contacts %v, want %v", ids, tt.wantIDs)
+			}
+			if len(queries) != tt.wantPages {
+				t.Errorf("requested %d pages, want %d", len(queries), tt.wantPages)
+			}
+			for i, query := range queries {
+				if query != tt.wantQuery {
+					t.Errorf("page %d: groupIds = %q, want %q", i+1, query, tt.wantQuery)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ContactsCount(t *testing.T) {
+	tests := []struct {
+		name      string
+		filter    ContactsFilter
+		body      string
+		wantQuery url.Values
+		want      int
+	}{
+		{
+			name: "all",
+			body: `{"success":true,"data":{"totalRecords":"1234","contacts":[{"basefields":{"contactId":1}}]}}`,
+			wantQuery: url.Values{
+				"pageNo":    {"1"},
+				"pageLimit": {"1"},
+			},
+			want: 1234,
+		},
+		{
+			name:   "filtered",
+			filter: ContactsFilter{IncludeArchived: true, GroupID: 3},
+			body:   `{"success":true,"data":{"totalRecords":7,"contacts":[]}}`,
+			wantQuery: url.Values{
+				"pageNo":          {"1"},
+				"pageLimit":       {"1"},
+				"includeArchived": {"true"},
+				"groupIds":        {"3"},
+			},
+			want: 7,
+		},
+		{
+			name: "undecodable contact",
+			body: `{"success":true,"data":{"totalRecords":42,"contacts":[{"basefields":"invalid"}]}}`,
+			wantQuery: url.Values{
+				"pageNo":    {"1"},
+				"pageLimit": {"1"},
+			},
+			want: 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []url.Values
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests = append(requests, r.URL.Query())
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = io.WriteString(w, tt.body)
+				}),
+			)
+
+			got, err := client.ContactsCount(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("ContactsCount() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ContactsCount() = %d, want %d", got, tt.want)
+			}
+
+			if len(requests) != 1 {
+				t.Fatalf("made %d requests, want 1", len(requests))
+			}
+			if !maps.EqualFunc(requests[0], tt.wantQuery, slices.Equal) {
+				t.Errorf("query = %v, want %v", requests[0], tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestClient_ContactsIter_Fields(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []IterOption
+		want []string
+	}{
+		{name: "all sections"},
+		{
+			name: "basefields only",
+			opts: []IterOption{WithContactFields(FieldBasefields)},
+			want: []string{"basefields"},
+		},
+		{
+			name: "several sections",
+			opts: []IterOption{
+				WithContactFields(FieldBasefields, FieldMembership, FieldAssignments),
+			},
+			want: []string{"basefields,membership,club_assignments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var queries [][]string
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					queries = append(queries, r.URL.Query()["fields"])
+					// Only the basefields section is returned.
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = io.WriteString(
+						w,
+						`{"success":true,"data":{"totalRecords":2,"contacts":[`+
+							`{"basefields":{"contact_id":1,"first_name":"Anna"}},`+
+							`{"basefields":{"contact_id":"2","last_update":null}}]}}`,
+					)
+				}),
+			)
+
+			var contacts []Contact
+			for contact, err := range client.ContactsIter(context.Background(), tt.opts...) {
+				if err != nil {
+					t.Fatalf("ContactsIter() error = %v", err)
+				}
+				contacts = append(contacts, contact)
+			}
+
+			if len(queries) != 1 || !slices.Equal(queries[0], tt.want) {
+				t.Errorf("fields parameters = %q, want %q", queries, tt.want)
+			}
+			if len(contacts) != 2 {
+				t.Fatalf("got %d contacts, want 2", len(contacts))
+			}
+			for _, contact := range contacts {
+				if contact.Basefields.ContactID == 0 {
+					t.Errorf("contact %+v lacks basefields", contact)
+				}
+				if contact.Membership != nil || contact.ClubAssignments != nil ||
+					contact.CorrAddress != (Address{}) || contact.Communication != (Communication{}) {
+					t.Errorf(
+						"omitted sections of contact %d aren't zero: %+v",
+						contact.Basefields.ContactID,
+						contact,
+					)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ContactsFiltered(t *testing.T) {
+	var query url.Values
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		writeJSON(w, http.StatusOK, map[string]any{
+			"success": true,
+			"data":    map[string]any{"totalRecords": 0, "contacts": []any{}},
+		})
+	}))
+
+	_, err := client.ContactsFiltered(context.Background(), ContactsParams{
+		PageParams:     PageParams{PageNo: 2},
+		ContactsFilter: ContactsFilter{IncludeArchived: true, GroupID: 3},
+		Fields:         []ContactField{FieldBasefields},
+	})
+	if err != nil {
+		t.Fatalf("ContactsFiltered() error = %v", err)
+	}
+
+	want := url.Values{
+		"pageNo":          {"2"},
+		"includeArchived": {"true"},
+		"groupIds":        {"3"},
+		"fields":          {"basefields"},
+	}
+	if !maps.EqualFunc(query, want, slices.Equal) {
+		t.Errorf("query = %v, want %v", query, want)
+	}
+}
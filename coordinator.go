@@ -0,0 +1,219 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultTokenLockTimeout is the default time waited for another process
+	// to refresh the token before refreshing it locally.
+	defaultTokenLockTimeout = 10 * time.Second
+	// tokenLockPollInterval is the interval shared tokens are checked at while
+	// another process refreshes the token.
+	tokenLockPollInterval = 50 * time.Millisecond
+)
+
+// TokenCoordinator shares tokens between clients using the same access key,
+// e.g. in multiple processes, so only one of them creates or refreshes the
+// token at a time. Implementations may use Redis, a database, or file locks.
+type TokenCoordinator interface {
+	// TryAcquireRefreshLock acquires the lock to refresh the token without
+	// waiting. It reports false if the lock is held by another client. The
+	// lock should expire eventually in case its holder dies.
+	TryAcquireRefreshLock(ctx context.Context) (release func(), ok bool, err error)
+	// Load returns the shared token, or a zero token if there is none.
+	Load(ctx context.Context) (CreateTokenResponse, error)
+	// Save replaces the shared token.
+	Save(ctx context.Context, token CreateTokenResponse) error
+}
+
+// WithTokenCoordinator shares tokens using coordinator. Once its token expires,
+// the client uses the shared token if it is still valid. Otherwise, it either
+// refreshes the token holding the refresh lock and saves it, or waits for the
+// lock holder to save a fresh token. If no fresh token is saved within
+// lockTimeout, the token is refreshed locally. A lockTimeout of zero defaults
+// to 10 seconds.
+func WithTokenCoordinator(coordinator TokenCoordinator, lockTimeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.coordinator = coordinator
+		c.coordinatorTimeout = lockTimeout
+		if lockTimeout <= 0 {
+			c.coordinatorTimeout = defaultTokenLockTimeout
+		}
+	}
+}
+
+// tokenExpiring reports whether the token must be created or refreshed.
+func (c *Client) tokenExpiring() bool {
+	_, version := c.auth.currentAccessKey()
+
+	c.auth.Lock()
+	defer c.auth.Unlock()
+
+	return c.auth.tokenKeyVersion != version || c.auth.shouldRefresh(c.clock.serverNow())
+}
+
+// ensureSharedToken makes sure a valid token is available, coordinating with
+// other clients using the token coordinator. Panics of the coordinator are
+// returned as [CallbackPanicError].
+func (c *Client) ensureSharedToken(ctx context.Context) (err error) {
+	deadline := c.clock.localNow().Add(c.coordinatorTimeout)
+	for {
+		if loaded, err := c.loadSharedToken(ctx); loaded || err != nil {
+			return err
+		}
+
+		var release func()
+		var ok bool
+		var lockErr error
+		if err := callSafely("TokenCoordinator.TryAcquireRefreshLock", func() {
+			release, ok, lockErr = c.coordinator.TryAcquireRefreshLock(ctx)
+		}); err != nil {
+			return err
+		}
+		if lockErr != nil {
+			if err := c.warn(
+				"token refresh lock failed, refreshing locally: %v", lockErr,
+			); err != nil {
+				return err
+			}
+			return c.ensureLocalToken(ctx)
+		}
+		if ok {
+			defer func() {
+				if releaseErr := callSafely("TokenCoordinator release", release); releaseErr != nil {
+					err = errors.Join(err, releaseErr)
+				}
+			}()
+			return c.refreshSharedToken(ctx)
+		}
+
+		if !c.clock.localNow().Before(deadline) {
+			if err := c.warn("token refresh lock not released within %s, refreshing locally",
+				c.coordinatorTimeout); err != nil {
+				return err
+			}
+			return c.ensureLocalToken(ctx)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(tokenLockPollInterval):
+		}
+	}
+}
+
+// refreshSharedToken refreshes the token holding the refresh lock and saves it.
+func (c *Client) refreshSharedToken(ctx context.Context) error {
+	// The token may have been saved since it was last loaded.
+	if loaded, err := c.loadSharedToken(ctx); loaded || err != nil {
+		return err
+	}
+
+	if err := c.ensureLocalToken(ctx); err != nil {
+		return err
+	}
+
+	c.auth.Lock()
+	token := CreateTokenResponse{Token: c.auth.token, RefreshToken: c.auth.refreshToken}
+	c.auth.Unlock()
+
+	var saveErr error
+	if err := callSafely("TokenCoordinator.Save", func() {
+		saveErr = c.coordinator.Save(ctx, token)
+	}); err != nil {
+		return err
+	}
+	if saveErr != nil {
+		return c.warn("saving shared token failed: %v", saveErr)
+	}
+
+	return nil
+}
+
+// loadSharedToken adopts the shared token and reports whether it is valid.
+func (c *Client) loadSharedToken(ctx context.Context) (bool, error) {
+	var token CreateTokenResponse
+	var loadErr error
+	if err := callSafely("TokenCoordinator.Load", func() {
+		token, loadErr = c.coordinator.Load(ctx)
+	}); err != nil {
+		return false, err
+	}
+	if loadErr != nil {
+		return false, c.warn("loading shared token failed: %v", loadErr)
+	}
+	if token.Token == "" {
+		return false, nil
+	}
+
+	claim, err := c.auth.validateToken(token.Token)
+	if err != nil {
+		return false, nil
+	}
+
+	_, version := c.auth.currentAccessKey()
+
+	c.auth.Lock()
+	defer c.auth.Unlock()
+
+	if refreshDue(claim, c.clock.serverNow()) {
+		return false, nil
+	}
+	c.auth.claim = claim
+	c.auth.token = token.Token
+	c.auth.setRefreshToken(token.RefreshToken)
+	c.auth.tokenKeyVersion = version
+
+	return true, nil
+}
+
+// LocalTokenCoordinator is a [TokenCoordinator] sharing tokens between clients
+// of a single process. It serves as reference for implementations coordinating
+// multiple processes. The zero value is ready to use.
+type LocalTokenCoordinator struct {
+	mu     sync.Mutex
+	locked bool
+	token  CreateTokenResponse
+}
+
+// TryAcquireRefreshLock implements [TokenCoordinator].
+func (l *LocalTokenCoordinator) TryAcquireRefreshLock(context.Context) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locked {
+		return nil, false, nil
+	}
+	l.locked = true
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			l.locked = false
+			l.mu.Unlock()
+		})
+	}, true, nil
+}
+
+// Load implements [TokenCoordinator].
+func (l *LocalTokenCoordinator) Load(context.Context) (CreateTokenResponse, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.token, nil
+}
+
+// Save implements [TokenCoordinator].
+func (l *LocalTokenCoordinator) Save(_ context.Context, token CreateTokenResponse) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.token = token
+	return nil
+}
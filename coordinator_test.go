@@ -0,0 +1,140 @@
+package fairgate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// coordinatedAuthHandler serves contacts and counts the created tokens.
+func coordinatedAuthHandler(
+	t *testing.T,
+	privateKey *ecdsa.PrivateKey,
+	creates *atomic.Int32,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/auth/create/") {
+			creates.Add(1)
+			// Keep the lock held long enough for other clients to wait.
+			time.Sleep(100 * time.Millisecond)
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Success: true,
+				Data: CreateTokenResponse{
+					Token:        createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+					RefreshToken: "refresh",
+				},
+			})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	})
+}
+
+// newCoordinatedClient returns an unauthenticated client using coordinator.
+func newCoordinatedClient(
+	serverURL string,
+	httpClient *http.Client,
+	publicKey *ecdsa.PublicKey,
+	coordinator TokenCoordinator,
+	opts ...ClientOption,
+) *Client {
+	return New("test-org", publicKey, append([]ClientOption{
+		WithHTTPClient(httpClient),
+		WithBaseURL(mustParseURL(serverURL)),
+		WithAccessKey("access-key"),
+		WithTokenCoordinator(coordinator, time.Second),
+	}, opts...)...)
+}
+
+func TestWithTokenCoordinator_SharedRefresh(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	var creates atomic.Int32
+	_, server := newTestClient(t, coordinatedAuthHandler(t, privateKey, &creates))
+
+	coordinator := &LocalTokenCoordinator{}
+	clients := []*Client{
+		newCoordinatedClient(server.URL, server.Client(), publicKey, coordinator),
+		newCoordinatedClient(server.URL, server.Client(), publicKey, coordinator),
+	}
+
+	var wg sync.WaitGroup
+	for _, client := range clients {
+		wg.Go(func() {
+			if _, err := client.Contact(context.Background(), 1); err != nil {
+				t.Errorf("Contact() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := creates.Load(); got != 1 {
+		t.Errorf("token creations = %d, want 1", got)
+	}
+	token, _ := coordinator.Load(context.Background())
+	for i, client := range clients {
+		if client.auth.token != token.Token {
+			t.Errorf("client %d doesn't use the shared token", i)
+		}
+	}
+}
+
+func TestWithTokenCoordinator_ValidSharedToken(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	var creates atomic.Int32
+	_, server := newTestClient(t, coordinatedAuthHandler(t, privateKey, &creates))
+
+	coordinator := &LocalTokenCoordinator{}
+	_ = coordinator.Save(context.Background(), CreateTokenResponse{
+		Token: createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+	})
+	client := newCoordinatedClient(server.URL, server.Client(), publicKey, coordinator)
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+	if got := creates.Load(); got != 0 {
+		t.Errorf("token creations = %d, want 0", got)
+	}
+}
+
+func TestWithTokenCoordinator_LockHolderDied(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	var creates atomic.Int32
+	_, server := newTestClient(t, coordinatedAuthHandler(t, privateKey, &creates))
+
+	coordinator := &LocalTokenCoordinator{}
+	if _, ok, _ := coordinator.TryAcquireRefreshLock(context.Background()); !ok {
+		t.Fatal("failed to acquire lock")
+	}
+
+	var warnings []string
+	client := newCoordinatedClient(
+		server.URL,
+		server.Client(),
+		publicKey,
+		coordinator,
+		WithTokenCoordinator(coordinator, 100*time.Millisecond),
+		WithWarningHandler(func(message string) { warnings = append(warnings, message) }),
+	)
+
+	start := time.Now()
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("refreshed after %s, want to wait for the lock timeout", elapsed)
+	}
+	if got := creates.Load(); got != 1 {
+		t.Errorf("token creations = %d, want 1", got)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %q, want a single warning", warnings)
+	}
+}
@@ -0,0 +1,71 @@
+package fairgate
+
+import (
+	"strings"
+	"unicode"
+)
+
+// EmailSource is the field of a contact an email address was taken from.
+type EmailSource string
+
+const (
+	EmailSourceNone    EmailSource = ""
+	EmailSourcePrimary EmailSource = "primary_email"
+	EmailSourceParent1 EmailSource = "email_parent_1"
+	EmailSourceParent2 EmailSource = "email_parent_2"
+)
+
+// EffectiveEmail returns the address to send correspondence to and the field
+// it was taken from: PrimaryEmail if set, else EmailParent1, else
+// EmailParent2. Addresses that don't look like an email address are skipped.
+// It reports false if no field holds an address.
+func (c Contact) EffectiveEmail() (string, EmailSource, bool) {
+	candidates := []struct {
+		email  string
+		source EmailSource
+	}{
+		{c.Communication.PrimaryEmail, EmailSourcePrimary},
+		{c.Communication.EmailParent1, EmailSourceParent1},
+		{c.Communication.EmailParent2, EmailSourceParent2},
+	}
+	for _, candidate := range candidates {
+		email := strings.TrimSpace(candidate.email)
+		if looksLikeEmail(email) {
+			return email, candidate.source, true
+		}
+	}
+
+	return "", EmailSourceNone, false
+}
+
+// EffectiveLanguage returns the language to correspond with the contact in:
+// Communication.CorrespondenceLanguage if set, else
+// Basefields.CorrespondenceLanguage, else defaultLang.
+func (c Contact) EffectiveLanguage(defaultLang Language) Language {
+	for _, lang := range []Language{
+		c.Communication.CorrespondenceLanguage,
+		c.Basefields.CorrespondenceLanguage,
+	} {
+		if lang := Language(strings.ToLower(strings.TrimSpace(string(lang)))); lang != "" {
+			return lang
+		}
+	}
+
+	return defaultLang
+}
+
+// looksLikeEmail reports whether s has the shape of an email address: a
+// single @ separating a non-empty local part from a domain containing a dot,
+// without whitespace.
+func looksLikeEmail(s string) bool {
+	local, domain, ok := strings.Cut(s, "@")
+	if !ok || local == "" || strings.Contains(domain, "@") {
+		return false
+	}
+	if strings.ContainsFunc(s, unicode.IsSpace) {
+		return false
+	}
+
+	dot := strings.LastIndex(domain, ".")
+	return dot > 0 && dot < len(domain)-1
+}
@@ -0,0 +1,126 @@
+package fairgate
+
+import "testing"
+
+func TestContact_EffectiveEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		primary    string
+		parent1    string
+		parent2    string
+		want       string
+		wantSource EmailSource
+		wantOK     bool
+	}{
+		{name: "all empty"},
+		{
+			name:       "primary only",
+			primary:    "anna@example.ch",
+			want:       "anna@example.ch",
+			wantSource: EmailSourcePrimary,
+			wantOK:     true,
+		},
+		{
+			name:       "primary before parents",
+			primary:    "anna@example.ch",
+			parent1:    "mother@example.ch",
+			parent2:    "father@example.ch",
+			want:       "anna@example.ch",
+			wantSource: EmailSourcePrimary,
+			wantOK:     true,
+		},
+		{
+			name:       "first parent",
+			parent1:    "mother@example.ch",
+			parent2:    "father@example.ch",
+			want:       "mother@example.ch",
+			wantSource: EmailSourceParent1,
+			wantOK:     true,
+		},
+		{
+			name:       "second parent only",
+			parent2:    "father@example.ch",
+			want:       "father@example.ch",
+			wantSource: EmailSourceParent2,
+			wantOK:     true,
+		},
+		{
+			name:       "trimmed",
+			primary:    "  anna@example.ch\n",
+			want:       "anna@example.ch",
+			wantSource: EmailSourcePrimary,
+			wantOK:     true,
+		},
+		{
+			name:       "invalid primary falls back",
+			primary:    "anna(at)example.ch",
+			parent1:    "mother@example.ch",
+			want:       "mother@example.ch",
+			wantSource: EmailSourceParent1,
+			wantOK:     true,
+		},
+		{
+			name:       "invalid first parent falls back",
+			parent1:    "mother@localhost",
+			parent2:    "father@example.ch",
+			want:       "father@example.ch",
+			wantSource: EmailSourceParent2,
+			wantOK:     true,
+		},
+		{
+			name:    "all invalid",
+			primary: "@example.ch",
+			parent1: "mother@@example.ch",
+			parent2: "father @example.ch",
+		},
+		{name: "whitespace only", primary: "   "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact := Contact{Communication: Communication{
+				PrimaryEmail: tt.primary,
+				EmailParent1: tt.parent1,
+				EmailParent2: tt.parent2,
+			}}
+
+			got, source, ok := contact.EffectiveEmail()
+			if got != tt.want || source != tt.wantSource || ok != tt.wantOK {
+				t.Errorf("EffectiveEmail() = %q, %q, %t, want %q, %q, %t",
+					got, source, ok, tt.want, tt.wantSource, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestContact_EffectiveLanguage(t *testing.T) {
+	tests := []struct {
+		name          string
+		communication Language
+		basefields    Language
+		want          Language
+	}{
+		{name: "default", want: LanguageEN},
+		{
+			name:          "communication",
+			communication: LanguageFR,
+			basefields:    LanguageDE,
+			want:          LanguageFR,
+		},
+		{name: "basefields", basefields: LanguageIT, want: LanguageIT},
+		{name: "normalized", communication: " DE ", want: LanguageDE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contact := Contact{
+				Basefields:    ContactBasefields{CorrespondenceLanguage: tt.basefields},
+				Communication: Communication{CorrespondenceLanguage: tt.communication},
+			}
+
+			if got := contact.EffectiveLanguage(LanguageEN); got != tt.want {
+				t.Errorf("EffectiveLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
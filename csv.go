@@ -0,0 +1,82 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"iter"
+	"strconv"
+	"time"
+)
+
+// csvHeader lists the columns written by [Client.ContactsWriteCSV].
+var csvHeader = []string{
+	"contact_id",
+	"first_name",
+	"last_name",
+	"company_name",
+	"contact_type",
+	"status",
+	"primary_email",
+	"mobile",
+	"street",
+	"postale_code",
+	"city",
+	"country",
+	"last_update",
+}
+
+// ContactsWriteCSV writes all contacts as CSV to w, starting with a header row.
+// It returns the number of contacts written. Pages are fetched ahead while
+// writing, but never more than two, see [WithPageBuffer].
+func (c *Client) ContactsWriteCSV(ctx context.Context, w io.Writer) (int, error) {
+	return writeContactsCSV(w, c.ContactsIter(ctx, WithPageBuffer(exportPageBuffer)))
+}
+
+// writeContactsCSV writes the contacts of seq as CSV to w.
+func writeContactsCSV(w io.Writer, seq iter.Seq2[Contact, error]) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for contact, err := range seq {
+		if err != nil {
+			cw.Flush()
+			return n, err
+		}
+
+		if err := cw.Write(contactCSVRecord(contact)); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	cw.Flush()
+	return n, cw.Error()
+}
+
+// contactCSVRecord returns the CSV columns of a contact.
+func contactCSVRecord(contact Contact) []string {
+	lastUpdate := ""
+	if !contact.Basefields.LastUpdate.IsZero() {
+		lastUpdate = contact.Basefields.LastUpdate.UTC().Format(time.RFC3339)
+	}
+
+	return []string{
+		strconv.Itoa(contact.Basefields.ContactID.Int()),
+		contact.Basefields.FirstName,
+		contact.Basefields.LastName,
+		contact.Basefields.CompanyName,
+		string(contact.Basefields.ContactType),
+		string(contact.Status),
+		contact.Communication.PrimaryEmail,
+		contact.Communication.Mobile,
+		contact.CorrAddress.Street,
+		contact.CorrAddress.PostaleCode,
+		contact.CorrAddress.City,
+		contact.CorrAddress.Country,
+		lastUpdate,
+	}
+}
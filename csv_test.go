@@ -0,0 +1,106 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteContactsCSV(t *testing.T) {
+	contacts := func(yield func(Contact, error) bool) {
+		for _, contact := range []Contact{
+			{
+				Basefields: ContactBasefields{
+					ContactID:   1,
+					FirstName:   `Anna, "Annie"`,
+					LastName:    "Muster\nMeier",
+					ContactType: ContactTypeSinglePerson,
+					LastUpdate:  Time{time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("", 2*3600))},
+				},
+				Status:        ContactStatusActive,
+				Communication: Communication{PrimaryEmail: "anna@example.com"},
+				CorrAddress:   Address{Street: "Bahnhofstrasse 1", PostaleCode: "8001", City: "Zürich"},
+			},
+			{Basefields: ContactBasefields{ContactID: 2, CompanyName: "Beispiel AG"}},
+		} {
+			if !yield(contact, nil) {
+				return
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	n, err := writeContactsCSV(&buf, contacts)
+	if err != nil {
+		t.Fatalf("writeContactsCSV() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("writeContactsCSV() = %d rows, want 2", n)
+	}
+
+	want := "contact_id,first_name,last_name,company_name,contact_type,status," +
+		"primary_email,mobile,street,postale_code,city,country,last_update\n" +
+		`1,"Anna, ""Annie""","Muster` + "\n" + `Meier",,` + string(ContactTypeSinglePerson) + "," +
+		string(ContactStatusActive) + ",anna@example.com,,Bahnhofstrasse 1,8001,Zürich,," +
+		"2024-05-01T10:00:00Z\n" +
+		"2,,,Beispiel AG,,,,,,,,,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// failingWriter fails every write with errWrite.
+type failingWriter struct{}
+
+var errWrite = errors.New("write failed")
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
+
+func TestWriteContactsCSV_WriterError(t *testing.T) {
+	_, err := writeContactsCSV(failingWriter{}, ndjsonContacts())
+	if !errors.Is(err, errWrite) {
+		t.Errorf("writeContactsCSV() error = %v, want %v", err, errWrite)
+	}
+}
+
+func TestClient_ContactsWriteCSV_Error(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		if pageNo > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{TotalRecords: 4, TotalPages: 2, PageNo: FlexInt(pageNo)},
+				Contacts: []Contact{
+					{Basefields: ContactBasefields{ContactID: 1}},
+					{Basefields: ContactBasefields{ContactID: 2}},
+				},
+			},
+		})
+	}))
+
+	var buf bytes.Buffer
+	n, err := client.ContactsWriteCSV(context.Background(), &buf)
+	if !errors.Is(err, ErrStatus) {
+		t.Errorf("ContactsWriteCSV() error = %v, want ErrStatus", err)
+	}
+	if n != 2 {
+		t.Errorf("ContactsWriteCSV() = %d rows, want 2", n)
+	}
+
+	// The rows written before the error are flushed.
+	if lines := strings.Count(buf.String(), "\n"); lines != 3 {
+		t.Errorf("got %d lines, want the header and 2 rows", lines)
+	}
+}
@@ -0,0 +1,141 @@
+package fairgate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// deprecatedField identifies a response field by Go type name and JSON name.
+type deprecatedField struct {
+	Type  string
+	Field string
+}
+
+// String returns the field in the form "Type.json_name".
+func (f deprecatedField) String() string {
+	return f.Type + "." + f.Field
+}
+
+// deprecatedFields lists the response fields marked as deprecated in the
+// Fairgate OpenAPI specification.
+var deprecatedFields = map[deprecatedField]bool{
+	{Type: "Communication", Field: "handy2"}: true,
+}
+
+// DeprecationHandler is called when a response contains a deprecated field.
+// The field is reported in the form "Type.json_name", e.g. "Communication.handy2".
+type DeprecationHandler func(endpoint, field string)
+
+// WithDeprecationHandler sets a handler called when a response contains a field
+// marked as deprecated by the API. The handler is called at most once per field
+// for the lifetime of the client.
+func WithDeprecationHandler(handler DeprecationHandler) ClientOption {
+	return func(c *Client) {
+		c.deprecations.handler = handler
+	}
+}
+
+// deprecationTracker reports deprecated response fields once per field.
+type deprecationTracker struct {
+	handler DeprecationHandler
+	seen    sync.Map
+}
+
+// enabled reports whether responses need to be checked for deprecated fields.
+func (d *deprecationTracker) enabled() bool {
+	return d.handler != nil
+}
+
+// check reports the deprecated fields present in data when decoded into v. It
+// returns a [CallbackPanicError] if the handler panics.
+func (d *deprecationTracker) check(endpoint string, data []byte, v any) error {
+	if !d.enabled() || v == nil {
+		return nil
+	}
+
+	var err error
+	walkDeprecated(reflect.TypeOf(v), data, func(field deprecatedField) {
+		if err != nil {
+			return
+		}
+		if _, loaded := d.seen.LoadOrStore(field, struct{}{}); loaded {
+			return
+		}
+		err = callSafely("DeprecationHandler", func() { d.handler(endpoint, field.String()) })
+	})
+
+	return err
+}
+
+// walkDeprecated walks data along the structure of t and calls report for each
+// deprecated field present with a non-null value.
+func walkDeprecated(t reflect.Type, data []byte, report func(deprecatedField)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(data, &obj) != nil {
+			return
+		}
+
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, skip := jsonFieldName(field)
+			if skip {
+				continue
+			}
+			if name == "" {
+				if field.Anonymous {
+					walkDeprecated(field.Type, data, report)
+				}
+				continue
+			}
+
+			raw, ok := obj[name]
+			if !ok || string(raw) == "null" {
+				continue
+			}
+
+			key := deprecatedField{Type: t.Name(), Field: name}
+			if deprecatedFields[key] {
+				report(key)
+			}
+
+			walkDeprecated(field.Type, raw, report)
+		}
+	case reflect.Slice, reflect.Array:
+		var items []json.RawMessage
+		if json.Unmarshal(data, &items) != nil {
+			return
+		}
+
+		for _, item := range items {
+			walkDeprecated(t.Elem(), item, report)
+		}
+	}
+}
+
+// jsonFieldName returns the JSON name of a struct field. It returns an empty
+// name for embedded fields without an explicit name.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", true
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" && !field.Anonymous {
+		name = field.Name
+	}
+
+	return name, false
+}
@@ -0,0 +1,72 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_WithDeprecationHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		contacts string
+		want     []string
+	}{
+		{
+			name:     "handy2 present",
+			contacts: `[{"communication":{"handy2":"079 123 45 67"}},{"communication":{"handy2":"079 765 43 21"}}]`,
+			want:     []string{"Communication.handy2"},
+		},
+		{
+			name:     "handy2 null",
+			contacts: `[{"communication":{"mobile":"079 123 45 67","handy2":null}}]`,
+		},
+		{
+			name:     "handy2 absent",
+			contacts: `[{"communication":{"mobile":"079 123 45 67"}}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			handler := func(endpoint, field string) {
+				if endpoint != "/fsa/v2.0/contact/test-org/contacts/extended" {
+					t.Errorf("unexpected endpoint: %s", endpoint)
+				}
+				got = append(got, field)
+			}
+
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.Header().Set("Content-Type", "application/json")
+					_, _ = w.Write([]byte(
+						`{"success":true,"data":{"totalPages":1,"contacts":` + tt.contacts + `}}`,
+					))
+				}),
+				WithDeprecationHandler(handler),
+			)
+
+			// Fetch twice to verify the handler fires at most once per field.
+			for range 2 {
+				list, err := client.Contacts(context.Background(), PageParams{PageNo: 1})
+				if err != nil {
+					t.Fatalf("Contacts() error = %v", err)
+				}
+				if len(list.Contacts) == 0 {
+					t.Fatal("expected contacts to be decoded")
+				}
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("handler called with %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("field[%d] = %q, want %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Do sends a request to an endpoint not covered by the client and decodes the
+// response envelope. The path is relative to the base URL, e.g.
+// "/fsa/v2.0/contact/" + c.OrganisationID() + "/contacts/extended". The path is
+// unescaped and escaped by the client, so a literal "%" is sent as "%25". If
+// body is not nil, it is sent as JSON. Requests with methods other than GET and HEAD
+// carry an idempotency key, see [WithIdempotencyKey].
+// A failed response, as reported by [Response.Error], is returned as error.
+func Do[T any](
+	ctx context.Context,
+	c *Client,
+	method, path string,
+	params url.Values,
+	body any,
+) (*Response[T], error) {
+	var result Response[T]
+	if err := c.DoJSON(ctx, method, path, params, body, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DoJSON sends a request like [Do], but decodes the JSON response body into
+// out as is, for endpoints not using the response envelope. If out is nil, the
+// response body is discarded. If out is a [Response], its error is returned.
+func (c *Client) DoJSON(
+	ctx context.Context,
+	method, path string,
+	params url.Values,
+	body, out any,
+) error {
+	newRequest := c.newWriteRequest
+	if method == http.MethodGet || method == http.MethodHead {
+		newRequest = c.newRequest
+	}
+
+	req, err := newRequest(ctx, method, path, params, body)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doJSON(req, out)
+	return err
+}
+
+// OrganisationID returns the ID of the organisation the client accesses.
+func (c *Client) OrganisationID() string {
+	return c.oid
+}
@@ -0,0 +1,126 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	type widget struct {
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		resp     any
+		wantName string
+		wantErr  string
+	}{
+		{
+			name:     "success",
+			status:   http.StatusOK,
+			resp:     Response[widget]{Code: 200, Success: true, Data: widget{Name: "gear"}},
+			wantName: "gear",
+		},
+		{
+			name:    "failure envelope",
+			status:  http.StatusOK,
+			resp:    Response[widget]{Code: 400, Message: "invalid widget"},
+			wantErr: "invalid widget",
+		},
+		{
+			name:    "missing envelope",
+			status:  http.StatusOK,
+			resp:    widget{Name: "gear"},
+			wantErr: "request failed (code 0) with no error details",
+		},
+		{
+			name:    "status",
+			status:  http.StatusNotFound,
+			resp:    Response[widget]{Code: 404},
+			wantErr: ErrStatus.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					if r.Method != http.MethodPost {
+						t.Errorf("method = %s, want POST", r.Method)
+					}
+					if r.URL.Path != "/fsa/v2.0/widget/test-org/widgets" {
+						t.Errorf("unexpected path: %s", r.URL.Path)
+					}
+					if r.URL.Query().Get("dryRun") != "1" {
+						t.Errorf("query = %q, want dryRun=1", r.URL.RawQuery)
+					}
+					if r.Header.Get(idempotencyKeyHeader) == "" {
+						t.Error("missing idempotency key")
+					}
+
+					var body widget
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil ||
+						body.Name != "gear" {
+						t.Errorf("body = %+v, %v, want gear", body, err)
+					}
+					writeJSON(w, tt.status, tt.resp)
+				},
+			))
+
+			resp, err := Do[widget](
+				context.Background(),
+				client,
+				http.MethodPost,
+				"/fsa/v2.0/widget/"+client.OrganisationID()+"/widgets",
+				url.Values{"dryRun": {"1"}},
+				widget{Name: "gear"},
+			)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Do() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if resp.Data.Name != tt.wantName {
+				t.Errorf("Data.Name = %q, want %q", resp.Data.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestClient_DoJSON_WithoutEnvelope(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("method = %s, want GET", r.Method)
+		}
+		if r.Header.Get(idempotencyKeyHeader) != "" {
+			t.Error("unexpected idempotency key on GET request")
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"url": "https://example.com/export.csv"})
+	}))
+
+	var out struct {
+		URL string `json:"url"`
+	}
+	err := client.DoJSON(context.Background(), http.MethodGet, "/fsa/v2.0/export", nil, nil, &out)
+	if err != nil {
+		t.Fatalf("DoJSON() error = %v", err)
+	}
+	if out.URL != "https://example.com/export.csv" {
+		t.Errorf("URL = %q, want https://example.com/export.csv", out.URL)
+	}
+
+	err = client.DoJSON(context.Background(), http.MethodGet, "/fsa/v2.0/export", nil, nil, nil)
+	if err != nil {
+		t.Errorf("DoJSON() without out error = %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package fairgate
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"mime"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// Document represents a document shared in the organisation, such as the
+// statutes or meeting protocols.
+type Document struct {
+	// DocumentID is the unique ID of the document.
+	DocumentID int `json:"document_id,omitempty"`
+	// Name is the file name of the document.
+	Name string `json:"name,omitempty"`
+	// Folder is the folder the document is stored in.
+	Folder string `json:"folder,omitempty"`
+	// Size is the size of the document in bytes.
+	Size int64 `json:"size,omitempty"`
+	// UpdatedAt is the date and time the document was last updated.
+	UpdatedAt Time `json:"updated_at"`
+	// MimeType is the media type of the document, e.g. "application/pdf".
+	MimeType string `json:"mime_type,omitempty"`
+}
+
+// DocumentsList represents a page of documents.
+type DocumentsList struct {
+	Pagination `           json:",inline"`
+	Documents  []Document `json:"documents,omitempty"`
+}
+
+// DocumentParams represents the parameters for listing documents.
+type DocumentParams struct {
+	PageParams
+	// Folder only returns documents in this folder.
+	Folder string `url:"folder,omitempty"`
+	// UpdatedSince only returns documents updated since this time, e.g.
+	// QueryTimeRFC3339(lastSync).
+	UpdatedSince QueryTime `url:"updatedSince"`
+}
+
+// DocumentMeta describes a downloaded document, as reported by the download
+// response.
+type DocumentMeta struct {
+	// Name is the file name of the document, if reported.
+	Name string
+	// MimeType is the media type of the document.
+	MimeType string
+	// Size is the size of the document in bytes, or -1 if unknown.
+	Size int64
+}
+
+// maxDocumentSizes is the number of listed document sizes kept to check the
+// size of downloads.
+const maxDocumentSizes = 10000
+
+// documentSize is the listed size of a document kept by [documentSizes].
+type documentSize struct {
+	id   int
+	size int64
+}
+
+// documentSizes holds the sizes of the most recently listed documents by ID, to
+// check the size of downloads. It is safe for concurrent use.
+type documentSizes struct {
+	mu      sync.Mutex
+	entries map[int]*list.Element
+	lru     *list.List
+}
+
+// store records the sizes of documents, evicting the least recently listed
+// sizes beyond [maxDocumentSizes].
+func (d *documentSizes) store(documents []Document) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.entries == nil {
+		d.entries = map[int]*list.Element{}
+		d.lru = list.New()
+	}
+	for _, document := range documents {
+		entry := &documentSize{id: document.DocumentID, size: document.Size}
+		if elem, ok := d.entries[entry.id]; ok {
+			elem.Value = entry
+			d.lru.MoveToFront(elem)
+			continue
+		}
+
+		d.entries[entry.id] = d.lru.PushFront(entry)
+		if d.lru.Len() > maxDocumentSizes {
+			oldest := d.lru.Back()
+			d.lru.Remove(oldest)
+			delete(d.entries, oldest.Value.(*documentSize).id)
+		}
+	}
+}
+
+// lookup returns the listed size of the document id.
+func (d *documentSizes) lookup(id int) (int64, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	elem, ok := d.entries[id]
+	if !ok {
+		return 0, false
+	}
+
+	return elem.Value.(*documentSize).size, true
+}
+
+// DocumentsIter returns an iterator over all documents matching params.
+func (c *Client) DocumentsIter(
+	ctx context.Context,
+	params DocumentParams,
+	opts ...IterOption,
+) iter.Seq2[Document, error] {
+	return iterate(ctx, func(ctx context.Context, p PageParams) ([]Document, Pagination, error) {
+		params.PageParams = p
+
+		list, err := c.Documents(ctx, params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return list.Documents, list.Pagination, nil
+	}, c.iterOptions(opts)...)
+}
+
+// Documents retrieves a page of documents matching params.
+func (c *Client) Documents(ctx context.Context, params DocumentParams) (*DocumentsList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/documents", url.PathEscape(c.oid))
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[DocumentsList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+	c.documentSizes.store(result.Data.Documents)
+
+	return &result.Data, nil
+}
+
+// DocumentDownload streams the content of the document. The caller must close
+// the returned body. A missing document fails with [ErrNotFound], and a
+// document the access key may not read with [ErrForbidden].
+//
+// If the size of the download differs from the size listed by
+// [Client.Documents], the warning handler is called, see
+// [WithWarningHandler], as the document may have changed since.
+func (c *Client) DocumentDownload(
+	ctx context.Context,
+	documentID int,
+) (io.ReadCloser, DocumentMeta, error) {
+	path := fmt.Sprintf(
+		"/fsa/v2.0/contact/%s/documents/%d/download",
+		url.PathEscape(c.oid),
+		documentID,
+	)
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, nil, nil)
+	if err != nil {
+		return nil, DocumentMeta{}, err
+	}
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, DocumentMeta{}, err
+	}
+
+	meta := DocumentMeta{
+		MimeType: resp.Header.Get("Content-Type"),
+		Size:     resp.ContentLength,
+	}
+	if _, params, err := mime.ParseMediaType(
+		resp.Header.Get("Content-Disposition"),
+	); err == nil {
+		meta.Name = params["filename"]
+	}
+
+	if listed, ok := c.documentSizes.lookup(documentID); ok && meta.Size >= 0 &&
+		meta.Size != listed {
+		err := c.warn(
+			"document %d has %d bytes, but was listed with %d bytes",
+			documentID,
+			meta.Size,
+			listed,
+		)
+		if err != nil {
+			closeBody(resp.Body)
+			return nil, DocumentMeta{}, err
+		}
+	}
+
+	return resp.Body, meta, nil
+}
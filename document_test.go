@@ -0,0 +1,165 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// documentsHandler serves the documents in pages, filtered by folder and
+// update time, and their content, which is longer than listed for document 3.
+func documentsHandler(t *testing.T, documents []Document) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/documents",
+		func(w http.ResponseWriter, r *http.Request) {
+			query := r.URL.Query()
+			since, err := time.Parse(time.RFC3339, query.Get("updatedSince"))
+			if err != nil {
+				t.Errorf("updatedSince = %q, want RFC 3339", query.Get("updatedSince"))
+			}
+
+			var matching []Document
+			for _, document := range documents {
+				if document.Folder == query.Get("folder") && !document.UpdatedAt.Before(since) {
+					matching = append(matching, document)
+				}
+			}
+
+			pageNo, _ := strconv.Atoi(query.Get("pageNo"))
+			pageLimit, _ := strconv.Atoi(query.Get("pageLimit"))
+			start := min((pageNo-1)*pageLimit, len(matching))
+			end := min(start+pageLimit, len(matching))
+			writeJSON(w, http.StatusOK, Response[DocumentsList]{
+				Success: true,
+				Data: DocumentsList{
+					Pagination: Pagination{
+						TotalRecords: FlexInt(len(matching)),
+						PageNo:       FlexInt(pageNo),
+					},
+					Documents: matching[start:end],
+				},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/documents/{id}/download",
+		func(w http.ResponseWriter, r *http.Request) {
+			id, _ := strconv.Atoi(r.PathValue("id"))
+			switch id {
+			case 3:
+			case 4:
+				writeJSON(w, http.StatusForbidden, Response[any]{Message: "forbidden"})
+				return
+			default:
+				writeJSON(w, http.StatusNotFound, Response[any]{Message: "not found"})
+				return
+			}
+
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Header().Set("Content-Disposition", `attachment; filename="Protokoll GV.pdf"`)
+			_, _ = io.WriteString(w, "%PDF-1.7 updated")
+		},
+	)
+
+	return mux
+}
+
+func TestClient_Documents(t *testing.T) {
+	updated := func(day int) Time {
+		return Time{time.Date(2024, time.May, day, 10, 0, 0, 0, time.UTC)}
+	}
+	documents := []Document{
+		{DocumentID: 1, Name: "Statuten.pdf", Folder: "Vereinsrecht", UpdatedAt: updated(1)},
+		{DocumentID: 2, Name: "Protokoll 2023.pdf", Folder: "Protokolle", UpdatedAt: updated(1)},
+		{
+			DocumentID: 3,
+			Name:       "Protokoll GV.pdf",
+			Folder:     "Protokolle",
+			Size:       8,
+			UpdatedAt:  updated(3),
+		},
+		{DocumentID: 4, Name: "Budget.pdf", Folder: "Protokolle", UpdatedAt: updated(4)},
+		{DocumentID: 5, Name: "Protokoll VS.pdf", Folder: "Protokolle", UpdatedAt: updated(5)},
+	}
+	client, _ := newTestClient(t, documentsHandler(t, documents))
+	var warnings []string
+	WithWarningHandler(func(message string) { warnings = append(warnings, message) })(client)
+
+	var ids []int
+	for document, err := range client.DocumentsIter(
+		context.Background(),
+		DocumentParams{
+			Folder:       "Protokolle",
+			UpdatedSince: QueryTimeRFC3339(updated(2).Time),
+		},
+		WithPageLimit(2),
+	) {
+		if err != nil {
+			t.Fatalf("DocumentsIter() error = %v", err)
+		}
+		ids = append(ids, document.DocumentID)
+	}
+	if !slices.Equal(ids, []int{3, 4, 5}) {
+		t.Errorf("DocumentsIter() = %v, want [3 4 5]", ids)
+	}
+
+	body, meta, err := client.DocumentDownload(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("DocumentDownload() error = %v", err)
+	}
+	content, err := io.ReadAll(body)
+	if closeErr := body.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		t.Fatalf("failed to read document: %v", err)
+	}
+
+	want := DocumentMeta{Name: "Protokoll GV.pdf", MimeType: "application/pdf", Size: 16}
+	if meta != want || string(content) != "%PDF-1.7 updated" {
+		t.Errorf("DocumentDownload() = %q, %+v, want %+v", content, meta, want)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "listed with 8 bytes") {
+		t.Errorf("warnings = %q, want size mismatch", warnings)
+	}
+
+	for id, wantErr := range map[int]error{4: ErrForbidden, 6: ErrNotFound} {
+		_, _, err := client.DocumentDownload(context.Background(), id)
+		if !errors.Is(err, wantErr) {
+			t.Errorf("DocumentDownload(%d) error = %v, want %v", id, err, wantErr)
+		}
+	}
+}
+
+func TestDocumentSizes(t *testing.T) {
+	var sizes documentSizes
+	documents := make([]Document, maxDocumentSizes)
+	for i := range documents {
+		documents[i] = Document{DocumentID: i + 1, Size: int64(i + 1)}
+	}
+	sizes.store(documents)
+
+	// Listing the first document again keeps it over the second one.
+	sizes.store(documents[:1])
+	sizes.store([]Document{{DocumentID: maxDocumentSizes + 1, Size: 7}})
+
+	if size, ok := sizes.lookup(1); !ok || size != 1 {
+		t.Errorf("lookup(1) = %d, %v, want 1, true", size, ok)
+	}
+	if _, ok := sizes.lookup(2); ok {
+		t.Error("lookup(2) found evicted size")
+	}
+	if size, ok := sizes.lookup(maxDocumentSizes + 1); !ok || size != 7 {
+		t.Errorf("lookup(%d) = %d, %v, want 7, true", maxDocumentSizes+1, size, ok)
+	}
+	if n := sizes.lru.Len(); n != maxDocumentSizes {
+		t.Errorf("kept %d sizes, want %d", n, maxDocumentSizes)
+	}
+}
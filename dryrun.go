@@ -0,0 +1,101 @@
+package fairgate
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// ErrDryRun is returned by requests modifying data of a client using
+// [WithDryRun] instead of sending them.
+var ErrDryRun = errors.New("dry run: request not sent")
+
+// redactedHeaders lists the headers whose values are not rendered in dry runs.
+var redactedHeaders = []string{"Authorization"}
+
+// WithDryRun renders requests modifying data to w instead of sending them, and
+// fails them with [ErrDryRun]. The requests are fully validated and built,
+// including their bodies. Requests reading data are sent as usual, so
+// operations reading data before modifying it keep working. Tokens are created
+// and refreshed as usual.
+func WithDryRun(w io.Writer) ClientOption {
+	return func(c *Client) {
+		c.dryRun = w
+	}
+}
+
+// dryRunRequest reports whether req is rendered instead of being sent.
+func (c *Client) dryRunRequest(req *http.Request) bool {
+	if _, auth := authOperation(req.Context()); c.dryRun == nil || auth {
+		return false
+	}
+
+	return req.Method != http.MethodGet && req.Method != http.MethodHead
+}
+
+// renderDryRun writes a human-readable rendering of req to the dry run writer
+// and returns [ErrDryRun].
+func (c *Client) renderDryRun(req *http.Request) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s %s\n", req.Method, req.URL)
+
+	for _, name := range slices.Sorted(maps.Keys(req.Header)) {
+		for _, value := range req.Header[name] {
+			if slices.Contains(redactedHeaders, name) {
+				value = "[REDACTED]"
+			}
+			fmt.Fprintf(&buf, "%s: %s\n", name, value)
+		}
+	}
+
+	body, err := dryRunBody(req)
+	if err != nil {
+		return fmt.Errorf("render dry run: %w", err)
+	}
+	if len(body) > 0 {
+		buf.WriteByte('\n')
+		if json.Indent(&buf, body, "", "  ") != nil {
+			buf.Write(body)
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+
+	if _, err := c.dryRun.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("render dry run: %w", err)
+	}
+
+	return ErrDryRun
+}
+
+// dryRunBody returns a copy of the uncompressed body of req.
+func dryRunBody(req *http.Request) ([]byte, error) {
+	if req.GetBody == nil {
+		return nil, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	r := io.Reader(body)
+	if strings.EqualFold(req.Header.Get("Content-Encoding"), "gzip") {
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		r = zr
+	}
+
+	return io.ReadAll(r)
+}
@@ -0,0 +1,86 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// unusedTransport fails the test if a request is sent.
+type unusedTransport struct {
+	t *testing.T
+}
+
+func (u unusedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	u.t.Errorf("unexpected request: %s %s", req.Method, req.URL)
+	return nil, errors.New("unexpected request")
+}
+
+func TestWithDryRun_ContactUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []ClientOption
+	}{
+		{name: "plain"},
+		{name: "compressed", opts: []ClientOption{WithCompression()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, publicKey := generateTestKeyPair(t)
+
+			var out bytes.Buffer
+			client := New("test-org", publicKey, append([]ClientOption{
+				WithBaseURLString("https://fsa.example.com/"),
+				WithHTTPClient(&http.Client{Transport: unusedTransport{t}}),
+				WithAccessKey("access-key"),
+				WithUserAgent("fairgate-test"),
+				WithDryRun(&out),
+			}, tt.opts...)...)
+
+			ctx := WithIdempotencyKey(context.Background(), "key-123")
+			err := client.ContactUpdate(ctx, 42, ContactUpdate{
+				LastUpdate: Time{time.Date(2024, 5, 1, 10, 0, 0, 0, APILocation)},
+				Basefields: &ContactBasefields{FirstName: "Anna", LastName: "Muster"},
+				Communication: &Communication{
+					// Pad the body beyond the compression threshold.
+					PrimaryEmail: string(bytes.Repeat([]byte("a"), compressionThreshold)) +
+						"@example.com",
+				},
+			})
+			if !errors.Is(err, ErrDryRun) {
+				t.Fatalf("ContactUpdate() error = %v, want ErrDryRun", err)
+			}
+
+			golden := "dry_run_contact_update.golden"
+			if tt.name == "compressed" {
+				golden = "dry_run_contact_update_compressed.golden"
+			}
+			assertGolden(t, golden, out.Bytes())
+		})
+	}
+}
+
+func TestWithDryRun_ReadsAreSent(t *testing.T) {
+	var out bytes.Buffer
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Response[Contact]{
+			Success: true,
+			Data:    Contact{Basefields: ContactBasefields{ContactID: 42}},
+		})
+	}), WithDryRun(&out))
+
+	resp, err := client.Contact(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+	if resp.Data.Basefields.ContactID != 42 {
+		t.Errorf("ContactID = %d, want 42", resp.Data.Basefields.ContactID)
+	}
+	if out.Len() != 0 {
+		t.Errorf("rendered %q, want nothing", out.String())
+	}
+}
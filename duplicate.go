@@ -0,0 +1,130 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// DuplicateParams represents the parameters for listing duplicate candidates.
+type DuplicateParams struct {
+	PageParams
+	// MinScore only returns groups with at least this match score.
+	MinScore float64 `url:"minScore,omitempty"`
+}
+
+// DuplicateGroup represents a group of contacts that likely represent the same person.
+type DuplicateGroup struct {
+	// Score is the match score of the group.
+	Score float64 `json:"score,omitempty"`
+	// Reason describes why the contacts were matched.
+	Reason string `json:"reason,omitempty"`
+	// Candidates are the contacts within the group.
+	Candidates []DuplicateCandidate `json:"candidates,omitempty"`
+}
+
+// DuplicateCandidate represents a single contact within a [DuplicateGroup].
+type DuplicateCandidate struct {
+	// ContactID is the ID of the contact.
+	ContactID int `json:"contact_id,omitempty"`
+	// OrganizationID is the oid of the organisation the contact belongs to.
+	OrganizationID string `json:"organization_id,omitempty"`
+	// Organization is the name of the organisation the contact belongs to.
+	Organization string `json:"organization,omitempty"`
+}
+
+// ContactIDs returns the IDs of all candidates in the group.
+func (g DuplicateGroup) ContactIDs() []int {
+	ids := make([]int, 0, len(g.Candidates))
+	for _, candidate := range g.Candidates {
+		ids = append(ids, candidate.ContactID)
+	}
+
+	return ids
+}
+
+type DuplicatesList struct {
+	Pagination `json:",inline"`
+	Duplicates []DuplicateGroup `json:"duplicates,omitempty"`
+}
+
+type ContactMergeRequest struct {
+	SourceContactIDs []int `json:"source_contact_ids"`
+}
+
+// ContactDuplicates returns an iterator over all duplicate candidate groups.
+func (c *Client) ContactDuplicates(
+	ctx context.Context,
+	params DuplicateParams,
+	opts ...IterOption,
+) iter.Seq2[DuplicateGroup, error] {
+	return iterate(
+		ctx,
+		func(ctx context.Context, p PageParams) ([]DuplicateGroup, Pagination, error) {
+			params.PageParams = p
+
+			list, err := c.contactDuplicates(ctx, params)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+			return list.Duplicates, list.Pagination, nil
+		},
+		c.iterOptions(opts)...,
+	)
+}
+
+// contactDuplicates retrieves a single page of duplicate candidate groups.
+func (c *Client) contactDuplicates(
+	ctx context.Context,
+	params DuplicateParams,
+) (*DuplicatesList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/duplicates", url.PathEscape(c.oid))
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[DuplicatesList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// ContactMerge merges the source contacts into the target contact.
+// The source contacts are removed afterwards, so this requires [WithDestructiveOps].
+// Validation failures, such as a source contact with open invoices, are
+// returned as [Error] values.
+func (c *Client) ContactMerge(ctx context.Context, targetID int, sourceIDs []int) error {
+	if !c.destructiveOps {
+		return fmt.Errorf("contact merge: %w", ErrDestructiveOpsDisabled)
+	}
+	if len(sourceIDs) == 0 {
+		return errors.New("contact merge: no source contacts")
+	}
+
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/%d/merge", url.PathEscape(c.oid), targetID),
+		nil,
+		ContactMergeRequest{SourceContactIDs: sourceIDs},
+	)
+	if err != nil {
+		return err
+	}
+
+	var result Response[json.RawMessage]
+	_, err = c.doJSON(req, &result)
+	return err
+}
@@ -0,0 +1,136 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestClient_ContactDuplicates(t *testing.T) {
+	pages := map[int][]DuplicateGroup{
+		1: {
+			{
+				Score:  0.98,
+				Reason: "same name and birthdate",
+				Candidates: []DuplicateCandidate{
+					{ContactID: 1, OrganizationID: "club-a", Organization: "Club A"},
+					{ContactID: 2, OrganizationID: "club-b", Organization: "Club B"},
+				},
+			},
+		},
+		2: {
+			{
+				Score:  0.81,
+				Reason: "same email",
+				Candidates: []DuplicateCandidate{
+					{ContactID: 3, OrganizationID: "club-a", Organization: "Club A"},
+					{ContactID: 4, OrganizationID: "club-c", Organization: "Club C"},
+				},
+			},
+		},
+	}
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fsa/v2.0/contact/test-org/contacts/duplicates" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("minScore"); got != "0.8" {
+			t.Errorf("minScore = %q, want 0.8", got)
+		}
+
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		writeJSON(w, http.StatusOK, Response[DuplicatesList]{
+			Success: true,
+			Data: DuplicatesList{
+				Pagination: Pagination{TotalRecords: 2, TotalPages: 2, PageNo: FlexInt(pageNo)},
+				Duplicates: pages[pageNo],
+			},
+		})
+	}))
+
+	var groups []DuplicateGroup
+	for group, err := range client.ContactDuplicates(
+		context.Background(),
+		DuplicateParams{MinScore: 0.8},
+	) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		groups = append(groups, group)
+	}
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+	if got := groups[1].ContactIDs(); !slices.Equal(got, []int{3, 4}) {
+		t.Errorf("ContactIDs() = %v, want [3 4]", got)
+	}
+	if groups[0].Candidates[1].Organization != "Club B" {
+		t.Errorf("Organization = %q, want Club B", groups[0].Candidates[1].Organization)
+	}
+}
+
+func TestClient_ContactMerge(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/fsa/v2.0/contact/test-org/contacts/1/merge" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var req ContactMergeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		if !slices.Equal(req.SourceContactIDs, []int{2, 3}) {
+			t.Errorf("SourceContactIDs = %v, want [2 3]", req.SourceContactIDs)
+		}
+
+		writeJSON(w, http.StatusOK, Response[any]{Code: 200, Success: true})
+	}), WithDestructiveOps())
+
+	if err := client.ContactMerge(context.Background(), 1, []int{2, 3}); err != nil {
+		t.Errorf("ContactMerge() error = %v", err)
+	}
+}
+
+func TestClient_ContactMerge_Conflict(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusConflict, Response[any]{
+			Code:    409,
+			Success: false,
+			Errors: []Error{
+				{Field: "source_contact_ids", Message: "contact 3 has open invoices"},
+			},
+		})
+	}), WithDestructiveOps())
+
+	err := client.ContactMerge(context.Background(), 1, []int{2, 3})
+	if !errors.Is(err, ErrStatus) {
+		t.Errorf("ContactMerge() error = %v, want ErrStatus", err)
+	}
+
+	var fieldErr Error
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("ContactMerge() error = %v, want field error", err)
+	}
+	if fieldErr.Field != "source_contact_ids" {
+		t.Errorf("Field = %q, want source_contact_ids", fieldErr.Field)
+	}
+}
+
+func TestClient_ContactMerge_Disabled(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("server should not be called without destructive ops")
+	}))
+
+	err := client.ContactMerge(context.Background(), 1, []int{2})
+	if !errors.Is(err, ErrDestructiveOpsDisabled) {
+		t.Errorf("ContactMerge() error = %v, want ErrDestructiveOpsDisabled", err)
+	}
+}
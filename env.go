@@ -0,0 +1,113 @@
+package fairgate
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Environment variables read by [NewFromEnv].
+const (
+	// EnvOrganisationID is the organisation ID.
+	EnvOrganisationID = "FAIRGATE_OID"
+	// EnvAccessKey is the access key used to create tokens.
+	EnvAccessKey = "FAIRGATE_ACCESS_KEY"
+	// EnvPublicKey is the PEM encoded public key verifying tokens. Escaped
+	// newlines ("\n") are supported for platforms without multi-line values.
+	EnvPublicKey = "FAIRGATE_PUBLIC_KEY"
+	// EnvPublicKeyFile is the path of the PEM encoded public key, as an
+	// alternative to EnvPublicKey.
+	EnvPublicKeyFile = "FAIRGATE_PUBLIC_KEY_FILE"
+	// EnvEnvironment selects the endpoint, either "prod" or "test". Defaults to
+	// "prod".
+	EnvEnvironment = "FAIRGATE_ENV"
+)
+
+// ErrInvalidEnv is returned by [NewFromEnv] when an environment variable is
+// missing, invalid, or contradicts another variable.
+var ErrInvalidEnv = errors.New("invalid environment variable")
+
+// NewFromEnv creates a Fairgate API client configured by the environment
+// variables EnvOrganisationID, EnvAccessKey, either EnvPublicKey or
+// EnvPublicKeyFile, and optionally EnvEnvironment. The client lazily creates
+// tokens using the access key, see [WithAccessKey]. opts are applied after the
+// configuration of the environment, so they take precedence.
+//
+// It returns an error wrapping [ErrInvalidEnv] and naming the variable if a
+// variable is missing or invalid.
+func NewFromEnv(opts ...ClientOption) (*Client, error) {
+	var errs []error
+	envErr := func(name, format string, args ...any) {
+		errs = append(
+			errs,
+			fmt.Errorf("%w %s: %s", ErrInvalidEnv, name, fmt.Sprintf(format, args...)),
+		)
+	}
+
+	oid := os.Getenv(EnvOrganisationID)
+	if oid == "" {
+		envErr(EnvOrganisationID, "not set")
+	}
+	accessKey := os.Getenv(EnvAccessKey)
+	if accessKey == "" {
+		envErr(EnvAccessKey, "not set")
+	}
+
+	key, name, err := publicKeyFromEnv()
+	if err != nil {
+		envErr(name, "%v", err)
+	}
+
+	envOpts := []ClientOption{WithAccessKey(accessKey)}
+	switch env := os.Getenv(EnvEnvironment); env {
+	case "", "prod":
+	case "test":
+		envOpts = append(envOpts, WithTest())
+	default:
+		envErr(EnvEnvironment, "%q is neither prod nor test", env)
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return New(oid, key, append(envOpts, opts...)...), nil
+}
+
+// publicKeyFromEnv parses the public key given by EnvPublicKey or
+// EnvPublicKeyFile. It returns the name of the variable to report errors for.
+func publicKeyFromEnv() (*ecdsa.PublicKey, string, error) {
+	value := os.Getenv(EnvPublicKey)
+	path := os.Getenv(EnvPublicKeyFile)
+
+	var data []byte
+	switch {
+	case value != "" && path != "":
+		return nil, EnvPublicKey, fmt.Errorf("conflicts with %s, set only one", EnvPublicKeyFile)
+	case value != "":
+		data = []byte(strings.ReplaceAll(value, `\n`, "\n"))
+	case path != "":
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, EnvPublicKeyFile, err
+		}
+	default:
+		return nil, EnvPublicKey, fmt.Errorf("neither it nor %s is set", EnvPublicKeyFile)
+	}
+
+	key, err := jwt.ParseECPublicKeyFromPEM(data)
+	if err != nil {
+		name := EnvPublicKey
+		if path != "" {
+			name = EnvPublicKeyFile
+		}
+		return nil, name, err
+	}
+
+	return key, "", nil
+}
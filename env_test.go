@@ -0,0 +1,156 @@
+package fairgate
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewFromEnv(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	keyFile := filepath.Join(t.TempDir(), "fairgate.pem")
+	if err := os.WriteFile(keyFile, []byte(keyPEM), 0o600); err != nil {
+		t.Fatalf("failed to write public key: %v", err)
+	}
+
+	valid := map[string]string{
+		EnvOrganisationID: "org",
+		EnvAccessKey:      "access-key",
+		EnvPublicKey:      keyPEM,
+	}
+	with := func(overrides map[string]string) map[string]string {
+		env := map[string]string{}
+		for k, v := range valid {
+			env[k] = v
+		}
+		for k, v := range overrides {
+			env[k] = v
+		}
+		return env
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		opts    []ClientOption
+		wantURL string
+		// wantErrs lists the variables named by the error.
+		wantErrs []string
+	}{
+		{name: "key value", env: valid, wantURL: ProductionURL},
+		{
+			name:    "key value with escaped newlines",
+			env:     with(map[string]string{EnvPublicKey: strings.ReplaceAll(keyPEM, "\n", `\n`)}),
+			wantURL: ProductionURL,
+		},
+		{
+			name:    "key file",
+			env:     with(map[string]string{EnvPublicKey: "", EnvPublicKeyFile: keyFile}),
+			wantURL: ProductionURL,
+		},
+		{
+			name:    "prod",
+			env:     with(map[string]string{EnvEnvironment: "prod"}),
+			wantURL: ProductionURL,
+		},
+		{
+			name:    "test",
+			env:     with(map[string]string{EnvEnvironment: "test"}),
+			wantURL: TestURL,
+		},
+		{
+			name:    "option overrides environment",
+			env:     with(map[string]string{EnvEnvironment: "test"}),
+			opts:    []ClientOption{WithBaseURLString("https://gateway.example.com/")},
+			wantURL: "https://gateway.example.com/",
+		},
+		{
+			name:     "invalid environment",
+			env:      with(map[string]string{EnvEnvironment: "staging"}),
+			wantErrs: []string{EnvEnvironment},
+		},
+		{
+			name:     "missing organisation ID",
+			env:      with(map[string]string{EnvOrganisationID: ""}),
+			wantErrs: []string{EnvOrganisationID},
+		},
+		{
+			name:     "missing access key",
+			env:      with(map[string]string{EnvAccessKey: ""}),
+			wantErrs: []string{EnvAccessKey},
+		},
+		{
+			name:     "missing key",
+			env:      with(map[string]string{EnvPublicKey: ""}),
+			wantErrs: []string{EnvPublicKey},
+		},
+		{
+			name:     "key value and file",
+			env:      with(map[string]string{EnvPublicKeyFile: keyFile}),
+			wantErrs: []string{EnvPublicKey},
+		},
+		{
+			name:     "invalid key value",
+			env:      with(map[string]string{EnvPublicKey: "not a key"}),
+			wantErrs: []string{EnvPublicKey},
+		},
+		{
+			name: "missing key file",
+			env: with(map[string]string{
+				EnvPublicKey:     "",
+				EnvPublicKeyFile: filepath.Join(t.TempDir(), "missing.pem"),
+			}),
+			wantErrs: []string{EnvPublicKeyFile},
+		},
+		{
+			name:     "all missing",
+			env:      map[string]string{},
+			wantErrs: []string{EnvOrganisationID, EnvAccessKey, EnvPublicKey},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{
+				EnvOrganisationID, EnvAccessKey, EnvPublicKey, EnvPublicKeyFile, EnvEnvironment,
+			} {
+				t.Setenv(name, tt.env[name])
+			}
+
+			client, err := NewFromEnv(tt.opts...)
+			if len(tt.wantErrs) > 0 {
+				if !errors.Is(err, ErrInvalidEnv) {
+					t.Fatalf("NewFromEnv() error = %v, want %v", err, ErrInvalidEnv)
+				}
+				for _, name := range tt.wantErrs {
+					if !strings.Contains(err.Error(), name) {
+						t.Errorf("NewFromEnv() error = %v, want it to name %s", err, name)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewFromEnv() error = %v", err)
+			}
+
+			if got := client.baseURL.String(); got != tt.wantURL {
+				t.Errorf("base URL = %q, want %q", got, tt.wantURL)
+			}
+			if client.oid != "org" {
+				t.Errorf("organisation ID = %q, want org", client.oid)
+			}
+			if client.auth.accessKey != "access-key" {
+				t.Errorf("access key = %q, want access-key", client.auth.accessKey)
+			}
+		})
+	}
+}
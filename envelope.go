@@ -0,0 +1,122 @@
+package fairgate
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// envelope decodes the parts of response envelopes that differ between API
+// versions into the common [Response] types.
+type envelope interface {
+	// decodeErrors decodes the errors of an envelope.
+	decodeErrors(raw json.RawMessage) ([]Error, error)
+}
+
+// envelopeFor returns the envelope of the API version of path.
+func envelopeFor(path string) envelope {
+	if strings.HasPrefix(path, "/fsa/v1.") {
+		return envelopeV1{}
+	}
+
+	return envelopeV2{}
+}
+
+// envelopeV2 is the envelope of API version 2.0, reporting errors as a list of
+// objects with field and message.
+type envelopeV2 struct{}
+
+func (envelopeV2) decodeErrors(raw json.RawMessage) ([]Error, error) {
+	var errs []Error
+	if err := json.Unmarshal(raw, &errs); err != nil {
+		return nil, err
+	}
+
+	return errs, nil
+}
+
+// envelopeV1 is the envelope of API version 1.1, reporting errors as an object
+// mapping fields to a message or a list of messages, or as a list of messages
+// without field.
+type envelopeV1 struct{}
+
+func (envelopeV1) decodeErrors(raw json.RawMessage) ([]Error, error) {
+	var byField map[string]json.RawMessage
+	if json.Unmarshal(raw, &byField) == nil {
+		var errs []Error
+		for _, field := range slices.Sorted(maps.Keys(byField)) {
+			messages, err := decodeMessages(byField[field])
+			if err != nil {
+				return nil, err
+			}
+			for _, message := range messages {
+				errs = append(errs, Error{Field: field, Message: message})
+			}
+		}
+		return errs, nil
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+	errs := make([]Error, 0, len(list))
+	for _, item := range list {
+		var message string
+		if json.Unmarshal(item, &message) == nil {
+			errs = append(errs, Error{Message: message})
+			continue
+		}
+
+		var e Error
+		if err := json.Unmarshal(item, &e); err != nil {
+			return nil, err
+		}
+		errs = append(errs, e)
+	}
+
+	return errs, nil
+}
+
+// decodeMessages decodes a message or a list of messages.
+func decodeMessages(raw json.RawMessage) ([]string, error) {
+	var message string
+	if json.Unmarshal(raw, &message) == nil {
+		return []string{message}, nil
+	}
+
+	var messages []string
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// envelopeDecoder is implemented by [Response] to decode an envelope of a
+// specific API version.
+type envelopeDecoder interface {
+	unmarshalEnvelope(codec JSONCodec, data []byte, e envelope) error
+}
+
+// unmarshalResponse decodes data into v using codec, using the envelope of the
+// API version of path if v is a [Response].
+func unmarshalResponse(codec JSONCodec, path string, data []byte, v any) error {
+	if d, ok := v.(envelopeDecoder); ok {
+		return d.unmarshalEnvelope(codec, data, envelopeFor(path))
+	}
+
+	return unmarshalJSON(codec, data, v)
+}
+
+// decodeResponse reads r and decodes it into v, see [unmarshalResponse].
+func decodeResponse(path string, r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	return unmarshalResponse(standardJSONCodec{}, path, data, v)
+}
@@ -0,0 +1,148 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// statusFixtureHandler responds with status and the fixture name.
+func statusFixtureHandler(t *testing.T, status int, name string) http.Handler {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(data)
+	})
+}
+
+func TestUnmarshalResponse_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		path        string
+		wantCode    int
+		wantMessage string
+		wantErrors  []Error
+	}{
+		{
+			name:        "v1.1 errors by field",
+			fixture:     "error_v1.1_fields.json",
+			path:        "/fsa/v1.1/auth/create/test-org/token",
+			wantCode:    401,
+			wantMessage: "Authentication failed",
+			wantErrors: []Error{
+				{Field: "access_key", Message: "Access key expired"},
+				{Field: "access_key", Message: "Access key must be renewed"},
+				{Field: "oid", Message: "Unknown organisation"},
+			},
+		},
+		{
+			name:        "v1.1 error list",
+			fixture:     "error_v1.1_list.json",
+			path:        "/fsa/v1.1/auth/refresh/test-org/token",
+			wantCode:    400,
+			wantMessage: "Invalid request",
+			wantErrors:  []Error{{Message: "refresh_token is required"}},
+		},
+		{
+			name:        "v2.0 errors",
+			fixture:     "error_v2.0.json",
+			path:        "/fsa/v2.0/contact/test-org/contacts/1",
+			wantCode:    400,
+			wantMessage: "Validation failed",
+			wantErrors: []Error{
+				{Field: "primary_email", Message: "is not a valid email address"},
+				{Field: "last_name", Message: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatalf("failed to read fixture: %v", err)
+			}
+
+			var resp Response[any]
+			if err := unmarshalResponse(standardJSONCodec{}, tt.path, data, &resp); err != nil {
+				t.Fatalf("unmarshalResponse() error = %v", err)
+			}
+
+			if resp.Code != tt.wantCode || resp.Message != tt.wantMessage {
+				t.Errorf("response = %+v, want code %d with message %q",
+					resp, tt.wantCode, tt.wantMessage)
+			}
+			if !slices.Equal(resp.Errors, tt.wantErrors) {
+				t.Errorf("Errors = %+v, want %+v", resp.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestClient_EnvelopeErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		fixture    string
+		call       func(*Client) error
+		wantErrors int
+	}{
+		{
+			name:    "v1.1 token create",
+			status:  http.StatusUnauthorized,
+			fixture: "error_v1.1_fields.json",
+			call: func(c *Client) error {
+				return c.TokenCreate(context.Background(), "access-key")
+			},
+			wantErrors: 3,
+		},
+		{
+			name:    "v1.1 access keys",
+			status:  http.StatusBadRequest,
+			fixture: "error_v1.1_list.json",
+			call: func(c *Client) error {
+				_, err := c.AccessKeys(context.Background())
+				return err
+			},
+			wantErrors: 1,
+		},
+		{
+			name:    "v2.0 contact",
+			status:  http.StatusBadRequest,
+			fixture: "error_v2.0.json",
+			call: func(c *Client) error {
+				_, err := c.Contact(context.Background(), 1)
+				return err
+			},
+			wantErrors: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, statusFixtureHandler(t, tt.status, tt.fixture))
+
+			err := tt.call(client)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *APIError", err)
+			}
+			if len(apiErr.Errors) != tt.wantErrors {
+				t.Errorf("APIError.Errors = %+v, want %d errors", apiErr.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// ErrTokenEnvironmentMismatch is returned when a token was issued for another
+// environment than the one the client talks to, e.g. a token of the test
+// environment used with the production endpoint.
+var ErrTokenEnvironmentMismatch = errors.New("token issued for another environment")
+
+// environmentHosts are the hosts of the known environments, see [ProductionURL]
+// and [TestURL].
+var environmentHosts = []string{productionURL.Hostname(), testURL.Hostname()}
+
+// tokenEnvironment holds the expected issuer and audience of tokens.
+type tokenEnvironment struct {
+	// host is the host of the known environment the base URL points to, or ""
+	// for custom base URLs. Tokens naming the host of another known
+	// environment as issuer or audience are rejected.
+	host string
+	// issuer and audience are the exact values expected, if configured.
+	issuer   string
+	audience string
+}
+
+// knownEnvironmentHost returns host if it is the host of a known environment,
+// or "" otherwise.
+func knownEnvironmentHost(host string) string {
+	if slices.ContainsFunc(environmentHosts, func(known string) bool {
+		return strings.EqualFold(known, host)
+	}) {
+		return host
+	}
+
+	return ""
+}
+
+// WithExpectedIssuer only accepts tokens issued by issuer. By default, tokens
+// for the production or test environment are rejected if their issuer names
+// the other environment. Tokens for custom base URLs are not checked.
+func WithExpectedIssuer(issuer string) ClientOption {
+	return func(c *Client) {
+		c.auth.environment.issuer = issuer
+	}
+}
+
+// WithExpectedAudience only accepts tokens for audience. By default, tokens
+// for the production or test environment are rejected if their audience only
+// names the other environment. Tokens for custom base URLs are not checked.
+func WithExpectedAudience(audience string) ClientOption {
+	return func(c *Client) {
+		c.auth.environment.audience = audience
+	}
+}
+
+// check returns an error wrapping [ErrTokenEnvironmentMismatch] if claim was
+// issued for another environment.
+func (e tokenEnvironment) check(claim *jwtClaim) error {
+	switch {
+	case e.issuer != "":
+		if claim.Issuer != e.issuer {
+			return fmt.Errorf(
+				"%w: issuer %q, want %q",
+				ErrTokenEnvironmentMismatch,
+				claim.Issuer,
+				e.issuer,
+			)
+		}
+	case e.host != "":
+		if e.foreign(claimHost(claim.Issuer)) {
+			return fmt.Errorf(
+				"%w: issuer %q, want host %q",
+				ErrTokenEnvironmentMismatch,
+				claim.Issuer,
+				e.host,
+			)
+		}
+	}
+
+	switch {
+	case e.audience != "":
+		if !slices.Contains(claim.Audience, e.audience) {
+			return fmt.Errorf(
+				"%w: audience %q, want %q",
+				ErrTokenEnvironmentMismatch,
+				[]string(claim.Audience),
+				e.audience,
+			)
+		}
+	case e.host != "" && len(claim.Audience) > 0:
+		var foreign bool
+		for _, aud := range claim.Audience {
+			host := claimHost(aud)
+			if strings.EqualFold(host, e.host) {
+				foreign = false
+				break
+			}
+			foreign = foreign || e.foreign(host)
+		}
+		if foreign {
+			return fmt.Errorf(
+				"%w: audience %q, want host %q",
+				ErrTokenEnvironmentMismatch,
+				[]string(claim.Audience),
+				e.host,
+			)
+		}
+	}
+
+	return nil
+}
+
+// foreign reports whether host is the host of another known environment.
+func (e tokenEnvironment) foreign(host string) bool {
+	return host != "" && !strings.EqualFold(host, e.host) && knownEnvironmentHost(host) != ""
+}
+
+// claimHost returns the host named by an issuer or audience value, such as a
+// URL or host name. It returns "" for other values.
+func claimHost(s string) string {
+	if u, err := url.Parse(s); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	if strings.Contains(s, ".") && !strings.ContainsAny(s, " /:") {
+		return s
+	}
+
+	return ""
+}
@@ -0,0 +1,146 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"thde.io/fairgate/fairgatetest"
+)
+
+func TestClient_TokenEnvironment(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	claims := fairgatetest.Claims()
+
+	tests := []struct {
+		name      string
+		claims    fairgatetest.TokenClaims
+		expiresAt time.Duration
+		opts      []ClientOption
+		wantErr   error
+	}{
+		{name: "no issuer or audience", claims: claims},
+		{name: "opaque issuer", claims: claims.WithIssuer("fairgate")},
+		{
+			name:   "issuer of production",
+			claims: claims.WithIssuer("https://fsa.fairgate.ch/"),
+			opts:   []ClientOption{WithBaseURL(mustParseURL(ProductionURL))},
+		},
+		{
+			name:    "issuer of test for production",
+			claims:  claims.WithIssuer("https://fsa-test.fairgate.ch/"),
+			opts:    []ClientOption{WithBaseURL(mustParseURL(ProductionURL))},
+			wantErr: ErrTokenEnvironmentMismatch,
+		},
+		{
+			name:    "issuer of production for test",
+			claims:  claims.WithIssuer("https://fsa.fairgate.ch/"),
+			opts:    []ClientOption{WithTest()},
+			wantErr: ErrTokenEnvironmentMismatch,
+		},
+		{
+			name:   "unknown issuer host for production",
+			claims: claims.WithIssuer("https://login.fairgate.ch/"),
+			opts:   []ClientOption{WithBaseURL(mustParseURL(ProductionURL))},
+		},
+		{
+			name:    "audience of test for production",
+			claims:  claims.WithAudience("fsa-test.fairgate.ch"),
+			opts:    []ClientOption{WithBaseURL(mustParseURL(ProductionURL))},
+			wantErr: ErrTokenEnvironmentMismatch,
+		},
+		{
+			name:   "audience including production",
+			claims: claims.WithAudience("fsa-test.fairgate.ch", "fsa.fairgate.ch"),
+			opts:   []ClientOption{WithBaseURL(mustParseURL(ProductionURL))},
+		},
+		{
+			name:   "issuer of test for custom base URL",
+			claims: claims.WithIssuer("https://fsa-test.fairgate.ch/"),
+		},
+		{
+			name:   "audience of test for custom base URL",
+			claims: claims.WithAudience("fsa-test.fairgate.ch"),
+		},
+		{
+			name:   "expected issuer",
+			claims: claims.WithIssuer("fsa-prod"),
+			opts:   []ClientOption{WithExpectedIssuer("fsa-prod")},
+		},
+		{
+			name:    "unexpected issuer",
+			claims:  claims.WithIssuer("fsa-test"),
+			opts:    []ClientOption{WithExpectedIssuer("fsa-prod")},
+			wantErr: ErrTokenEnvironmentMismatch,
+		},
+		{
+			name:    "missing expected audience",
+			claims:  claims,
+			opts:    []ClientOption{WithExpectedAudience("fsa-prod")},
+			wantErr: ErrTokenEnvironmentMismatch,
+		},
+		{
+			name:   "expected audience",
+			claims: claims.WithAudience("fsa-prod", "other"),
+			opts:   []ClientOption{WithExpectedAudience("fsa-prod")},
+		},
+		{
+			name:      "expired token of production",
+			claims:    claims.WithIssuer("https://fsa.fairgate.ch/"),
+			opts:      []ClientOption{WithBaseURL(mustParseURL(ProductionURL))},
+			expiresAt: -time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiresAt := time.Now().Add(time.Hour)
+			if tt.expiresAt != 0 {
+				expiresAt = time.Now().Add(tt.expiresAt)
+			}
+			token, err := fairgatetest.SignToken(privateKey, tt.claims, expiresAt)
+			if err != nil {
+				t.Fatalf("failed to sign token: %v", err)
+			}
+
+			server := httptest.NewServer(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+						Success: true,
+						Data:    CreateTokenResponse{Token: token, RefreshToken: "refresh"},
+					})
+				}),
+			)
+			t.Cleanup(server.Close)
+			// Requests to the known environments are sent to the server.
+			serverURL := mustParseURL(server.URL)
+			httpClient := &http.Client{
+				Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					req.URL.Scheme = serverURL.Scheme
+					req.URL.Host = serverURL.Host
+					return server.Client().Transport.RoundTrip(req)
+				}),
+			}
+			opts := append([]ClientOption{
+				WithHTTPClient(httpClient),
+				WithBaseURL(serverURL),
+			}, tt.opts...)
+			client := New("test-org", publicKey, opts...)
+
+			err = client.TokenCreate(context.Background(), "access-key")
+			if tt.expiresAt < 0 {
+				// Expired tokens fail for another reason.
+				if err == nil || errors.Is(err, ErrTokenEnvironmentMismatch) {
+					t.Errorf("TokenCreate() error = %v, want expiry error", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("TokenCreate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
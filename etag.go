@@ -0,0 +1,121 @@
+package fairgate
+
+import (
+	"container/list"
+	"net/http"
+	"reflect"
+	"sync"
+)
+
+// WithETagCache enables conditional requests for up to maxEntries responses.
+// The decoded values of GET responses carrying an ETag header are kept, and
+// repeated requests are sent with an If-None-Match header. If the server
+// responds with 304 Not Modified, the kept value is returned without decoding.
+// Values returned from the cache share slices, maps, and pointers with the
+// kept value, so they must not be modified.
+func WithETagCache(maxEntries int) ClientOption {
+	return func(c *Client) {
+		if maxEntries <= 0 {
+			c.etags = nil
+			return
+		}
+
+		c.etags = &etagCache{
+			maxEntries: maxEntries,
+			entries:    map[string]*list.Element{},
+			lru:        list.New(),
+		}
+	}
+}
+
+// etagEntry is a decoded response kept by an [etagCache].
+type etagEntry struct {
+	key   string
+	etag  string
+	value reflect.Value
+}
+
+// etagCache keeps decoded responses by request, evicting the least recently
+// used entry. It is safe for concurrent use.
+type etagCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	lru        *list.List
+}
+
+// etagKey returns the cache key of req, or "" if responses to req aren't kept.
+func etagKey(req *http.Request) string {
+	if req.Method != http.MethodGet {
+		return ""
+	}
+
+	return req.Method + " " + req.URL.RequestURI()
+}
+
+// lookup returns the entry kept for req if it can be decoded into v.
+func (e *etagCache) lookup(req *http.Request, v any) *etagEntry {
+	key := etagKey(req)
+	if e == nil || key == "" || v == nil {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	elem, ok := e.entries[key]
+	if !ok {
+		return nil
+	}
+
+	entry := elem.Value.(*etagEntry)
+	if entry.value.Type() != reflect.TypeOf(v).Elem() {
+		return nil
+	}
+	e.lru.MoveToFront(elem)
+
+	return entry
+}
+
+// store keeps v decoded from resp to req, if resp carries an ETag.
+func (e *etagCache) store(req *http.Request, resp *http.Response, v any) {
+	key := etagKey(req)
+	etag := resp.Header.Get("ETag")
+	if e == nil || key == "" || etag == "" {
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return
+	}
+	entry := &etagEntry{key: key, etag: etag, value: reflect.New(rv.Type().Elem()).Elem()}
+	entry.value.Set(rv.Elem())
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if elem, ok := e.entries[key]; ok {
+		elem.Value = entry
+		e.lru.MoveToFront(elem)
+		return
+	}
+
+	e.entries[key] = e.lru.PushFront(entry)
+	if e.lru.Len() > e.maxEntries {
+		oldest := e.lru.Back()
+		e.lru.Remove(oldest)
+		delete(e.entries, oldest.Value.(*etagEntry).key)
+	}
+}
+
+// load sets v to the value kept by entry.
+func (entry *etagEntry) load(v any) {
+	reflect.ValueOf(v).Elem().Set(entry.value)
+}
+
+// notModified reports whether resp is the response to a conditional request
+// whose kept value is still valid.
+func notModified(req *http.Request, resp *http.Response) bool {
+	return resp.StatusCode == http.StatusNotModified && req.Header.Get("If-None-Match") != ""
+}
@@ -0,0 +1,102 @@
+package fairgate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+// etagHandler serves contacts with an ETag derived from the contact ID and
+// answers matching conditional requests with 304 Not Modified.
+func etagHandler(hits, notModified *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+
+		id := r.PathValue("id")
+		etag := fmt.Sprintf(`"contact-%s"`, id)
+		if r.Header.Get("If-None-Match") == etag {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", etag)
+		writeJSON(w, http.StatusOK, map[string]any{
+			"code":    200,
+			"success": true,
+			"data":    map[string]any{"basefields": map[string]any{"first_name": "Jane " + id}},
+		})
+	})
+}
+
+func TestWithETagCache(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxEntries      int
+		ids             []int
+		wantHits        int32
+		wantNotModified int32
+	}{
+		{
+			name:            "repeated fetch",
+			maxEntries:      10,
+			ids:             []int{1, 1, 1},
+			wantHits:        3,
+			wantNotModified: 2,
+		},
+		{
+			name:            "distinct contacts",
+			maxEntries:      10,
+			ids:             []int{1, 2, 1, 2},
+			wantHits:        4,
+			wantNotModified: 2,
+		},
+		{
+			name:            "evicted entry",
+			maxEntries:      1,
+			ids:             []int{1, 2, 1},
+			wantHits:        3,
+			wantNotModified: 0,
+		},
+		{name: "disabled", maxEntries: 0, ids: []int{1, 1}, wantHits: 2, wantNotModified: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits, notModified atomic.Int32
+			mux := http.NewServeMux()
+			mux.Handle(
+				"GET /fsa/v2.0/contact/test-org/contacts/{id}/extended",
+				etagHandler(&hits, &notModified),
+			)
+			client, _ := newTestClient(t, mux, WithETagCache(tt.maxEntries))
+
+			first := map[int]*Response[Contact]{}
+			for _, id := range tt.ids {
+				resp, err := client.Contact(context.Background(), id)
+				if err != nil {
+					t.Fatalf("Contact(%d) error = %v", id, err)
+				}
+				if want, ok := first[id]; ok && !reflect.DeepEqual(resp, want) {
+					t.Errorf("Contact(%d) = %+v, want %+v", id, resp, want)
+				}
+				if resp.Data.Basefields.FirstName != fmt.Sprintf("Jane %d", id) {
+					t.Errorf("Contact(%d) FirstName = %q", id, resp.Data.Basefields.FirstName)
+				}
+				if _, ok := first[id]; !ok {
+					first[id] = resp
+				}
+			}
+
+			if got := hits.Load(); got != tt.wantHits {
+				t.Errorf("server hits = %d, want %d", got, tt.wantHits)
+			}
+			if got := notModified.Load(); got != tt.wantNotModified {
+				t.Errorf("304 responses = %d, want %d", got, tt.wantNotModified)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package fairgate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"thde.io/fairgate"
+	"thde.io/fairgate/fairgatetest"
+)
+
+// newExampleServer starts a fake API serving three contacts.
+func newExampleServer() *fairgatetest.Server {
+	server, err := fairgatetest.NewServer(
+		"access-key",
+		fairgatetest.Contact{ID: 1, FirstName: "Anna", LastName: "Muster"},
+		fairgatetest.Contact{ID: 2, FirstName: "Beat", LastName: "Beispiel"},
+		fairgatetest.Contact{ID: 3, FirstName: "Carla", LastName: "Test"},
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return server
+}
+
+func ExampleClient_ContactsIter() {
+	server := newExampleServer()
+	defer server.Close()
+
+	client := fairgate.New(
+		"org",
+		server.PublicKey,
+		fairgate.WithBaseURLString(server.URL),
+		fairgate.WithAccessKey("access-key"),
+	)
+
+	for contact, err := range client.ContactsIter(context.Background(), fairgate.WithPageLimit(2)) {
+		// Errors end the iteration, e.g. a failed page request.
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Println(contact.Basefields.ContactID, contact.Basefields.FirstName)
+
+		// Breaking the loop stops requesting further pages.
+		if contact.Basefields.ContactID == 2 {
+			break
+		}
+	}
+	// Output:
+	// 1 Anna
+	// 2 Beat
+}
+
+func ExampleClient_TokenCreate() {
+	server := newExampleServer()
+	defer server.Close()
+
+	client := fairgate.New("org", server.PublicKey, fairgate.WithBaseURLString(server.URL))
+
+	err := client.TokenCreate(context.Background(), "wrong-key")
+	fmt.Println("wrong key rejected:", errors.Is(err, fairgate.ErrAuthFailed))
+
+	if err := client.TokenCreate(context.Background(), "access-key"); err != nil {
+		log.Fatal(err)
+	}
+
+	resp, err := client.Contact(context.Background(), 3)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(resp.Data.Basefields.FirstName, resp.Data.Basefields.LastName)
+	// Output:
+	// wrong key rejected: true
+	// Carla Test
+}
+
+func ExampleNew_withOptions() {
+	server := newExampleServer()
+	defer server.Close()
+
+	// Invalid options are reported when creating the client.
+	_, err := fairgate.NewWithOptions("org", server.PublicKey,
+		fairgate.Option(fairgate.WithHTTPClient(nil)),
+	)
+	fmt.Println("invalid option:", errors.Is(err, fairgate.ErrInvalidOption))
+
+	client, err := fairgate.NewWithOptions("org", server.PublicKey,
+		fairgate.Option(fairgate.WithBaseURLString(server.URL)),
+		fairgate.Option(fairgate.WithAccessKey("access-key")),
+		fairgate.Option(fairgate.WithUserAgent("example/1.0")),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = client.Contact(context.Background(), 42)
+	fmt.Println("missing contact:", errors.Is(err, fairgate.ErrNotFound))
+	// Output:
+	// invalid option: true
+	// missing contact: true
+}
+
+func Example_rateLimitHandling() {
+	server := newExampleServer()
+	defer server.Close()
+
+	// By default, rate limited requests are retried once the limit passes.
+	client := fairgate.New(
+		"org",
+		server.PublicKey,
+		fairgate.WithBaseURLString(server.URL),
+		fairgate.WithAccessKey("access-key"),
+	)
+	if err := client.TokenCreate(context.Background(), "access-key"); err != nil {
+		log.Fatal(err)
+	}
+
+	server.RateLimit(2)
+	resp, err := client.Contact(context.Background(), 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("retried:", resp.Data.Basefields.FirstName)
+
+	// Failing fast returns a RateLimitError instead, e.g. to reschedule a job.
+	failFast := fairgate.New(
+		"org",
+		server.PublicKey,
+		fairgate.WithBaseURLString(server.URL),
+		fairgate.WithAccessKey("access-key"),
+		fairgate.WithFailFastOnRateLimit(),
+	)
+	if err := failFast.TokenCreate(context.Background(), "access-key"); err != nil {
+		log.Fatal(err)
+	}
+
+	server.RateLimit(1)
+	_, err = failFast.Contact(context.Background(), 1)
+	var rateLimitErr *fairgate.RateLimitError
+	fmt.Println("rate limited:", errors.As(err, &rateLimitErr))
+	// Output:
+	// retried: Anna
+	// rate limited: true
+}
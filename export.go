@@ -0,0 +1,136 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// ContactExportSchemaVersion is the version of the JSON document produced by
+// marshaling a [ContactExport]. It is incremented whenever fields are removed
+// or change their meaning.
+const ContactExportSchemaVersion = 1
+
+// ExportStatus is the status of a section of a [ContactExport].
+type ExportStatus string
+
+const (
+	// ExportStatusOK marks a section that was exported completely.
+	ExportStatusOK ExportStatus = "ok"
+	// ExportStatusForbidden marks a section the access key may not read.
+	ExportStatusForbidden ExportStatus = "forbidden"
+	// ExportStatusNotFound marks a section the API doesn't provide for the contact.
+	ExportStatusNotFound ExportStatus = "not_found"
+	// ExportStatusUnavailable marks a section the API has no endpoint for, so
+	// it can't be exported.
+	ExportStatusUnavailable ExportStatus = "unavailable"
+)
+
+// ExportSection is a section of a [ContactExport].
+type ExportSection[T any] struct {
+	// Status reports whether Data is complete.
+	Status ExportStatus `json:"status"`
+	// Error describes why the section is missing, if it is.
+	Error string `json:"error,omitempty"`
+	// Data is the exported data. It is the zero value unless Status is
+	// [ExportStatusOK].
+	Data T `json:"data,omitzero"`
+}
+
+// ContactExport is everything Fairgate holds about a single contact, as
+// needed to answer a data-subject access request. It marshals to a single JSON
+// document.
+type ContactExport struct {
+	// SchemaVersion is [ContactExportSchemaVersion].
+	SchemaVersion int `json:"schema_version"`
+	// ContactID is the ID of the exported contact.
+	ContactID int `json:"contact_id"`
+	// ExportedAt is the local time the export was started.
+	ExportedAt time.Time `json:"exported_at"`
+	// Contact holds the extended contact details, including the memberships
+	// and club assignments of the contact.
+	Contact ExportSection[*Contact] `json:"contact"`
+	// Notes holds the notes attached to the contact.
+	Notes ExportSection[[]Note] `json:"notes"`
+	// Invoices holds the invoices issued to the contact.
+	Invoices ExportSection[[]Invoice] `json:"invoices"`
+	// Relations holds the relations of the contact to other contacts. It is
+	// always [ExportStatusUnavailable].
+	Relations ExportSection[json.RawMessage] `json:"relations"`
+	// MembershipHistory holds the past memberships of the contact. It is
+	// always [ExportStatusUnavailable]; the current membership is part of
+	// Contact.
+	MembershipHistory ExportSection[json.RawMessage] `json:"membership_history"`
+}
+
+// exportUnavailable is the section of data the API has no endpoint for.
+var exportUnavailable = ExportSection[json.RawMessage]{
+	Status: ExportStatusUnavailable,
+	Error:  "not provided by the API",
+}
+
+// ContactFullExport exports everything Fairgate holds about a contact.
+// Sections the access key may not read, the API doesn't provide for the
+// contact or has no endpoint for are marked as such instead of failing the
+// export. Other errors,
+// including a missing contact, are returned.
+func (c *Client) ContactFullExport(ctx context.Context, contactID int) (ContactExport, error) {
+	export := ContactExport{
+		SchemaVersion: ContactExportSchemaVersion,
+		ContactID:     contactID,
+		ExportedAt:    c.clock.localNow(),
+	}
+
+	contact, err := c.Contact(ctx, contactID)
+	if err != nil {
+		return ContactExport{}, fmt.Errorf("contact export: %w", err)
+	}
+	export.Contact = ExportSection[*Contact]{Status: ExportStatusOK, Data: &contact.Data}
+
+	if export.Notes, err = exportSeq(c.ContactNotesIter(ctx, contactID)); err != nil {
+		return ContactExport{}, fmt.Errorf("contact export: notes: %w", err)
+	}
+
+	invoices, err := exportSeq(c.InvoicesByContactIter(ctx, InvoiceParams{ContactID: contactID}))
+	if err != nil {
+		return ContactExport{}, fmt.Errorf("contact export: invoices: %w", err)
+	}
+	export.Invoices = ExportSection[[]Invoice]{Status: invoices.Status, Error: invoices.Error}
+	for _, group := range invoices.Data {
+		export.Invoices.Data = append(export.Invoices.Data, group.Invoices...)
+	}
+
+	export.Relations = exportUnavailable
+	export.MembershipHistory = exportUnavailable
+
+	return export, nil
+}
+
+// exportSeq collects the items of seq into a section.
+func exportSeq[T any](seq iter.Seq2[T, error]) (ExportSection[[]T], error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return exportSectionError[[]T](err)
+		}
+		items = append(items, item)
+	}
+
+	return ExportSection[[]T]{Status: ExportStatusOK, Data: items}, nil
+}
+
+// exportSectionError returns the section for a sub-resource that failed with
+// err, or err if the failure isn't tolerated.
+func exportSectionError[T any](err error) (ExportSection[T], error) {
+	switch {
+	case errors.Is(err, ErrForbidden):
+		return ExportSection[T]{Status: ExportStatusForbidden, Error: err.Error()}, nil
+	case errors.Is(err, ErrNotFound):
+		return ExportSection[T]{Status: ExportStatusNotFound, Error: err.Error()}, nil
+	default:
+		return ExportSection[T]{}, err
+	}
+}
@@ -0,0 +1,184 @@
+package fairgate
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ContactFullExport(t *testing.T) {
+	tests := []struct {
+		name          string
+		contactStatus int
+		notesStatus   int
+		invoiceStatus int
+		wantErr       error
+		wantNotes     ExportStatus
+		wantNoteCount int
+		wantInvoices  ExportStatus
+	}{
+		{
+			name:          "complete",
+			contactStatus: http.StatusOK,
+			notesStatus:   http.StatusOK,
+			wantNotes:     ExportStatusOK,
+			wantNoteCount: 2,
+			wantInvoices:  ExportStatusOK,
+		},
+		{
+			name:          "invoices forbidden",
+			contactStatus: http.StatusOK,
+			notesStatus:   http.StatusOK,
+			invoiceStatus: http.StatusForbidden,
+			wantNotes:     ExportStatusOK,
+			wantNoteCount: 2,
+			wantInvoices:  ExportStatusForbidden,
+		},
+		{
+			name:          "notes forbidden",
+			contactStatus: http.StatusOK,
+			notesStatus:   http.StatusForbidden,
+			wantNotes:     ExportStatusForbidden,
+			wantInvoices:  ExportStatusOK,
+		},
+		{
+			name:          "notes not found",
+			contactStatus: http.StatusOK,
+			notesStatus:   http.StatusNotFound,
+			wantNotes:     ExportStatusNotFound,
+			wantInvoices:  ExportStatusOK,
+		},
+		{
+			name:          "notes failing",
+			contactStatus: http.StatusOK,
+			notesStatus:   http.StatusBadRequest,
+			wantErr:       ErrStatus,
+		},
+		{
+			name:          "contact not found",
+			contactStatus: http.StatusNotFound,
+			notesStatus:   http.StatusOK,
+			wantErr:       ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc(
+				"GET /fsa/v2.0/contact/test-org/contacts/7/extended",
+				func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, tt.contactStatus, Response[Contact]{
+						Success: tt.contactStatus == http.StatusOK,
+						Data:    Contact{Basefields: ContactBasefields{ContactID: 7}},
+					})
+				},
+			)
+			mux.HandleFunc(
+				"GET /fsa/v2.0/contact/test-org/contacts/7/notes",
+				func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, tt.notesStatus, Response[NotesList]{
+						Success: tt.notesStatus == http.StatusOK,
+						Data: NotesList{
+							Pagination: Pagination{TotalRecords: 2, TotalPages: 1},
+							Notes:      []Note{{NoteID: 1}, {NoteID: 2}},
+						},
+					})
+				},
+			)
+			mux.HandleFunc(
+				"GET /fsa/v2.0/contact/test-org/invoices",
+				func(w http.ResponseWriter, r *http.Request) {
+					status := cmp.Or(tt.invoiceStatus, http.StatusOK)
+					if r.URL.Query().Get("contactId") != "7" {
+						status = http.StatusBadRequest
+					}
+					writeJSON(w, status, Response[InvoicesList]{
+						Success: status == http.StatusOK,
+						Data: InvoicesList{
+							Pagination: Pagination{TotalRecords: 2, TotalPages: 1},
+							Invoices: []Invoice{
+								{InvoiceID: 1, ContactID: 7},
+								{InvoiceID: 2, ContactID: 7},
+							},
+						},
+					})
+				},
+			)
+			client, _ := newTestClient(t, mux)
+
+			export, err := client.ContactFullExport(context.Background(), 7)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ContactFullExport() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			if export.SchemaVersion != ContactExportSchemaVersion || export.ContactID != 7 {
+				t.Errorf("export = %+v, want schema version and contact ID set", export)
+			}
+			if export.Contact.Status != ExportStatusOK ||
+				export.Contact.Data.Basefields.ContactID != 7 {
+				t.Errorf("export.Contact = %+v, want contact 7", export.Contact)
+			}
+			if export.Notes.Status != tt.wantNotes || len(export.Notes.Data) != tt.wantNoteCount {
+				t.Errorf(
+					"export.Notes = %+v, want status %q with %d notes",
+					export.Notes,
+					tt.wantNotes,
+					tt.wantNoteCount,
+				)
+			}
+			if (export.Notes.Error != "") == (tt.wantNotes == ExportStatusOK) {
+				t.Errorf("export.Notes.Error = %q", export.Notes.Error)
+			}
+			wantInvoiceCount := 0
+			if tt.wantInvoices == ExportStatusOK {
+				wantInvoiceCount = 2
+			}
+			if export.Invoices.Status != tt.wantInvoices ||
+				len(export.Invoices.Data) != wantInvoiceCount {
+				t.Errorf(
+					"export.Invoices = %+v, want status %q with %d invoices",
+					export.Invoices,
+					tt.wantInvoices,
+					wantInvoiceCount,
+				)
+			}
+			if export.Relations.Status != ExportStatusUnavailable ||
+				export.MembershipHistory.Status != ExportStatusUnavailable {
+				t.Errorf(
+					"export.Relations = %+v, export.MembershipHistory = %+v, want unavailable",
+					export.Relations,
+					export.MembershipHistory,
+				)
+			}
+
+			var doc struct {
+				SchemaVersion int `json:"schema_version"`
+				Notes         struct {
+					Status ExportStatus `json:"status"`
+				} `json:"notes"`
+				Relations struct {
+					Status ExportStatus `json:"status"`
+				} `json:"relations"`
+			}
+			b, err := json.Marshal(export)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if err := json.Unmarshal(b, &doc); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if doc.SchemaVersion != ContactExportSchemaVersion ||
+				doc.Notes.Status != tt.wantNotes ||
+				doc.Relations.Status != ExportStatusUnavailable {
+				t.Errorf("marshaled export = %s", b)
+			}
+		})
+	}
+}
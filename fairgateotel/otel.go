@@ -0,0 +1,137 @@
+// Package fairgateotel provides OpenTelemetry tracing for the Fairgate client.
+//
+//	client := fairgate.New(oid, key, fairgateotel.WithTracing(otel.GetTracerProvider()))
+//
+// Spans are created for each request attempt, token creation and refresh, and
+// rate limit waits. Rate limited attempts carry a "rate_limited" event.
+package fairgateotel
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"thde.io/fairgate"
+)
+
+// instrumentationName is the name of the tracer.
+const instrumentationName = "thde.io/fairgate/fairgateotel"
+
+// Attribute keys set on spans.
+const (
+	attrMethod     = attribute.Key("http.request.method")
+	attrPath       = attribute.Key("url.path")
+	attrStatusCode = attribute.Key("http.response.status_code")
+	attrAttempt    = attribute.Key("fairgate.attempt")
+	attrRetryAfter = attribute.Key("fairgate.retry_after")
+)
+
+// config holds the configuration of the tracer.
+type config struct {
+	organisationID bool
+}
+
+// Option configures the tracer.
+type Option func(*config)
+
+// WithOrganisationID keeps the organisation ID in span paths. By default, it
+// is replaced by "{oid}".
+func WithOrganisationID() Option {
+	return func(c *config) {
+		c.organisationID = true
+	}
+}
+
+// WithTracing traces the operations of the client using tp.
+func WithTracing(tp trace.TracerProvider, opts ...Option) fairgate.ClientOption {
+	return fairgate.WithTracer(NewTracer(tp, opts...))
+}
+
+// NewTracer returns a [fairgate.Tracer] creating spans using tp.
+func NewTracer(tp trace.TracerProvider, opts ...Option) fairgate.Tracer {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return &tracer{tracer: tp.Tracer(instrumentationName), cfg: cfg}
+}
+
+// tracer implements [fairgate.Tracer].
+type tracer struct {
+	tracer trace.Tracer
+	cfg    config
+}
+
+// Start implements [fairgate.Tracer].
+func (t *tracer) Start(
+	ctx context.Context,
+	op fairgate.Operation,
+) (context.Context, fairgate.Span) {
+	kind := trace.SpanKindClient
+	if op.Name == fairgate.OperationRateLimitWait {
+		kind = trace.SpanKindInternal
+	}
+
+	var attrs []attribute.KeyValue
+	if op.Method != "" {
+		attrs = append(attrs, attrMethod.String(op.Method))
+	}
+	if op.Path != "" {
+		attrs = append(attrs, attrPath.String(t.path(op)))
+	}
+	if op.Attempt > 0 {
+		attrs = append(attrs, attrAttempt.Int(op.Attempt))
+	}
+
+	ctx, s := t.tracer.Start(ctx, "fairgate."+op.Name,
+		trace.WithSpanKind(kind),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, span{span: s}
+}
+
+// path returns the path of op, without organisation ID unless configured.
+func (t *tracer) path(op fairgate.Operation) string {
+	if t.cfg.organisationID || op.OrganisationID == "" {
+		return op.Path
+	}
+
+	segments := strings.Split(op.Path, "/")
+	for i, segment := range segments {
+		if segment == op.OrganisationID {
+			segments[i] = "{oid}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// span implements [fairgate.Span].
+type span struct {
+	span trace.Span
+}
+
+// RateLimited implements [fairgate.Span].
+func (s span) RateLimited(retryAfter time.Time) {
+	s.span.AddEvent("rate_limited", trace.WithAttributes(
+		attrRetryAfter.String(retryAfter.UTC().Format(time.RFC3339)),
+	))
+}
+
+// End implements [fairgate.Span].
+func (s span) End(statusCode int, err error) {
+	if statusCode != 0 {
+		s.span.SetAttributes(attrStatusCode.Int(statusCode))
+	}
+	if err != nil {
+		s.span.RecordError(err)
+		s.span.SetStatus(codes.Error, err.Error())
+	}
+
+	s.span.End()
+}
@@ -0,0 +1,179 @@
+package fairgateotel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"thde.io/fairgate"
+	"thde.io/fairgate/fairgatetest"
+)
+
+// newTracedClient returns a client with tracing talking to a fake API which
+// rate limits the first contact request.
+func newTracedClient(
+	t *testing.T,
+	opts ...Option,
+) (*fairgate.Client, *tracetest.InMemoryExporter) {
+	t.Helper()
+
+	privateKey, publicKey, err := fairgatetest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	var contactRequests atomic.Int64
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"POST /fsa/v1.1/auth/create/{oid}/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			token, err := fairgatetest.SignToken(
+				privateKey,
+				fairgatetest.Claims(),
+				time.Now().Add(time.Hour),
+			)
+			if err != nil {
+				t.Errorf("failed to sign token: %v", err)
+			}
+
+			_ = json.NewEncoder(w).Encode(fairgate.Response[fairgate.CreateTokenResponse]{
+				Success: true,
+				Data:    fairgate.CreateTokenResponse{Token: token, RefreshToken: "refresh"},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+		func(w http.ResponseWriter, r *http.Request) {
+			if contactRequests.Add(1) == 1 {
+				retryAfter := time.Now().Add(time.Second).Unix()
+				w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(retryAfter, 10))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(fairgate.Response[fairgate.Contact]{Success: true})
+		},
+	)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	t.Cleanup(func() { _ = tp.Shutdown(context.Background()) })
+
+	baseURL, _ := url.Parse(server.URL)
+	client := fairgate.New("secret-org", publicKey,
+		fairgate.WithHTTPClient(server.Client()),
+		fairgate.WithBaseURL(baseURL),
+		fairgate.WithAccessKey("access-key"),
+		WithTracing(tp, opts...),
+	)
+
+	return client, exporter
+}
+
+// attrs returns the attributes of a span by key.
+func attrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	m := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestWithTracing(t *testing.T) {
+	client, exporter := newTracedClient(t)
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+
+	// Spans are exported when they end, so nested spans come first.
+	want := []string{
+		"fairgate.token.create",
+		"fairgate.request",
+		"fairgate.rate_limit.wait",
+		"fairgate.request",
+	}
+	if len(names) != len(want) {
+		t.Fatalf("got spans %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got spans %v, want %v", names, want)
+		}
+	}
+
+	token, first, wait, second := spans[0], spans[1], spans[2], spans[3]
+
+	if token.Parent.SpanID() != first.SpanContext.SpanID() {
+		t.Error("token creation is not a child of the first request attempt")
+	}
+	if got := attrs(token)[attrPath].AsString(); got != "/fsa/v1.1/auth/create/{oid}/token" {
+		t.Errorf("token path = %q, want organisation ID replaced", got)
+	}
+
+	firstAttrs := attrs(first)
+	if got := firstAttrs[attrMethod].AsString(); got != http.MethodGet {
+		t.Errorf("method = %q, want GET", got)
+	}
+	if got := firstAttrs[attrPath].AsString(); got != "/fsa/v2.0/contact/{oid}/contacts/1/extended" {
+		t.Errorf("path = %q, want organisation ID replaced", got)
+	}
+	if got := firstAttrs[attrAttempt].AsInt64(); got != 1 {
+		t.Errorf("attempt = %d, want 1", got)
+	}
+	if got := firstAttrs[attrStatusCode].AsInt64(); got != http.StatusTooManyRequests {
+		t.Errorf("status code = %d, want 429", got)
+	}
+	if len(first.Events) != 1 || first.Events[0].Name != "rate_limited" {
+		t.Fatalf("first attempt events = %v, want rate_limited", first.Events)
+	}
+	if len(first.Events[0].Attributes) != 1 || first.Events[0].Attributes[0].Key != attrRetryAfter {
+		t.Errorf("rate_limited event attributes = %v, want retry after", first.Events[0].Attributes)
+	}
+
+	if wait.Parent.SpanID() != second.SpanContext.SpanID() {
+		t.Error("rate limit wait is not a child of the second request attempt")
+	}
+
+	secondAttrs := attrs(second)
+	if got := secondAttrs[attrAttempt].AsInt64(); got != 2 {
+		t.Errorf("attempt = %d, want 2", got)
+	}
+	if got := secondAttrs[attrStatusCode].AsInt64(); got != http.StatusOK {
+		t.Errorf("status code = %d, want 200", got)
+	}
+}
+
+func TestWithTracing_WithOrganisationID(t *testing.T) {
+	client, exporter := newTracedClient(t, WithOrganisationID())
+
+	if err := client.TokenCreate(context.Background(), "access-key"); err != nil {
+		t.Fatalf("TokenCreate() error = %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("got %d spans, want 1", len(spans))
+	}
+	if got := attrs(spans[0])[attrPath].AsString(); got != "/fsa/v1.1/auth/create/secret-org/token" {
+		t.Errorf("path = %q, want organisation ID", got)
+	}
+}
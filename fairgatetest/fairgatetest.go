@@ -0,0 +1,106 @@
+// Package fairgatetest provides utilities to test code using the Fairgate client
+// against a fake API, such as a [net/http/httptest.Server].
+//
+//	priv, pub, err := fairgatetest.GenerateKeyPair()
+//	token, err := fairgatetest.SignToken(priv, fairgatetest.Claims(), time.Now().Add(time.Hour))
+//	client := fairgate.New(oid, pub, fairgate.WithBaseURL(serverURL))
+//
+// The fake API returns token from its token creation endpoint. [Server] is a
+// ready-made fake API issuing tokens and serving contacts, and
+// [ContactGenerator] generates realistic contact fixtures.
+package fairgatetest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// signingMethod is the method used by FSA to sign tokens.
+var signingMethod = jwt.SigningMethodES512
+
+// TokenClaims represents the claims of a Fairgate JWT token.
+type TokenClaims struct {
+	FsaID  string   `json:"fsa_id"`
+	UniqID string   `json:"uniq_id"`
+	Scopes []string `json:"scopes,omitempty"`
+	OID    string   `json:"oid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Claims returns claims with placeholder IDs, issued now.
+func Claims() TokenClaims {
+	return TokenClaims{
+		FsaID:  "test-fsa-id",
+		UniqID: "test-uniq-id",
+		RegisteredClaims: jwt.RegisteredClaims{
+			IssuedAt: jwt.NewNumericDate(time.Now()),
+		},
+	}
+}
+
+// WithFsaID returns a copy of the claims with the FSA ID set to id.
+func (c TokenClaims) WithFsaID(id string) TokenClaims {
+	c.FsaID = id
+	return c
+}
+
+// WithUniqID returns a copy of the claims with the unique ID set to id.
+func (c TokenClaims) WithUniqID(id string) TokenClaims {
+	c.UniqID = id
+	return c
+}
+
+// WithOID returns a copy of the claims issued for the organisation oid. Claims
+// without organisation carry no oid claim.
+func (c TokenClaims) WithOID(oid string) TokenClaims {
+	c.OID = oid
+	return c
+}
+
+// WithScopes returns a copy of the claims granting scopes, e.g.
+// "read_contacts". Claims without scopes carry no scopes claim.
+func (c TokenClaims) WithScopes(scopes ...string) TokenClaims {
+	c.Scopes = scopes
+	return c
+}
+
+// WithIssuer returns a copy of the claims issued by issuer.
+func (c TokenClaims) WithIssuer(issuer string) TokenClaims {
+	c.Issuer = issuer
+	return c
+}
+
+// WithAudience returns a copy of the claims for audience.
+func (c TokenClaims) WithAudience(audience ...string) TokenClaims {
+	c.Audience = audience
+	return c
+}
+
+// WithIssuedAt returns a copy of the claims issued at t.
+func (c TokenClaims) WithIssuedAt(t time.Time) TokenClaims {
+	c.IssuedAt = jwt.NewNumericDate(t)
+	return c
+}
+
+// GenerateKeyPair generates a key pair to sign tokens. Pass the public key to
+// [thde.io/fairgate.New] and the private key to [SignToken].
+func GenerateKeyPair() (*ecdsa.PrivateKey, *ecdsa.PublicKey, error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return privateKey, &privateKey.PublicKey, nil
+}
+
+// SignToken returns a token with claims expiring at expiresAt, signed the same
+// way as tokens issued by FSA.
+func SignToken(priv *ecdsa.PrivateKey, claims TokenClaims, expiresAt time.Time) (string, error) {
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+
+	return jwt.NewWithClaims(signingMethod, claims).SignedString(priv)
+}
@@ -0,0 +1,114 @@
+package fairgatetest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"thde.io/fairgate/fairgatetest"
+)
+
+func TestSignToken(t *testing.T) {
+	priv, pub, err := fairgatetest.GenerateKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateKeyPair() error = %v", err)
+	}
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	claims := fairgatetest.Claims().WithFsaID("fsa-1").WithUniqID("uniq-1")
+	token, err := fairgatetest.SignToken(priv, claims, expiresAt)
+	if err != nil {
+		t.Fatalf("SignToken() error = %v", err)
+	}
+
+	var got fairgatetest.TokenClaims
+	_, err = jwt.ParseWithClaims(token, &got, func(*jwt.Token) (any, error) {
+		return pub, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodES512.Alg()}))
+	if err != nil {
+		t.Fatalf("failed to parse token: %v", err)
+	}
+
+	if got.FsaID != "fsa-1" || got.UniqID != "uniq-1" {
+		t.Errorf("claims = %+v, want fsa-1 and uniq-1", got)
+	}
+	if !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("ExpiresAt = %v, want %v", got.ExpiresAt, expiresAt)
+	}
+}
+
+func TestServer(t *testing.T) {
+	server, err := fairgatetest.NewServer("access-key", fairgatetest.Contact{ID: 1})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		auth   bool
+		want   int
+	}{
+		{
+			name:   "token",
+			method: http.MethodPost,
+			path:   "/fsa/v1.1/auth/create/org/token",
+			body:   `{"access_key":"access-key"}`,
+			want:   http.StatusOK,
+		},
+		{
+			name:   "invalid access key",
+			method: http.MethodPost,
+			path:   "/fsa/v1.1/auth/create/org/token",
+			body:   `{"access_key":"wrong"}`,
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "contact without token",
+			method: http.MethodGet,
+			path:   "/fsa/v2.0/contact/org/contacts/1/extended",
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "contact",
+			method: http.MethodGet,
+			path:   "/fsa/v2.0/contact/org/contacts/1/extended",
+			auth:   true,
+			want:   http.StatusOK,
+		},
+		{
+			name:   "missing contact",
+			method: http.MethodGet,
+			path:   "/fsa/v2.0/contact/org/contacts/2/extended",
+			auth:   true,
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, server.URL+tt.path, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			if tt.auth {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+
+			resp, err := server.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
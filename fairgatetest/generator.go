@@ -0,0 +1,327 @@
+package fairgatetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Anomaly is a deviation from the regular API representation injected by a
+// [ContactGenerator] for negative tests.
+type Anomaly int
+
+const (
+	// AnomalyMissingDates returns dates as empty strings or null.
+	AnomalyMissingDates Anomaly = iota + 1
+	// AnomalyStringIDs returns contact IDs as strings, e.g. "1001".
+	AnomalyStringIDs
+)
+
+// defaultReferenceDate is the date ages and dates of generated contacts are
+// relative to, unless set using [WithReferenceDate].
+var defaultReferenceDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// GeneratorOption configures a [ContactGenerator].
+type GeneratorOption func(*ContactGenerator)
+
+// FederationMode generates contacts of a federation, with federation data and
+// club assignments instead of a club membership.
+func FederationMode() GeneratorOption {
+	return func(g *ContactGenerator) {
+		g.federation = true
+	}
+}
+
+// WithAnomalies injects anomalies into all generated contacts.
+func WithAnomalies(anomalies ...Anomaly) GeneratorOption {
+	return func(g *ContactGenerator) {
+		for _, anomaly := range anomalies {
+			g.anomalies[anomaly] = true
+		}
+	}
+}
+
+// WithReferenceDate sets the date ages and dates of the generated contacts are
+// relative to. Defaults to 1 January 2025.
+func WithReferenceDate(t time.Time) GeneratorOption {
+	return func(g *ContactGenerator) {
+		g.reference = t
+	}
+}
+
+// ContactGenerator generates realistic contacts in the API representation,
+// e.g. to serve from a fake API or to decode into a fairgate.Contact. It is
+// deterministic: generators with the same seed and options generate the same
+// contacts.
+//
+// Generated contacts are internally consistent:
+//   - Companies have a company name, but no personal names, gender or
+//     birthdate.
+//   - Only minors have parent email addresses.
+//   - Addresses are Swiss, with matching postal codes and correspondence
+//     languages.
+//   - Only contacts generated in [FederationMode] have federation data and
+//     club assignments, and only the others have a club membership.
+//
+// A ContactGenerator is not safe for concurrent use.
+type ContactGenerator struct {
+	rng        *rand.Rand
+	federation bool
+	anomalies  map[Anomaly]bool
+	reference  time.Time
+	nextID     int
+}
+
+// NewContactGenerator returns a generator seeded with seed.
+func NewContactGenerator(seed uint64, opts ...GeneratorOption) *ContactGenerator {
+	g := &ContactGenerator{
+		rng:       rand.New(rand.NewPCG(seed, seed)),
+		anomalies: map[Anomaly]bool{},
+		reference: defaultReferenceDate,
+		nextID:    1001,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g
+}
+
+// GenerateContacts returns the next n contacts, encoded as JSON objects.
+func (g *ContactGenerator) GenerateContacts(n int) []json.RawMessage {
+	contacts := make([]json.RawMessage, 0, n)
+	for range n {
+		contacts = append(contacts, g.Contact())
+	}
+
+	return contacts
+}
+
+// Contact returns the next contact, encoded as JSON object.
+func (g *ContactGenerator) Contact() json.RawMessage {
+	data, err := json.Marshal(g.contact())
+	if err != nil {
+		panic(err)
+	}
+
+	return data
+}
+
+// swissCity is a Swiss city with its postal code and language.
+type swissCity struct {
+	name     string
+	code     string
+	language string
+}
+
+var (
+	swissCities = []swissCity{
+		{"Zürich", "8001", "de"},
+		{"Bern", "3011", "de"},
+		{"Basel", "4051", "de"},
+		{"Luzern", "6003", "de"},
+		{"St. Gallen", "9000", "de"},
+		{"Genève", "1204", "fr"},
+		{"Lausanne", "1003", "fr"},
+		{"Neuchâtel", "2000", "fr"},
+		{"Lugano", "6900", "it"},
+		{"Bellinzona", "6500", "it"},
+	}
+	streets = []string{
+		"Bahnhofstrasse", "Hauptstrasse", "Dorfstrasse", "Seestrasse",
+		"Rue du Lac", "Chemin des Vignes", "Via Cantonale", "Kirchweg",
+	}
+	femaleNames = []string{"Anna", "Laura", "Sara", "Lea", "Chiara", "Elodie", "Nina", "Mia"}
+	maleNames   = []string{"Luca", "Noah", "David", "Marco", "Julien", "Simon", "Elias", "Jonas"}
+	lastNames   = []string{
+		"Muster", "Meier", "Keller", "Weber", "Schmid", "Rochat", "Bernasconi", "Favre",
+	}
+	companySuffixes = []string{"AG", "GmbH", "SA", "Sagl"}
+	clubs           = []string{"FC Seeland", "TV Oberland", "HC Lémanique", "SC Ticino"}
+)
+
+// contact generates the API representation of the next contact.
+func (g *ContactGenerator) contact() map[string]any {
+	id := g.nextID
+	g.nextID++
+
+	city := pick(g.rng, swissCities)
+	basefields := map[string]any{
+		"contact_id":              g.id(id),
+		"correspondence_language": city.language,
+		"last_update":             g.date(g.daysBefore(g.reference, 365), time.RFC3339),
+	}
+	communication := map[string]any{
+		"mobile": fmt.Sprintf("07%d %03d %02d %02d",
+			5+g.rng.IntN(5), g.rng.IntN(1000), g.rng.IntN(100), g.rng.IntN(100)),
+	}
+	contact := map[string]any{
+		"basefields": basefields,
+		"status":     pick(g.rng, []string{"active", "active", "active", "archived"}),
+		"corr_address": map[string]any{
+			"street":       fmt.Sprintf("%s %d", pick(g.rng, streets), 1+g.rng.IntN(120)),
+			"postale_code": city.code,
+			"city":         city.name,
+			"country":      "CH",
+		},
+		"communication": communication,
+	}
+
+	company := g.rng.IntN(8) == 0
+	birthdate := time.Time{}
+	if company {
+		name := pick(g.rng, lastNames) + " " + pick(g.rng, companySuffixes)
+		basefields["contact_type"] = "company"
+		basefields["company_name"] = name
+		basefields["salutation"] = "formal"
+		communication["primary_email"] = "info@" + emailPart(name) + ".ch"
+		contact["invoice_address"] = map[string]any{
+			"alias_name":      "Buchhaltung",
+			"post_office_box": "Postfach " + strconv.Itoa(100+g.rng.IntN(900)),
+			"postale_code":    city.code,
+			"city":            city.name,
+			"country":         "CH",
+		}
+	} else {
+		gender, firstName := "female", pick(g.rng, femaleNames)
+		if g.rng.IntN(2) == 0 {
+			gender, firstName = "male", pick(g.rng, maleNames)
+		}
+		lastName := pick(g.rng, lastNames)
+		age := 6 + g.rng.IntN(75)
+		birthdate = g.daysBefore(g.reference.AddDate(-age, 0, 0), 365)
+		minor := birthdate.After(g.reference.AddDate(-18, 0, 0))
+
+		basefields["contact_type"] = "singleperson"
+		basefields["first_name"] = firstName
+		basefields["last_name"] = lastName
+		basefields["gender"] = gender
+		basefields["birthdate"] = g.date(birthdate, time.DateOnly)
+		basefields["salutation"] = pick(g.rng, []string{"formal", "informal"})
+		email := emailPart(firstName) + "." + emailPart(lastName) + "@example.com"
+		if minor {
+			basefields["salutation"] = "informal"
+			communication["email_parent_1"] = "parent." + email
+			if g.rng.IntN(2) == 0 {
+				communication["email_parent_2"] = "parent2." + email
+			}
+		} else {
+			communication["primary_email"] = email
+		}
+	}
+
+	// Members joined at the earliest at the age of 5.
+	joinedAfter := g.reference.AddDate(-30, 0, 0)
+	if earliest := birthdate.AddDate(5, 0, 0); earliest.After(joinedAfter) {
+		joinedAfter = earliest
+	}
+	joined := g.between(joinedAfter, g.reference)
+	membership := pick(g.rng, []string{"Active", "Passive", "Honorary"})
+	switch {
+	case company:
+		membership = "Sponsor"
+	case birthdate.After(g.reference.AddDate(-18, 0, 0)):
+		membership = "Junior"
+	}
+
+	if !g.federation {
+		contact["membership"] = map[string]any{
+			"membership":         membership,
+			"first_joining_date": g.date(joined, time.DateOnly),
+		}
+		return contact
+	}
+
+	contact["federation_data"] = map[string]any{
+		"federation_contact_id":         g.id(id + 500000),
+		"federation_membership":         membership,
+		"federation_first_joining_date": g.date(joined, time.DateOnly),
+	}
+	assignments := map[string]any{
+		"primary": g.clubAssignment(membership, joined),
+	}
+	if n := g.rng.IntN(3); n > 0 {
+		secondary := make([]any, 0, n)
+		for range n {
+			secondary = append(
+				secondary,
+				g.clubAssignment("Passive", g.between(joined, g.reference)),
+			)
+		}
+		assignments["secondary"] = secondary
+	}
+	contact["club_assignments"] = assignments
+
+	return contact
+}
+
+// clubAssignment generates the assignment to a random club.
+func (g *ContactGenerator) clubAssignment(membership string, joined time.Time) map[string]any {
+	club := g.rng.IntN(len(clubs))
+	assignment := map[string]any{
+		"organization_id": fmt.Sprintf("club-%d", club+1),
+		"organization":    clubs[club],
+		"membership": map[string]any{
+			"membership":         membership,
+			"first_joining_date": g.date(joined, time.DateOnly),
+		},
+	}
+	if g.rng.IntN(10) == 0 {
+		assignment["executive_board"] = []any{
+			map[string]any{"role_id": 1, "role_name": "President"},
+		}
+	}
+
+	return assignment
+}
+
+// id returns the representation of the ID id.
+func (g *ContactGenerator) id(id int) any {
+	if g.anomalies[AnomalyStringIDs] {
+		return strconv.Itoa(id)
+	}
+
+	return id
+}
+
+// date returns the representation of t formatted with layout, alternating
+// between empty strings and null with [AnomalyMissingDates].
+func (g *ContactGenerator) date(t time.Time, layout string) any {
+	if !g.anomalies[AnomalyMissingDates] {
+		return t.Format(layout)
+	}
+	if g.rng.IntN(2) == 0 {
+		return ""
+	}
+
+	return nil
+}
+
+// daysBefore returns a random day up to days before t.
+func (g *ContactGenerator) daysBefore(t time.Time, days int) time.Time {
+	return t.AddDate(0, 0, -g.rng.IntN(days+1))
+}
+
+// between returns a random day between from and to, or to if from is later.
+func (g *ContactGenerator) between(from, to time.Time) time.Time {
+	days := int(to.Sub(from).Hours() / 24)
+	if days <= 0 {
+		return to
+	}
+
+	return g.daysBefore(to, days)
+}
+
+// pick returns a random element of values.
+func pick[T any](rng *rand.Rand, values []T) T {
+	return values[rng.IntN(len(values))]
+}
+
+// emailPart returns s in lower case without spaces, for use in an email
+// address.
+func emailPart(s string) string {
+	return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+}
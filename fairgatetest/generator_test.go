@@ -0,0 +1,136 @@
+package fairgatetest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"thde.io/fairgate/fairgatetest"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestContactGenerator_Golden(t *testing.T) {
+	tests := []struct {
+		golden string
+		opts   []fairgatetest.GeneratorOption
+	}{
+		{"contacts_club.golden", nil},
+		{
+			"contacts_federation.golden",
+			[]fairgatetest.GeneratorOption{fairgatetest.FederationMode()},
+		},
+		{
+			"contacts_anomalies.golden",
+			[]fairgatetest.GeneratorOption{fairgatetest.WithAnomalies(
+				fairgatetest.AnomalyMissingDates,
+				fairgatetest.AnomalyStringIDs,
+			)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			var got bytes.Buffer
+			for _, contact := range fairgatetest.NewContactGenerator(1, tt.opts...).GenerateContacts(8) {
+				got.Write(contact)
+				got.WriteByte('\n')
+			}
+
+			path := filepath.Join("testdata", tt.golden)
+			if *update {
+				if err := os.WriteFile(path, got.Bytes(), 0o600); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+			if !bytes.Equal(got.Bytes(), want) {
+				t.Errorf("output differs from %s:\ngot:\n%s\nwant:\n%s", path, got.Bytes(), want)
+			}
+		})
+	}
+}
+
+// generatedContact holds the fields of a generated contact checked for
+// consistency.
+type generatedContact struct {
+	Basefields struct {
+		ContactID   int    `json:"contact_id"`
+		ContactType string `json:"contact_type"`
+		CompanyName string `json:"company_name"`
+		FirstName   string `json:"first_name"`
+		Gender      string `json:"gender"`
+		Birthdate   string `json:"birthdate"`
+	} `json:"basefields"`
+	CorrAddress struct {
+		PostaleCode string `json:"postale_code"`
+		Country     string `json:"country"`
+	} `json:"corr_address"`
+	Communication struct {
+		EmailParent1 string `json:"email_parent_1"`
+	} `json:"communication"`
+	Membership      *json.RawMessage `json:"membership"`
+	FederationData  *json.RawMessage `json:"federation_data"`
+	ClubAssignments *json.RawMessage `json:"club_assignments"`
+}
+
+func TestContactGenerator_Consistency(t *testing.T) {
+	reference := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, federation := range []bool{false, true} {
+		var opts []fairgatetest.GeneratorOption
+		if federation {
+			opts = append(opts, fairgatetest.FederationMode())
+		}
+
+		ids := map[int]bool{}
+		for _, data := range fairgatetest.NewContactGenerator(42, opts...).GenerateContacts(500) {
+			var c generatedContact
+			if err := json.Unmarshal(data, &c); err != nil {
+				t.Fatalf("failed to decode %s: %v", data, err)
+			}
+
+			if ids[c.Basefields.ContactID] {
+				t.Errorf("duplicate contact ID %d", c.Basefields.ContactID)
+			}
+			ids[c.Basefields.ContactID] = true
+
+			switch c.Basefields.ContactType {
+			case "company":
+				if c.Basefields.CompanyName == "" || c.Basefields.FirstName != "" ||
+					c.Basefields.Gender != "" || c.Basefields.Birthdate != "" {
+					t.Errorf("inconsistent company %s", data)
+				}
+			case "singleperson":
+				if c.Basefields.CompanyName != "" || c.Basefields.FirstName == "" ||
+					c.Basefields.Gender == "" {
+					t.Errorf("inconsistent person %s", data)
+				}
+			default:
+				t.Errorf("unexpected contact type in %s", data)
+			}
+
+			birthdate, _ := time.Parse(time.DateOnly, c.Basefields.Birthdate)
+			minor := birthdate.After(reference.AddDate(-18, 0, 0))
+			if (c.Communication.EmailParent1 != "") != minor {
+				t.Errorf("parent email of minor %v in %s", minor, data)
+			}
+			if len(c.CorrAddress.PostaleCode) != 4 || c.CorrAddress.Country != "CH" {
+				t.Errorf("non-Swiss address in %s", data)
+			}
+
+			if federation != (c.FederationData != nil) ||
+				federation != (c.ClubAssignments != nil) ||
+				federation == (c.Membership != nil) {
+				t.Errorf("federation mode %v inconsistent with %s", federation, data)
+			}
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package fairgatetest
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Contact is a contact served by [Server].
+type Contact struct {
+	ID        int
+	FirstName string
+	LastName  string
+}
+
+// Server is a fake Fairgate API for tests and examples. It issues tokens for
+// its access key and serves its contacts:
+//
+//	server, err := fairgatetest.NewServer("access-key", contacts...)
+//	defer server.Close()
+//	client := fairgate.New("org", server.PublicKey,
+//		fairgate.WithBaseURLString(server.URL), fairgate.WithAccessKey("access-key"))
+type Server struct {
+	*httptest.Server
+
+	// PublicKey verifies the tokens issued by the server.
+	PublicKey *ecdsa.PublicKey
+
+	privateKey *ecdsa.PrivateKey
+	accessKey  string
+
+	mu         sync.Mutex
+	contacts   []Contact
+	rateLimits int
+}
+
+// NewServer starts a fake API issuing tokens for accessKey and serving
+// contacts. The caller should call Close when finished.
+func NewServer(accessKey string, contacts ...Contact) (*Server, error) {
+	privateKey, publicKey, err := GenerateKeyPair()
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		PublicKey:  publicKey,
+		privateKey: privateKey,
+		accessKey:  accessKey,
+		contacts:   contacts,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /fsa/v1.1/auth/create/{oid}/token", s.createToken)
+	mux.HandleFunc("POST /fsa/v1.1/auth/refresh/{oid}/token", s.refreshToken)
+	mux.Handle("GET /fsa/v2.0/contact/{oid}/contacts/extended", s.authorized(s.listContacts))
+	mux.Handle("GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended", s.authorized(s.getContact))
+	s.Server = httptest.NewServer(s.rateLimited(mux))
+
+	return s, nil
+}
+
+// RateLimit answers the next n requests with status 429 Too Many Requests,
+// allowing requests again right away.
+func (s *Server) RateLimit(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimits = n
+}
+
+// rateLimited answers requests with 429 Too Many Requests while rate limited.
+func (s *Server) rateLimited(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		limited := s.rateLimits > 0
+		if limited {
+			s.rateLimits--
+		}
+		s.mu.Unlock()
+
+		if limited {
+			w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(time.Now().Unix(), 10))
+			writeEnvelope(w, http.StatusTooManyRequests, "Too many requests", nil)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorized rejects requests without bearer token.
+func (s *Server) authorized(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeEnvelope(w, http.StatusUnauthorized, "Missing token", nil)
+			return
+		}
+		next(w, r)
+	})
+}
+
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		AccessKey string `json:"access_key"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.AccessKey != s.accessKey {
+		writeEnvelope(w, http.StatusUnauthorized, "Invalid access key", nil)
+		return
+	}
+
+	s.writeToken(w)
+}
+
+func (s *Server) refreshToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		writeEnvelope(w, http.StatusUnauthorized, "Invalid refresh token", nil)
+		return
+	}
+
+	s.writeToken(w)
+}
+
+// writeToken issues a token valid for an hour.
+func (s *Server) writeToken(w http.ResponseWriter) {
+	token, err := SignToken(s.privateKey, Claims(), time.Now().Add(time.Hour))
+	if err != nil {
+		writeEnvelope(w, http.StatusInternalServerError, err.Error(), nil)
+		return
+	}
+
+	writeEnvelope(w, http.StatusOK, "", map[string]string{
+		"token":         token,
+		"refresh_token": "refresh-token",
+	})
+}
+
+func (s *Server) listContacts(w http.ResponseWriter, r *http.Request) {
+	pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+	pageLimit, _ := strconv.Atoi(r.URL.Query().Get("pageLimit"))
+	pageNo = max(pageNo, 1)
+	if pageLimit <= 0 {
+		pageLimit = 100
+	}
+
+	s.mu.Lock()
+	total := len(s.contacts)
+	start := min((pageNo-1)*pageLimit, total)
+	page := make([]any, 0, pageLimit)
+	for _, contact := range s.contacts[start:min(start+pageLimit, total)] {
+		page = append(page, contactJSON(contact))
+	}
+	s.mu.Unlock()
+
+	writeEnvelope(w, http.StatusOK, "", map[string]any{
+		"totalRecords": total,
+		"totalPages":   (total + pageLimit - 1) / pageLimit,
+		"pageNo":       pageNo,
+		"pageLimit":    pageLimit,
+		"contacts":     page,
+	})
+}
+
+func (s *Server) getContact(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.Atoi(r.PathValue("id"))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, contact := range s.contacts {
+		if contact.ID == id {
+			writeEnvelope(w, http.StatusOK, "", contactJSON(contact))
+			return
+		}
+	}
+	writeEnvelope(w, http.StatusNotFound, "Contact not found", nil)
+}
+
+// contactJSON returns the API representation of contact.
+func contactJSON(contact Contact) map[string]any {
+	return map[string]any{
+		"basefields": map[string]any{
+			"contact_id": contact.ID,
+			"first_name": contact.FirstName,
+			"last_name":  contact.LastName,
+		},
+	}
+}
+
+// writeEnvelope writes a response envelope with status, reporting message if
+// the request failed.
+func writeEnvelope(w http.ResponseWriter, status int, message string, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"code":    status,
+		"success": status < http.StatusMultipleChoices,
+		"message": message,
+		"data":    data,
+	})
+}
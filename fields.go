@@ -0,0 +1,135 @@
+package fairgate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+)
+
+// standardField is a standard field documented by Fairgate, delivered in the
+// "standard_fields" object of a contact.
+type standardField struct {
+	// key is the documented key of the field.
+	key string
+	// sensitive fields are only available using [WithSensitiveFields].
+	sensitive bool
+}
+
+// Standard fields with typed accessors on [Contact].
+var (
+	fieldLicenseNumber        = standardField{key: "license_number"}
+	fieldSocialSecurityNumber = standardField{key: "ahv_number", sensitive: true}
+)
+
+// standardFields lists all standard fields by key.
+var standardFields = map[string]standardField{
+	fieldLicenseNumber.key:        fieldLicenseNumber,
+	fieldSocialSecurityNumber.key: fieldSocialSecurityNumber,
+}
+
+// standardFieldValues holds the standard field values of a contact.
+// It is referenced by pointer, so fmt verbs don't print the values.
+type standardFieldValues struct {
+	values map[string]string
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler] interface.
+// It retains the values of the documented standard fields.
+func (c *Contact) UnmarshalJSON(data []byte) error {
+	type contact Contact
+	aux := struct {
+		*contact
+		StandardFields map[string]json.RawMessage `json:"standard_fields"`
+	}{contact: (*contact)(c)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.fields = nil
+	for key, raw := range aux.StandardFields {
+		if _, ok := standardFields[key]; !ok {
+			continue
+		}
+
+		value, ok := standardFieldValue(raw)
+		if !ok {
+			continue
+		}
+		if c.fields == nil {
+			c.fields = &standardFieldValues{values: map[string]string{}}
+		}
+		c.fields.values[key] = value
+	}
+
+	return nil
+}
+
+// standardFieldValue returns the string representation of a raw value.
+// Empty and null values are reported as missing.
+func standardFieldValue(raw json.RawMessage) (string, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, s != ""
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String(), true
+	}
+
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return strconv.FormatBool(b), true
+	}
+
+	return "", false
+}
+
+// standardField returns the value of a standard field.
+func (c Contact) standardField(field standardField) (string, bool) {
+	if c.fields == nil {
+		return "", false
+	}
+
+	value, ok := c.fields.values[field.key]
+	return value, ok
+}
+
+// LicenseNumber returns the license number used by sport federations.
+// It reports false if the organisation doesn't populate the field.
+func (c Contact) LicenseNumber() (string, bool) {
+	return c.standardField(fieldLicenseNumber)
+}
+
+// SocialSecurityNumber returns the Swiss social security (AHV) number.
+// It reports false if the organisation doesn't populate the field or the
+// client was created without [WithSensitiveFields].
+func (c Contact) SocialSecurityNumber() (string, bool) {
+	return c.standardField(fieldSocialSecurityNumber)
+}
+
+// WithSensitiveFields retains sensitive standard fields such as
+// [Contact.SocialSecurityNumber] in decoded responses. Without this option,
+// they are dropped right after decoding.
+func WithSensitiveFields() ClientOption {
+	return func(c *Client) {
+		c.sensitiveFields = true
+	}
+}
+
+// dropSensitiveFields removes the sensitive standard field values of all
+// contacts reachable from v.
+func dropSensitiveFields(v any) {
+	walk(reflect.ValueOf(v), func(c *Contact) {
+		if c.fields == nil {
+			return
+		}
+
+		for key := range c.fields.values {
+			if standardFields[key].sensitive {
+				delete(c.fields.values, key)
+			}
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package fairgate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixtureHandler serves the file testdata/name.
+func fixtureHandler(t *testing.T, name string) http.Handler {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	})
+}
+
+func TestContact_StandardFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		opts        []ClientOption
+		wantLicense string
+		wantSSN     string
+	}{
+		{
+			name:        "populated",
+			fixture:     "contact_standard_fields.json",
+			wantLicense: "123456",
+		},
+		{
+			name:        "populated with sensitive fields",
+			fixture:     "contact_standard_fields.json",
+			opts:        []ClientOption{WithSensitiveFields()},
+			wantLicense: "123456",
+			wantSSN:     "756.1234.5678.97",
+		},
+		{
+			name:    "not populated",
+			fixture: "contact_without_standard_fields.json",
+			opts:    []ClientOption{WithSensitiveFields()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, fixtureHandler(t, tt.fixture), tt.opts...)
+
+			resp, err := client.Contact(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Contact() error = %v", err)
+			}
+			contact := resp.Data
+
+			license, ok := contact.LicenseNumber()
+			if license != tt.wantLicense || ok != (tt.wantLicense != "") {
+				t.Errorf("LicenseNumber() = %q, %v, want %q", license, ok, tt.wantLicense)
+			}
+
+			ssn, ok := contact.SocialSecurityNumber()
+			if ssn != tt.wantSSN || ok != (tt.wantSSN != "") {
+				t.Errorf("SocialSecurityNumber() = %q, %v, want %q", ssn, ok, tt.wantSSN)
+			}
+
+			for _, verb := range []string{"%v", "%+v", "%#v"} {
+				if out := fmt.Sprintf(verb, contact); strings.Contains(out, "756.1234") {
+					t.Errorf("%s of contact contains social security number: %s", verb, out)
+				}
+			}
+		})
+	}
+}
+
+func TestContact_StandardFields_Zero(t *testing.T) {
+	var contact Contact
+	if _, ok := contact.LicenseNumber(); ok {
+		t.Error("LicenseNumber() reported a value for a zero contact")
+	}
+	if _, ok := contact.SocialSecurityNumber(); ok {
+		t.Error("SocialSecurityNumber() reported a value for a zero contact")
+	}
+}
@@ -0,0 +1,56 @@
+package fairgate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrScopeForbidden is returned when the access key lacks a scope required
+	// by the request, e.g. the finance scope. It affects all records of the
+	// endpoint. Errors matching it also match [ErrForbidden].
+	ErrScopeForbidden = errors.New("access key lacks scope")
+	// ErrRecordForbidden is returned when a single record, e.g. a protected
+	// contact, can't be accessed. Errors matching it also match [ErrForbidden].
+	ErrRecordForbidden = errors.New("record protected")
+)
+
+// Envelope codes of 403 Forbidden responses distinguishing missing scopes from
+// protected records.
+const (
+	forbiddenScopeCode  = 4031
+	forbiddenRecordCode = 4032
+)
+
+// forbiddenDetails represents the data of a 403 Forbidden response.
+type forbiddenDetails struct {
+	Scope     string  `json:"scope"`
+	ContactID FlexInt `json:"contact_id"`
+}
+
+// forbiddenError wraps err, the error of a 403 Forbidden response, with
+// [ErrScopeForbidden] or [ErrRecordForbidden] based on the envelope code, or
+// the envelope data naming the missing scope or the protected contact. It
+// returns err if the envelope is ambiguous.
+func forbiddenError(err error, envelope Response[json.RawMessage]) error {
+	switch envelope.Code {
+	case forbiddenScopeCode:
+		return fmt.Errorf("%w: %w", ErrScopeForbidden, err)
+	case forbiddenRecordCode:
+		return fmt.Errorf("%w: %w", ErrRecordForbidden, err)
+	}
+
+	var details forbiddenDetails
+	if len(envelope.Data) == 0 || json.Unmarshal(envelope.Data, &details) != nil {
+		return err
+	}
+	switch {
+	case details.Scope != "" && details.ContactID == 0:
+		return fmt.Errorf("%w %q: %w", ErrScopeForbidden, details.Scope, err)
+	case details.ContactID != 0 && details.Scope == "":
+		return fmt.Errorf("%w: contact %d: %w", ErrRecordForbidden, details.ContactID, err)
+	default:
+		return err
+	}
+}
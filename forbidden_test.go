@@ -0,0 +1,172 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestClient_ForbiddenKinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler func(t *testing.T) http.Handler
+		wantErr error
+		notErr  error
+	}{
+		{
+			name: "scope code",
+			handler: func(t *testing.T) http.Handler {
+				return statusFixtureHandler(t, http.StatusForbidden, "error_forbidden_scope.json")
+			},
+			wantErr: ErrScopeForbidden,
+			notErr:  ErrRecordForbidden,
+		},
+		{
+			name: "record code",
+			handler: func(t *testing.T) http.Handler {
+				return statusFixtureHandler(t, http.StatusForbidden, "error_forbidden_record.json")
+			},
+			wantErr: ErrRecordForbidden,
+			notErr:  ErrScopeForbidden,
+		},
+		{
+			name:    "scope data",
+			handler: forbiddenHandler(map[string]any{"scope": "finance"}),
+			wantErr: ErrScopeForbidden,
+			notErr:  ErrRecordForbidden,
+		},
+		{
+			name:    "record data",
+			handler: forbiddenHandler(map[string]any{"contact_id": 2}),
+			wantErr: ErrRecordForbidden,
+			notErr:  ErrScopeForbidden,
+		},
+		{
+			name:    "ambiguous",
+			handler: forbiddenHandler(map[string]any{"scope": "finance", "contact_id": 2}),
+			wantErr: ErrForbidden,
+			notErr:  ErrScopeForbidden,
+		},
+		{
+			name:    "no details",
+			handler: forbiddenHandler(nil),
+			wantErr: ErrForbidden,
+			notErr:  ErrRecordForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, tt.handler(t))
+
+			_, err := client.Contact(context.Background(), 2)
+			if !errors.Is(err, tt.wantErr) || !errors.Is(err, ErrForbidden) {
+				t.Errorf("Contact() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(err, tt.notErr) {
+				t.Errorf("Contact() error = %v, must not match %v", err, tt.notErr)
+			}
+		})
+	}
+}
+
+// forbiddenHandler returns a handler answering with a 403 Forbidden envelope
+// with the generic code and data.
+func forbiddenHandler(data map[string]any) func(t *testing.T) http.Handler {
+	return func(*testing.T) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusForbidden, Response[map[string]any]{
+				Code:    http.StatusForbidden,
+				Message: "forbidden",
+				Data:    data,
+			})
+		})
+	}
+}
+
+func TestClient_ContactsByIDs_ForbiddenKinds(t *testing.T) {
+	tests := []struct {
+		name string
+		// fixtures maps the IDs of forbidden contacts to error fixtures.
+		fixtures    map[int]string
+		opts        []IterOption
+		wantIDs     []int
+		wantSkipped []int
+		wantErr     error
+	}{
+		{
+			name:        "skip protected records",
+			fixtures:    map[int]string{2: "error_forbidden_record.json"},
+			wantIDs:     []int{1, 3, 4},
+			wantSkipped: []int{2},
+		},
+		{
+			name: "abort on missing scope",
+			fixtures: map[int]string{
+				2: "error_forbidden_record.json",
+				3: "error_forbidden_scope.json",
+			},
+			opts:        []IterOption{SkipForbidden(true)},
+			wantIDs:     []int{1},
+			wantSkipped: []int{2},
+			wantErr:     ErrScopeForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc(
+				"GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+				func(w http.ResponseWriter, r *http.Request) {
+					id, _ := strconv.Atoi(r.PathValue("id"))
+					if fixture, ok := tt.fixtures[id]; ok {
+						statusFixtureHandler(t, http.StatusForbidden, fixture).ServeHTTP(w, r)
+						return
+					}
+
+					writeJSON(w, http.StatusOK, Response[Contact]{
+						Success: true,
+						Data:    Contact{Basefields: ContactBasefields{ContactID: FlexInt(id)}},
+					})
+				},
+			)
+			client, _ := newTestClient(t, mux)
+
+			var skipped []int
+			opts := append(slices.Clone(tt.opts), WithSkipped(func(id int, err error) {
+				if !errors.Is(err, ErrRecordForbidden) {
+					t.Errorf("skipped %d with error %v, want ErrRecordForbidden", id, err)
+				}
+				skipped = append(skipped, id)
+			}))
+
+			var ids []int
+			var gotErr error
+			for contact, err := range client.ContactsByIDs(
+				context.Background(),
+				[]int{1, 2, 3, 4},
+				opts...,
+			) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				ids = append(ids, contact.Basefields.ContactID.Int())
+			}
+
+			if !errors.Is(gotErr, tt.wantErr) {
+				t.Errorf("got error %v, want %v", gotErr, tt.wantErr)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("got contacts %v, want %v", ids, tt.wantIDs)
+			}
+			if !slices.Equal(skipped, tt.wantSkipped) {
+				t.Errorf("got skipped %v, want %v", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// ErrProtectedHeader is returned when a header set by the client, such as
+// Authorization, is set with [WithHeader] or [WithDefaultHeaders].
+var ErrProtectedHeader = errors.New("protected header")
+
+// protectedHeaders are the canonical names of headers which can't be set with
+// [WithHeader] or [WithDefaultHeaders].
+var protectedHeaders = map[string]bool{
+	"Authorization": true,
+	"Content-Type":  true,
+}
+
+type (
+	headerCtxKey           struct{}
+	rawAuthorizationCtxKey struct{}
+)
+
+// WithHeader returns a context carrying a header to send with requests made
+// with it, e.g. to route requests through a gateway. Headers accumulate:
+// calling WithHeader for the same key again adds a value. Headers of the
+// context replace the headers of [WithDefaultHeaders] and the headers set by
+// the client, such as User-Agent. Requests with the protected headers
+// Authorization or Content-Type fail with [ErrProtectedHeader].
+func WithHeader(ctx context.Context, key, value string) context.Context {
+	header, _ := ctx.Value(headerCtxKey{}).(http.Header)
+	header = header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Add(key, value)
+
+	return context.WithValue(ctx, headerCtxKey{}, header)
+}
+
+// WithRawAuthorization returns a context sending authorization verbatim as
+// Authorization header of requests made with it, e.g. "Bearer <token>". The
+// token of the client is neither refreshed nor used for these requests, and
+// the client's token store is left untouched.
+//
+// This is a debugging facility, e.g. to reproduce how the server handles an
+// expired token. Don't use it in production code.
+func WithRawAuthorization(ctx context.Context, authorization string) context.Context {
+	return context.WithValue(ctx, rawAuthorizationCtxKey{}, authorization)
+}
+
+// rawAuthorization returns the Authorization header set by
+// [WithRawAuthorization], if any.
+func rawAuthorization(ctx context.Context) (string, bool) {
+	authorization, ok := ctx.Value(rawAuthorizationCtxKey{}).(string)
+	return authorization, ok
+}
+
+// WithDefaultHeaders sets headers sent with all requests. They replace the
+// headers set by the client, such as User-Agent, and are replaced by the
+// headers of [WithHeader]. Setting the protected headers Authorization or
+// Content-Type makes requests fail with [ErrProtectedHeader].
+func WithDefaultHeaders(headers map[string]string) ClientOption {
+	return func(c *Client) {
+		c.headers = http.Header{}
+		for key, value := range headers {
+			c.headers.Set(key, value)
+		}
+		if err := checkHeaders(c.headers); err != nil {
+			c.err = errors.Join(c.err, err)
+		}
+	}
+}
+
+// setHeaders applies the default headers of the client and the headers of
+// the context of req.
+func (c *Client) setHeaders(req *http.Request) error {
+	ctxHeader, _ := req.Context().Value(headerCtxKey{}).(http.Header)
+	if err := checkHeaders(ctxHeader); err != nil {
+		return err
+	}
+
+	for _, header := range []http.Header{c.headers, ctxHeader} {
+		for key, values := range header {
+			req.Header[key] = slices.Clone(values)
+		}
+	}
+
+	return nil
+}
+
+// checkHeaders returns an error if header contains protected headers.
+func checkHeaders(header http.Header) error {
+	var errs []error
+	for key := range header {
+		if protectedHeaders[key] {
+			errs = append(errs, fmt.Errorf("%w %s", ErrProtectedHeader, key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
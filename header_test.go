@@ -0,0 +1,173 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Headers(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		ctx     func(context.Context) context.Context
+		want    http.Header
+		wantErr error
+	}{
+		{
+			name: "client defaults",
+			want: http.Header{"User-Agent": {"test-agent"}, "Accept-Language": {"en"}},
+		},
+		{
+			name: "default headers replace client defaults",
+			opts: []ClientOption{WithDefaultHeaders(map[string]string{
+				"User-Agent": "gateway-agent",
+				"x-tenant":   "club-1",
+			})},
+			want: http.Header{"User-Agent": {"gateway-agent"}, "X-Tenant": {"club-1"}},
+		},
+		{
+			name: "context headers replace default headers",
+			opts: []ClientOption{WithDefaultHeaders(map[string]string{
+				"X-Tenant": "club-1",
+				"X-Job":    "sync",
+			})},
+			ctx: func(ctx context.Context) context.Context {
+				ctx = WithHeader(ctx, "X-Tenant", "club-2")
+				return WithHeader(ctx, "Accept-Language", "de")
+			},
+			want: http.Header{
+				"X-Tenant":        {"club-2"},
+				"X-Job":           {"sync"},
+				"Accept-Language": {"de"},
+			},
+		},
+		{
+			name: "context headers accumulate",
+			ctx: func(ctx context.Context) context.Context {
+				ctx = WithHeader(ctx, "X-Trace", "a")
+				return WithHeader(ctx, "x-trace", "b")
+			},
+			want: http.Header{"X-Trace": {"a", "b"}},
+		},
+		{
+			name: "protected context header",
+			ctx: func(ctx context.Context) context.Context {
+				return WithHeader(ctx, "authorization", "Bearer other")
+			},
+			wantErr: ErrProtectedHeader,
+		},
+		{
+			name: "protected default header",
+			opts: []ClientOption{
+				WithDefaultHeaders(map[string]string{"Content-Type": "text/plain"}),
+			},
+			wantErr: ErrProtectedHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			opts := append([]ClientOption{WithUserAgent("test-agent")}, tt.opts...)
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					got = r.Header
+					writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+				}),
+				opts...,
+			)
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx(ctx)
+			}
+			_, err := client.Contact(ctx, 1)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Contact() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if got != nil {
+					t.Error("request with protected header was sent")
+				}
+				return
+			}
+
+			for key, want := range tt.want {
+				if !slices.Equal(got.Values(key), want) {
+					t.Errorf("header %s = %q, want %q", key, got.Values(key), want)
+				}
+			}
+			if auth := got.Get("Authorization"); auth == "" {
+				t.Error("Authorization header is missing")
+			}
+		})
+	}
+}
+
+func TestWithRawAuthorization(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	var refreshes atomic.Int32
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/auth/refresh/") {
+			refreshes.Add(1)
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Success: true,
+				Data: CreateTokenResponse{
+					Token:        createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+					RefreshToken: "refresh",
+				},
+			})
+			return
+		}
+
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+	)
+	// The token expires within a minute, so it is due for refresh.
+	expiring := createTestToken(t, privateKey, time.Now().Add(time.Minute))
+	err := client.auth.updateToken(CreateTokenResponse{Token: expiring, RefreshToken: "refresh"})
+	if err != nil {
+		t.Fatalf("failed to set up token: %v", err)
+	}
+
+	ctx := WithRawAuthorization(context.Background(), "Bearer expired")
+	if _, err := client.Contact(ctx, 1); err != nil {
+		t.Fatalf("Contact() with raw authorization error = %v", err)
+	}
+	if got := refreshes.Load(); got != 0 {
+		t.Errorf("token refreshed %d times with raw authorization, want 0", got)
+	}
+	if client.auth.token != expiring {
+		t.Error("raw authorization changed the token store")
+	}
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+	if got := refreshes.Load(); got != 1 {
+		t.Errorf("token refreshed %d times, want 1", got)
+	}
+
+	want := []string{"Bearer expired", "Bearer " + client.auth.token}
+	if !slices.Equal(authorizations, want) {
+		t.Errorf("Authorization headers = %q, want %q", authorizations, want)
+	}
+	if client.auth.token == expiring {
+		t.Error("managed token wasn't refreshed")
+	}
+}
@@ -0,0 +1,122 @@
+package fairgate
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// idempotencyKeyHeader is the header carrying the idempotency key of write requests.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+type (
+	idempotencyKeyCtxKey        struct{}
+	idempotencyKeyHandlerCtxKey struct{}
+)
+
+// WithIdempotencyKey returns a context carrying the idempotency key to use for
+// write requests made with it. Write requests made without a key get a random one,
+// which [IdempotencyKey] returns from the error of a failed write and
+// [WithIdempotencyKeyHandler] reports for any write.
+// The key is sent on every retry of a request, allowing the server to
+// deduplicate requests which were sent more than once.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// WithIdempotencyKeyHandler returns a context calling handler with the
+// idempotency key of each write request made with it before the request is
+// sent, including keys generated by the client. Use it to log the keys of
+// successful writes too, which [IdempotencyKey] can't return.
+func WithIdempotencyKeyHandler(ctx context.Context, handler func(key string)) context.Context {
+	return context.WithValue(ctx, idempotencyKeyHandlerCtxKey{}, handler)
+}
+
+// NewIdempotencyKey returns a random UUID suitable as idempotency key.
+// Generate keys upfront with it to log them or to reuse them when retrying an
+// operation after a timeout.
+func NewIdempotencyKey() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	// Set version 4 and variant bits according to RFC 9562.
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// newWriteRequest creates a new HTTP request for an endpoint modifying data.
+// The request carries an idempotency key, which stays the same across retries.
+func (c *Client) newWriteRequest(
+	ctx context.Context,
+	method, path string,
+	params url.Values,
+	body any,
+) (*http.Request, error) {
+	if err := c.checkScope(method, path); err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest(ctx, method, path, params, body)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _ := ctx.Value(idempotencyKeyCtxKey{}).(string)
+	if key == "" {
+		key = NewIdempotencyKey()
+	}
+	req.Header.Set(idempotencyKeyHeader, key)
+
+	if handler, _ := ctx.Value(idempotencyKeyHandlerCtxKey{}).(func(string)); handler != nil {
+		if err := callSafely("IdempotencyKeyHandler", func() { handler(key) }); err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+// IdempotencyKey returns the idempotency key of the write request err was
+// returned for, including keys generated by the client. Pass it to
+// [WithIdempotencyKey] to retry the operation, e.g. after a timeout, without
+// applying it twice.
+func IdempotencyKey(err error) (string, bool) {
+	var keyErr *idempotencyKeyError
+	if !errors.As(err, &keyErr) {
+		return "", false
+	}
+
+	return keyErr.key, true
+}
+
+// idempotencyKeyError annotates the error of a write request with its
+// idempotency key.
+type idempotencyKeyError struct {
+	key string
+	err error
+}
+
+// withIdempotencyKey annotates err with the idempotency key of req, if any.
+func withIdempotencyKey(req *http.Request, err error) error {
+	key := req.Header.Get(idempotencyKeyHeader)
+	if err == nil || key == "" {
+		return err
+	}
+
+	return &idempotencyKeyError{key: key, err: err}
+}
+
+// Error implements [error].
+func (e *idempotencyKeyError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error of the write request.
+func (e *idempotencyKeyError) Unwrap() error {
+	return e.err
+}
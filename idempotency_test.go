@@ -0,0 +1,141 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+var uuidPattern = regexp.MustCompile(
+	`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+)
+
+func TestNewIdempotencyKey(t *testing.T) {
+	a, b := NewIdempotencyKey(), NewIdempotencyKey()
+
+	if !uuidPattern.MatchString(a) {
+		t.Errorf("NewIdempotencyKey() = %q, want UUID v4", a)
+	}
+	if a == b {
+		t.Errorf("NewIdempotencyKey() returned %q twice", a)
+	}
+}
+
+func TestClient_IdempotencyKey_Retry(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{
+			name: "generated key",
+			ctx:  context.Background(),
+		},
+		{
+			name: "provided key",
+			ctx:  WithIdempotencyKey(context.Background(), "merge-1-2"),
+			want: "merge-1-2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var keys []string
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					keys = append(keys, r.Header.Get("Idempotency-Key"))
+
+					if len(keys) == 1 {
+						w.Header().Set(
+							"X-Ratelimit-Retry-After",
+							strconv.FormatInt(time.Now().Unix(), 10),
+						)
+						w.WriteHeader(http.StatusTooManyRequests)
+						return
+					}
+
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				}),
+				WithDestructiveOps(),
+			)
+
+			if err := client.ContactMerge(tt.ctx, 1, []int{2}); err != nil {
+				t.Fatalf("ContactMerge() error = %v", err)
+			}
+
+			if len(keys) != 2 {
+				t.Fatalf("expected 2 requests, got %d", len(keys))
+			}
+			if keys[0] != keys[1] {
+				t.Errorf("retry used key %q, original used %q", keys[1], keys[0])
+			}
+			if tt.want != "" && keys[0] != tt.want {
+				t.Errorf("Idempotency-Key = %q, want %q", keys[0], tt.want)
+			}
+			if tt.want == "" && !uuidPattern.MatchString(keys[0]) {
+				t.Errorf("Idempotency-Key = %q, want UUID v4", keys[0])
+			}
+		})
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	var sent string
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = r.Header.Get("Idempotency-Key")
+		writeJSON(w, http.StatusInternalServerError, Response[any]{Message: "unavailable"})
+	}))
+
+	err := client.ContactUpdate(context.Background(), 42, ContactUpdate{})
+	if err == nil {
+		t.Fatal("ContactUpdate() error = nil, want error")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Errorf("ContactUpdate() error = %v, want *APIError", err)
+	}
+
+	key, ok := IdempotencyKey(err)
+	if !ok || key != sent {
+		t.Errorf("IdempotencyKey() = %q, %v, want %q, true", key, ok, sent)
+	}
+
+	if _, err := client.Contact(context.Background(), 42); err == nil {
+		t.Fatal("Contact() error = nil, want error")
+	} else if key, ok := IdempotencyKey(err); ok {
+		t.Errorf("IdempotencyKey() of read = %q, want none", key)
+	}
+}
+
+func TestWithIdempotencyKeyHandler(t *testing.T) {
+	var sent []string
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Header.Get("Idempotency-Key"))
+		writeJSON(w, http.StatusOK, Response[any]{Success: true})
+	}))
+
+	var keys []string
+	ctx := WithIdempotencyKeyHandler(context.Background(), func(key string) {
+		keys = append(keys, key)
+	})
+
+	if err := client.ContactUpdate(ctx, 42, ContactUpdate{}); err != nil {
+		t.Fatalf("ContactUpdate() error = %v", err)
+	}
+	if err := client.ContactUpdate(WithIdempotencyKey(ctx, "fixed"), 42, ContactUpdate{}); err != nil {
+		t.Fatalf("ContactUpdate() error = %v", err)
+	}
+	if _, err := client.Contact(ctx, 42); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+
+	if len(sent) != 3 || !slices.Equal(keys, sent[:2]) || keys[1] != "fixed" {
+		t.Errorf("handled keys = %q, want the keys of the writes, sent %q", keys, sent)
+	}
+}
@@ -0,0 +1,183 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ErrInvoicesNotSorted is returned by [Client.InvoicesByContactIter] when the
+// server returns invoices out of contact order, so they can't be grouped.
+var ErrInvoicesNotSorted = errors.New("invoices not sorted by contact")
+
+// InvoiceStatus defines the payment status of an invoice.
+type InvoiceStatus string
+
+const (
+	InvoiceStatusOpen      InvoiceStatus = "open"
+	InvoiceStatusPaid      InvoiceStatus = "paid"
+	InvoiceStatusCancelled InvoiceStatus = "cancelled"
+)
+
+// Invoice represents an invoice issued to a contact.
+type Invoice struct {
+	// InvoiceID is the unique ID of the invoice.
+	InvoiceID int `json:"invoice_id,omitempty"`
+	// ContactID is the ID of the invoiced contact.
+	ContactID int `json:"contact_id,omitempty"`
+	// Number is the invoice number shown to the contact.
+	Number string `json:"invoice_number,omitempty"`
+	// Title describes the invoice, e.g. "Membership fee 2024".
+	Title string `json:"title,omitempty"`
+	// Amount is the total amount in minor units of Currency, e.g. 15000 for
+	// CHF 150.00.
+	Amount int64 `json:"amount,omitempty"`
+	// Currency is the ISO 4217 currency code, e.g. "CHF".
+	Currency string `json:"currency,omitempty"`
+	// Status is the payment status of the invoice.
+	Status InvoiceStatus `json:"status,omitempty"`
+	// IssueDate is the date the invoice was issued.
+	IssueDate Time `json:"issue_date"`
+	// DueDate is the date the invoice is due.
+	DueDate Time `json:"due_date"`
+}
+
+type InvoicesList struct {
+	Pagination `json:",inline"`
+	Invoices   []Invoice `json:"invoices,omitempty"`
+}
+
+// InvoiceParams represents the parameters for listing invoices.
+type InvoiceParams struct {
+	PageParams
+	// Year only returns invoices issued in this year.
+	Year int `url:"year,omitempty"`
+	// Status only returns invoices with this status.
+	Status InvoiceStatus `url:"status,omitempty"`
+	// ContactID only returns invoices of this contact.
+	ContactID int `url:"contactId,omitempty"`
+}
+
+// invoicesSortedParams represents the parameters for listing sorted invoices.
+type invoicesSortedParams struct {
+	InvoiceParams
+	SortBy    string `url:"sortBy"`
+	SortOrder string `url:"sortOrder"`
+}
+
+// ContactInvoices holds the invoices of a contact.
+type ContactInvoices struct {
+	ContactID int
+	Invoices  []Invoice
+}
+
+// InvoicesIter returns an iterator over all invoices matching params.
+func (c *Client) InvoicesIter(
+	ctx context.Context,
+	params InvoiceParams,
+	opts ...IterOption,
+) iter.Seq2[Invoice, error] {
+	return iterate(ctx, func(ctx context.Context, p PageParams) ([]Invoice, Pagination, error) {
+		params.PageParams = p
+
+		list, err := c.Invoices(ctx, params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return list.Invoices, list.Pagination, nil
+	}, c.iterOptions(opts)...)
+}
+
+// InvoicesByContactIter returns an iterator over the invoices matching params,
+// grouped by contact in ascending order of the contact ID, e.g. to generate
+// yearly statements without a request per contact.
+//
+// The server sorts the invoices by contact, so only the invoices of a single
+// contact are held in memory, even if they span several pages. If the server
+// returns invoices out of order, the iteration fails with
+// [ErrInvoicesNotSorted]. [MaxItems] limits the number of invoices, so the
+// invoices of the last contact may be incomplete.
+func (c *Client) InvoicesByContactIter(
+	ctx context.Context,
+	params InvoiceParams,
+	opts ...IterOption,
+) iter.Seq2[ContactInvoices, error] {
+	return func(yield func(ContactInvoices, error) bool) {
+		fetch := func(ctx context.Context, p PageParams) ([]Invoice, Pagination, error) {
+			params.PageParams = p
+
+			list, err := c.invoices(ctx, invoicesSortedParams{
+				InvoiceParams: params,
+				SortBy:        "contact_id",
+				SortOrder:     "asc",
+			})
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+			return list.Invoices, list.Pagination, nil
+		}
+
+		var group ContactInvoices
+		for invoice, err := range iterate(ctx, fetch, c.iterOptions(opts)...) {
+			if err != nil {
+				yield(ContactInvoices{}, err)
+				return
+			}
+
+			switch {
+			case group.Invoices == nil:
+				group = ContactInvoices{ContactID: invoice.ContactID}
+			case invoice.ContactID == group.ContactID:
+			case invoice.ContactID < group.ContactID:
+				yield(ContactInvoices{}, fmt.Errorf(
+					"%w: invoice %d of contact %d follows contact %d",
+					ErrInvoicesNotSorted,
+					invoice.InvoiceID,
+					invoice.ContactID,
+					group.ContactID,
+				))
+				return
+			default:
+				if !yield(group, nil) {
+					return
+				}
+				group = ContactInvoices{ContactID: invoice.ContactID}
+			}
+			group.Invoices = append(group.Invoices, invoice)
+		}
+
+		if group.Invoices != nil {
+			yield(group, nil)
+		}
+	}
+}
+
+// Invoices retrieves a page of invoices matching params.
+func (c *Client) Invoices(ctx context.Context, params InvoiceParams) (*InvoicesList, error) {
+	return c.invoices(ctx, params)
+}
+
+// invoices retrieves a page of invoices using params, either [InvoiceParams]
+// or invoicesSortedParams.
+func (c *Client) invoices(ctx context.Context, params any) (*InvoicesList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/invoices", url.PathEscape(c.oid))
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[InvoicesList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
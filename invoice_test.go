@@ -0,0 +1,132 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// invoicesHandler serves invoices in the given order, checking they are
+// requested sorted by contact.
+func invoicesHandler(t *testing.T, invoices []Invoice) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("sortBy") != "contact_id" || query.Get("sortOrder") != "asc" {
+			t.Errorf("unexpected sort %q %q", query.Get("sortBy"), query.Get("sortOrder"))
+		}
+		if got := query.Get("year"); got != "2024" {
+			t.Errorf("year = %q, want 2024", got)
+		}
+
+		pageNo, _ := strconv.Atoi(query.Get("pageNo"))
+		pageLimit, _ := strconv.Atoi(query.Get("pageLimit"))
+		start := min((pageNo-1)*pageLimit, len(invoices))
+		end := min(start+pageLimit, len(invoices))
+
+		writeJSON(w, http.StatusOK, Response[InvoicesList]{
+			Success: true,
+			Data: InvoicesList{
+				Pagination: Pagination{
+					TotalRecords: FlexInt(len(invoices)),
+					TotalPages:   FlexInt((len(invoices) + pageLimit - 1) / pageLimit),
+					PageNo:       FlexInt(pageNo),
+					PageLimit:    FlexInt(pageLimit),
+				},
+				Invoices: invoices[start:end],
+			},
+		})
+	})
+}
+
+// invoiceIDs returns the IDs of the invoices of each contact.
+func invoiceIDs(groups []ContactInvoices) map[int][]int {
+	ids := map[int][]int{}
+	for _, group := range groups {
+		for _, invoice := range group.Invoices {
+			ids[group.ContactID] = append(ids[group.ContactID], invoice.InvoiceID)
+		}
+	}
+	return ids
+}
+
+func TestClient_InvoicesByContactIter(t *testing.T) {
+	tests := []struct {
+		name     string
+		invoices []Invoice
+		want     map[int][]int
+		wantErr  error
+	}{
+		{
+			// With 3 invoices per page, contact 2 straddles pages 1 and 2.
+			name: "group across pages",
+			invoices: []Invoice{
+				{InvoiceID: 10, ContactID: 1},
+				{InvoiceID: 11, ContactID: 1},
+				{InvoiceID: 20, ContactID: 2},
+				{InvoiceID: 21, ContactID: 2},
+				{InvoiceID: 22, ContactID: 2},
+				{InvoiceID: 30, ContactID: 3},
+			},
+			want: map[int][]int{1: {10, 11}, 2: {20, 21, 22}, 3: {30}},
+		},
+		{
+			name: "group ends at page break",
+			invoices: []Invoice{
+				{InvoiceID: 10, ContactID: 1},
+				{InvoiceID: 11, ContactID: 1},
+				{InvoiceID: 12, ContactID: 1},
+				{InvoiceID: 20, ContactID: 2},
+			},
+			want: map[int][]int{1: {10, 11, 12}, 2: {20}},
+		},
+		{
+			name: "not sorted",
+			invoices: []Invoice{
+				{InvoiceID: 20, ContactID: 2},
+				{InvoiceID: 10, ContactID: 1},
+			},
+			wantErr: ErrInvoicesNotSorted,
+		},
+		{name: "no invoices", want: map[int][]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, invoicesHandler(t, tt.invoices))
+
+			var groups []ContactInvoices
+			var err error
+			for group, iterErr := range client.InvoicesByContactIter(
+				context.Background(),
+				InvoiceParams{Year: 2024},
+				WithPageLimit(3),
+			) {
+				if iterErr != nil {
+					err = iterErr
+					break
+				}
+				groups = append(groups, group)
+			}
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("InvoicesByContactIter() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			for i := 1; i < len(groups); i++ {
+				if groups[i].ContactID <= groups[i-1].ContactID {
+					t.Errorf("contact %d yielded after contact %d",
+						groups[i].ContactID, groups[i-1].ContactID)
+				}
+			}
+			if got := invoiceIDs(groups); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("invoices = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,37 +2,323 @@ package fairgate
 
 import (
 	"context"
+	"errors"
 	"iter"
+	"time"
 )
 
 // paginatorFunc fetches a single page of items T.
 type paginatorFunc[T any] func(context.Context, PageParams) ([]T, Pagination, error)
 
+// IterOption configures iterators such as [Client.ContactsIter].
+type IterOption func(*iterConfig)
+
+// iterConfig holds the configuration of an iterator.
+type iterConfig struct {
+	pageLimit          int
+	maxItems           int
+	maxPages           int
+	progress           func(Progress)
+	skipForbidden      bool
+	skipped            func(id int, err error)
+	summary            *IterationSummary
+	verifyCompleteness bool
+	now                func() time.Time
+	warn               func(format string, args ...any) error
+	contactsFilter     ContactsFilter
+	contactFields      []ContactField
+	maxErrors          int
+	authPause          time.Duration
+	pageBuffer         int
+	// prefetching reports whether [PrefetchNextPage] set pageBuffer, replacing
+	// prefetchPrevious.
+	prefetching      bool
+	prefetchPrevious int
+}
+
+// newIterConfig returns the iterator configuration for opts.
+func newIterConfig(opts []IterOption) iterConfig {
+	cfg := iterConfig{
+		pageLimit: 100,
+		now:       time.Now,
+		warn:      func(string, ...any) error { return nil },
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// WithPageLimit sets the number of items requested per page. The server may
+// return fewer items per page. Defaults to 100.
+func WithPageLimit(n int) IterOption {
+	return func(c *iterConfig) {
+		if n > 0 {
+			c.pageLimit = n
+		}
+	}
+}
+
+// MaxItems stops paginated iterators such as [Client.ContactsIter] after n
+// items. The page limit is lowered to n if needed, and no page is requested
+// once n items were yielded. [IterationSummary.Limited] reports whether the
+// iteration stopped due to the cap.
+func MaxItems(n int) IterOption {
+	return func(c *iterConfig) {
+		if n > 0 {
+			c.maxItems = n
+		}
+	}
+}
+
+// MaxPages stops paginated iterators such as [Client.ContactsIter] after n
+// pages were fetched. [IterationSummary.Limited] reports whether the iteration
+// stopped due to the cap.
+func MaxPages(n int) IterOption {
+	return func(c *iterConfig) {
+		if n > 0 {
+			c.maxPages = n
+		}
+	}
+}
+
+// limited reports whether the caps set by [MaxItems] or [MaxPages] were
+// reached after yielding items from pages.
+func (c iterConfig) limited(items, pages int) bool {
+	return (c.maxItems > 0 && items >= c.maxItems) || (c.maxPages > 0 && pages >= c.maxPages)
+}
+
+// ContinueOnError skips pages that fail to be fetched instead of stopping the
+// iteration, up to maxErrors pages. The errors are yielded last as a single
+// [IterationError]. If more than maxErrors pages fail, the iteration stops
+// with an [IterationError] reporting all of them. Errors due to the context
+// being done stop the iteration immediately.
+func ContinueOnError(maxErrors int) IterOption {
+	return func(c *iterConfig) {
+		c.maxErrors = max(maxErrors, 0)
+	}
+}
+
+// SkipForbidden skips items the access key has no permission to read instead
+// of failing with [ErrForbidden]. It applies to iterators fetching items one by
+// one, such as [Client.ContactsByIDs]. Use [WithSkipped] to report skipped items.
+//
+// Protected records failing with [ErrRecordForbidden] are always skipped, and
+// missing scopes failing with [ErrScopeForbidden] always stop the iteration,
+// as they affect all items.
+func SkipForbidden(skip bool) IterOption {
+	return func(c *iterConfig) {
+		c.skipForbidden = skip
+	}
+}
+
+// WithSkipped calls fn with the ID and error of each item skipped due to
+// [SkipForbidden] or [ErrRecordForbidden].
+func WithSkipped(fn func(id int, err error)) IterOption {
+	return func(c *iterConfig) {
+		c.skipped = fn
+	}
+}
+
+// skip reports whether the item id should be skipped due to err. It fails if
+// the function passed to [WithSkipped] panics.
+func (c iterConfig) skip(id int, err error) (bool, error) {
+	switch {
+	case errors.Is(err, ErrScopeForbidden):
+		return false, nil
+	case errors.Is(err, ErrRecordForbidden):
+	case !c.skipForbidden || !errors.Is(err, ErrForbidden):
+		return false, nil
+	}
+	if c.skipped != nil {
+		if err := callSafely("skipped", func() { c.skipped(id, err) }); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
 // iterate returns an iterator that walks through all pages using the provided fetcher.
-func iterate[T any](ctx context.Context, fetch paginatorFunc[T]) iter.Seq2[T, error] {
+func iterate[T any](
+	ctx context.Context,
+	fetch paginatorFunc[T],
+	opts ...IterOption,
+) iter.Seq2[T, error] {
+	cfg := newIterConfig(opts)
+	if cfg.pageBuffer > 0 {
+		return bufferPages(ctx, fetch, cfg, opts)
+	}
+
 	return func(yield func(T, error) bool) {
-		params := PageParams{PageNo: 1, PageLimit: 100}
+		var summary IterationSummary
+		if cfg.summary != nil {
+			defer func() { *cfg.summary = summary }()
+		}
 
+		params := PageParams{PageNo: 1, PageLimit: cfg.pageLimit}
+		if cfg.maxItems > 0 && cfg.maxItems < params.PageLimit {
+			params.PageLimit = cfg.maxItems
+		}
+
+		var progress *progressTracker
+		if cfg.progress != nil {
+			progress = newProgressTracker(cfg.now())
+		}
+
+		seen, expected := 0, 0
+		var last Pagination
+		var failed []*PageError
 		for {
-			items, meta, err := fetch(ctx, params)
-			if err != nil {
+			items, meta, err := fetchPage(ctx, cfg, fetch, params)
+			if err != nil && (cfg.maxErrors == 0 || ctx.Err() != nil) {
+				if errors.Is(err, ErrAuthDuringIteration) {
+					err = &PageError{PageNo: params.PageNo, Err: err}
+				}
+				summary.Err = err
 				yield(*new(T), err)
 				return
 			}
+			if err != nil {
+				failed = append(failed, &PageError{PageNo: params.PageNo, Err: err})
+				if len(failed) > cfg.maxErrors {
+					summary.Err = &IterationError{Pages: failed, Aborted: true}
+					yield(*new(T), summary.Err)
+					return
+				}
+
+				// Assume the page was full, so the following pages are unaffected.
+				seen += params.PageLimit
+				if !morePagesAfterSkip(params, last, seen) {
+					summary.Err = &IterationError{Pages: failed}
+					yield(*new(T), summary.Err)
+					return
+				}
+				params.PageNo++
+				continue
+			}
+			last = meta
+			if summary.PagesFetched == 0 {
+				expected = meta.TotalRecords.Int()
+			}
+			seen += len(items)
+			summary.PagesFetched++
+			summary.ServerTotalRecords = meta.TotalRecords.Int()
+			if progress != nil {
+				p := progress.page(cfg.now(), len(items), meta)
+				if err := callSafely("progress", func() { cfg.progress(p) }); err != nil {
+					summary.Err = err
+					yield(*new(T), err)
+					return
+				}
+			}
 
 			for _, item := range items {
+				if cfg.maxItems > 0 && summary.ItemsYielded == cfg.maxItems {
+					summary.Limited = true
+					return
+				}
+				summary.ItemsYielded++
 				if !yield(item, nil) {
 					return
 				}
 			}
 
-			if meta.TotalPages > 0 && params.PageNo >= meta.TotalPages {
+			if !morePages(params, meta, len(items), seen) {
+				if len(failed) > 0 {
+					summary.Err = &IterationError{Pages: failed}
+					yield(*new(T), summary.Err)
+					return
+				}
+				summary.Completed = true
+				if cfg.verifyCompleteness && expected > 0 && summary.ItemsYielded != expected {
+					summary.Err = &IncompleteIterationError{
+						Expected: expected,
+						Yielded:  summary.ItemsYielded,
+					}
+					yield(*new(T), summary.Err)
+				}
+				return
+			}
+			if cfg.limited(summary.ItemsYielded, summary.PagesFetched) {
+				summary.Limited = true
 				return
 			}
-			if len(items) == 0 {
+			params, err = nextPage(params, meta, len(items), seen, cfg.warn)
+			if err != nil {
+				summary.Err = err
+				yield(*new(T), err)
 				return
 			}
-			params.PageNo++
 		}
 	}
 }
+
+// morePages reports whether pages follow the page of n items requested with
+// params, after seen items in total. As long as the last page wasn't empty,
+// the total number of records takes precedence over the total number of
+// pages, which is wrong if the server capped the page limit.
+func morePages(params PageParams, meta Pagination, n, seen int) bool {
+	switch {
+	case n == 0:
+		return false
+	case meta.TotalRecords.Int() > 0:
+		return seen < meta.TotalRecords.Int()
+	case meta.TotalPages.Int() > 0:
+		return params.PageNo < meta.TotalPages.Int()
+	default:
+		return true
+	}
+}
+
+// morePagesAfterSkip reports whether pages follow the page requested with
+// params that failed and was skipped, after seen items in total, based on the
+// pagination of the last page fetched. Without any page fetched, the following
+// page is tried.
+func morePagesAfterSkip(params PageParams, last Pagination, seen int) bool {
+	switch {
+	case last.TotalRecords.Int() > 0:
+		return seen < last.TotalRecords.Int()
+	case last.TotalPages.Int() > 0:
+		return params.PageNo < last.TotalPages.Int()
+	default:
+		return true
+	}
+}
+
+// nextPage returns the parameters of the page following the page of n items
+// requested with params, after seen items in total. The server may cap the
+// page limit, either reporting the capped limit or echoing the requested one;
+// the next page is requested with the limit actually used.
+func nextPage(
+	params PageParams,
+	meta Pagination,
+	n, seen int,
+	warn func(format string, args ...any) error,
+) (PageParams, error) {
+	limit := params.PageLimit
+	if meta.PageLimit.Int() > 0 && meta.PageLimit.Int() != limit {
+		err := warn(
+			"server used page limit %d instead of requested %d", meta.PageLimit.Int(), limit,
+		)
+		if err != nil {
+			return params, err
+		}
+		limit = meta.PageLimit.Int()
+	}
+	if n < limit && seen < meta.TotalRecords.Int() {
+		err := warn("server returned %d items for page limit %d before the last page", n, limit)
+		if err != nil {
+			return params, err
+		}
+		limit = n
+	}
+
+	if limit != params.PageLimit && seen%limit == 0 {
+		return PageParams{PageNo: seen/limit + 1, PageLimit: limit}, nil
+	}
+
+	params.PageNo++
+	return params, nil
+}
@@ -3,6 +3,10 @@ package fairgate
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync/atomic"
 	"testing"
 )
 
@@ -247,7 +251,7 @@ func TestIterate_ContextCancellation(t *testing.T) {
 		}
 
 		return []string{"item1", "item2"},
-			Pagination{TotalRecords: 100, TotalPages: 50, PageNo: params.PageNo, PageLimit: 2}, nil
+			Pagination{TotalRecords: 100, TotalPages: 50, PageNo: FlexInt(params.PageNo), PageLimit: 2}, nil
 	}
 
 	var collected []string
@@ -270,3 +274,237 @@ func TestIterate_ContextCancellation(t *testing.T) {
 		t.Errorf("expected 2 items before cancellation, got %d", len(collected))
 	}
 }
+
+// cappedFetcher returns a fetcher serving total items of a server capping the
+// page limit to limit. If echo is set, the server reports the requested page
+// limit and the total pages based on it.
+func cappedFetcher(total, limit int, echo bool) paginatorFunc[int] {
+	return func(ctx context.Context, params PageParams) ([]int, Pagination, error) {
+		used := min(params.PageLimit, limit)
+		reported := used
+		if echo {
+			reported = params.PageLimit
+		}
+
+		var items []int
+		for i := (params.PageNo - 1) * used; i < min(params.PageNo*used, total); i++ {
+			items = append(items, i)
+		}
+
+		return items, Pagination{
+			TotalRecords: FlexInt(total),
+			TotalPages:   FlexInt((total + reported - 1) / reported),
+			PageNo:       FlexInt(params.PageNo),
+			PageLimit:    FlexInt(reported),
+		}, nil
+	}
+}
+
+func TestIterate_CappedPageLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		echo bool
+	}{
+		{name: "reported limit", echo: false},
+		{name: "echoed limit", echo: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			warn := func(c *iterConfig) {
+				c.warn = func(format string, args ...any) error {
+					warnings = append(warnings, fmt.Sprintf(format, args...))
+					return nil
+				}
+			}
+
+			fetcher := cappedFetcher(250, 20, tt.echo)
+
+			var collected []int
+			for item, err := range iterate(context.Background(), fetcher, warn) {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				collected = append(collected, item)
+			}
+
+			if len(collected) != 250 {
+				t.Fatalf("expected 250 items, got %d", len(collected))
+			}
+			for i, item := range collected {
+				if item != i {
+					t.Fatalf("item[%d] = %d, want %d", i, item, i)
+				}
+			}
+			if len(warnings) != 1 {
+				t.Errorf("expected 1 warning, got %q", warnings)
+			}
+		})
+	}
+}
+
+func TestIterate_TotalPagesTooLow(t *testing.T) {
+	callCount := 0
+	fetcher := func(ctx context.Context, params PageParams) ([]string, Pagination, error) {
+		callCount++
+
+		switch params.PageNo {
+		case 1:
+			return []string{"item1", "item2"},
+				Pagination{TotalRecords: 3, TotalPages: 1, PageNo: 1, PageLimit: 2}, nil
+		case 2:
+			return []string{"item3"},
+				Pagination{TotalRecords: 3, TotalPages: 1, PageNo: 2, PageLimit: 2}, nil
+		default:
+			t.Fatalf("unexpected page number: %d", params.PageNo)
+			return nil, Pagination{}, nil
+		}
+	}
+
+	var collected []string
+	for item, err := range iterate(context.Background(), fetcher) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		collected = append(collected, item)
+	}
+
+	if len(collected) != 3 {
+		t.Errorf("expected 3 items, got %d", len(collected))
+	}
+	if callCount != 2 {
+		t.Errorf("expected 2 fetcher calls, got %d", callCount)
+	}
+}
+
+
+func TestClient_ContactsIter_Caps(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []IterOption
+		wantItems    int
+		wantRequests int32
+		wantLimited  bool
+	}{
+		{name: "no caps", wantItems: 25, wantRequests: 3},
+		{
+			name:         "items within first page",
+			opts:         []IterOption{MaxItems(4)},
+			wantItems:    4,
+			wantRequests: 1,
+			wantLimited:  true,
+		},
+		{
+			name:         "items at page boundary",
+			opts:         []IterOption{MaxItems(20)},
+			wantItems:    20,
+			wantRequests: 2,
+			wantLimited:  true,
+		},
+		{
+			name:         "items within last page",
+			opts:         []IterOption{MaxItems(22)},
+			wantItems:    22,
+			wantRequests: 3,
+			wantLimited:  true,
+		},
+		{
+			name:         "items equal total",
+			opts:         []IterOption{MaxItems(25)},
+			wantItems:    25,
+			wantRequests: 3,
+		},
+		{
+			name:         "items beyond total",
+			opts:         []IterOption{MaxItems(100)},
+			wantItems:    25,
+			wantRequests: 3,
+		},
+		{
+			name:         "one page",
+			opts:         []IterOption{MaxPages(1)},
+			wantItems:    10,
+			wantRequests: 1,
+			wantLimited:  true,
+		},
+		{
+			name:         "two pages",
+			opts:         []IterOption{MaxPages(2)},
+			wantItems:    20,
+			wantRequests: 2,
+			wantLimited:  true,
+		},
+		{
+			name:         "pages beyond total",
+			opts:         []IterOption{MaxPages(5)},
+			wantItems:    25,
+			wantRequests: 3,
+		},
+		{
+			name:         "pages before items",
+			opts:         []IterOption{MaxItems(15), MaxPages(1)},
+			wantItems:    10,
+			wantRequests: 1,
+			wantLimited:  true,
+		},
+		{
+			name:         "items before pages",
+			opts:         []IterOption{MaxItems(15), MaxPages(3)},
+			wantItems:    15,
+			wantRequests: 2,
+			wantLimited:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			handler := contactsHandler(25)
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests.Add(1)
+					handler.ServeHTTP(w, r)
+				}),
+			)
+
+			var summary IterationSummary
+			opts := append([]IterOption{WithPageLimit(10), WithSummary(&summary)}, tt.opts...)
+			items := 0
+			for _, err := range client.ContactsIter(context.Background(), opts...) {
+				if err != nil {
+					t.Fatalf("ContactsIter() error = %v", err)
+				}
+				items++
+			}
+
+			if items != tt.wantItems {
+				t.Errorf("items = %d, want %d", items, tt.wantItems)
+			}
+			if got := requests.Load(); got != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", got, tt.wantRequests)
+			}
+			if summary.Limited != tt.wantLimited || summary.Completed == tt.wantLimited ||
+				summary.Err != nil {
+				t.Errorf("summary = %+v, want Limited = %t", summary, tt.wantLimited)
+			}
+		})
+	}
+}
+
+func TestIterate_MaxItemsLowersPageLimit(t *testing.T) {
+	var limits []int
+	fetcher := func(ctx context.Context, params PageParams) ([]int, Pagination, error) {
+		limits = append(limits, params.PageLimit)
+		items := make([]int, params.PageLimit)
+		return items, Pagination{TotalRecords: 100, TotalPages: FlexInt(100 / params.PageLimit)}, nil
+	}
+
+	for range iterate(context.Background(), fetcher, WithPageLimit(50), MaxItems(3)) {
+	}
+
+	if want := []int{3}; !slices.Equal(limits, want) {
+		t.Errorf("page limits = %v, want %v", limits, want)
+	}
+}
@@ -0,0 +1,223 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrInvalidMapping is returned by [MapContact] for a destination field that
+// can't be mapped, e.g. as its type doesn't match the contact field.
+var ErrInvalidMapping = errors.New("invalid contact mapping")
+
+// mapTag is the struct tag holding the path of the contact field mapped to a
+// destination field by [MapContact].
+const mapTag = "fairgate"
+
+// MapOption configures [MapContact].
+type MapOption func(*mapConfig)
+
+// mapConfig holds the configuration of [MapContact].
+type mapConfig struct {
+	strict bool
+}
+
+// StrictMapping makes [MapContact] fail for tags referencing unknown contact
+// fields, e.g. due to a typo. They are ignored by default.
+func StrictMapping() MapOption {
+	return func(cfg *mapConfig) {
+		cfg.strict = true
+	}
+}
+
+// MapContact maps c to the struct T, setting each field tagged with the
+// dot-separated JSON path of a contact field, e.g.
+//
+//	type Member struct {
+//		ID        int       `fairgate:"basefields.contact_id"`
+//		FirstName string    `fairgate:"basefields.first_name"`
+//		Email     string    `fairgate:"communication.primary_email"`
+//		City      string    `fairgate:"corr_address.city"`
+//		Joined    time.Time `fairgate:"membership.first_joining_date"`
+//	}
+//
+// Untagged struct fields are mapped recursively. Fields in sections missing
+// from c, such as the membership of a federation contact, keep their zero
+// value, or are left nil if they are pointers.
+//
+// [Time] is converted to [time.Time], and enums like [Gender] or [FlexInt] to
+// their underlying string or integer types. Other type mismatches fail with
+// [ErrInvalidMapping], as do tags referencing unknown paths with
+// [StrictMapping].
+func MapContact[T any](c Contact, opts ...MapOption) (T, error) {
+	var cfg mapConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var dst T
+	v := reflect.ValueOf(&dst).Elem()
+	if v.Kind() != reflect.Struct {
+		return dst, fmt.Errorf("%w: %s is not a struct", ErrInvalidMapping, v.Type())
+	}
+
+	if err := mapFields(v, reflect.ValueOf(c), cfg); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return dst, nil
+}
+
+// mapFields sets the tagged fields of the struct dst from src.
+func mapFields(dst, src reflect.Value, cfg mapConfig) error {
+	for i := range dst.NumField() {
+		field := dst.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path, ok := field.Tag.Lookup(mapTag)
+		if path == "-" {
+			continue
+		}
+		if !ok {
+			if isMappedStruct(field.Type) {
+				if err := mapFields(dst.Field(i), src, cfg); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		value, found, err := contactPathValue(src, path)
+		if err != nil {
+			if cfg.strict {
+				return fmt.Errorf("%w: field %s: %w", ErrInvalidMapping, field.Name, err)
+			}
+			continue
+		}
+		if !found {
+			continue
+		}
+
+		if err := assignMapped(dst.Field(i), value); err != nil {
+			return fmt.Errorf("%w: field %s from %q: %w", ErrInvalidMapping, field.Name, path, err)
+		}
+	}
+
+	return nil
+}
+
+// isMappedStruct reports whether untagged fields of type t are mapped
+// recursively.
+func isMappedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != reflect.TypeFor[time.Time]()
+}
+
+// contactPathValue returns the value of the contact field at the
+// dot-separated JSON path. It reports whether the field is present, which it
+// isn't if a section on the path is nil. The path is validated even then.
+func contactPathValue(v reflect.Value, path string) (reflect.Value, bool, error) {
+	found := true
+	for segment := range strings.SplitSeq(path, ".") {
+		t := v.Type()
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+			if !found || v.IsNil() {
+				found = false
+				v = reflect.Zero(t)
+			} else {
+				v = v.Elem()
+			}
+		}
+		if t.Kind() != reflect.Struct || t == reflect.TypeFor[Time]() {
+			return reflect.Value{}, false, fmt.Errorf("unknown path %q", path)
+		}
+
+		index, ok := jsonFieldIndex(t, segment)
+		if !ok {
+			return reflect.Value{}, false, fmt.Errorf("unknown path %q", path)
+		}
+		v = v.FieldByIndex(index)
+	}
+
+	if v.Kind() == reflect.Pointer && v.IsNil() {
+		found = false
+	}
+
+	return v, found, nil
+}
+
+// jsonFieldIndex returns the index of the field of the struct t with the
+// given JSON name, including fields of embedded structs.
+func jsonFieldIndex(t reflect.Type, name string) ([]int, bool) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		fieldName, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		if fieldName == "" && field.Type.Kind() == reflect.Struct {
+			if index, ok := jsonFieldIndex(field.Type, name); ok {
+				return append([]int{i}, index...), true
+			}
+			continue
+		}
+		if fieldName == name {
+			return []int{i}, true
+		}
+	}
+
+	return nil, false
+}
+
+// assignMapped sets dst to src, converting between compatible types.
+func assignMapped(dst, src reflect.Value) error {
+	if src.Kind() == reflect.Pointer {
+		src = src.Elem()
+	}
+	if t, ok := src.Interface().(Time); ok {
+		src = reflect.ValueOf(t.Time)
+	}
+
+	if dst.Kind() == reflect.Pointer && !src.Type().AssignableTo(dst.Type()) {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+	case sameKindClass(src.Kind(), dst.Kind()) && src.Type().ConvertibleTo(dst.Type()):
+		dst.Set(src.Convert(dst.Type()))
+	default:
+		return fmt.Errorf("cannot convert %s to %s", src.Type(), dst.Type())
+	}
+
+	return nil
+}
+
+// sameKindClass reports whether a and b are both strings, integers, or
+// floats, so converting between them preserves the value.
+func sameKindClass(a, b reflect.Kind) bool {
+	class := func(k reflect.Kind) int {
+		switch k {
+		case reflect.String:
+			return 1
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return 2
+		case reflect.Float32, reflect.Float64:
+			return 3
+		default:
+			return 0
+		}
+	}
+
+	return class(a) != 0 && class(a) == class(b)
+}
@@ -0,0 +1,128 @@
+package fairgate
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// mappedMember is a destination type of a typical downstream application.
+type mappedMember struct {
+	ID        int       `fairgate:"basefields.contact_id"`
+	FirstName string    `fairgate:"basefields.first_name"`
+	LastName  string    `fairgate:"basefields.last_name"`
+	Gender    string    `fairgate:"basefields.gender"`
+	Birthdate time.Time `fairgate:"basefields.birthdate"`
+	Status    string    `fairgate:"status"`
+	Email     string    `fairgate:"communication.primary_email"`
+
+	Address struct {
+		Street string `fairgate:"corr_address.street"`
+		City   string `fairgate:"corr_address.city"`
+	}
+
+	MembershipType string     `fairgate:"membership.membership"`
+	Joined         *time.Time `fairgate:"membership.first_joining_date"`
+	Notes          string     `fairgate:"-"`
+}
+
+func TestMapContact(t *testing.T) {
+	birthdate := time.Date(2010, 5, 17, 0, 0, 0, 0, time.UTC)
+	joined := time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC)
+	contact := Contact{
+		Basefields: ContactBasefields{
+			ContactID: 42,
+			FirstName: "Anna",
+			LastName:  "Muster",
+			Gender:    GenderFemale,
+			Birthdate: Time{birthdate},
+		},
+		Status:        ContactStatusActive,
+		Communication: Communication{PrimaryEmail: "anna@example.com"},
+		CorrAddress:   Address{Street: "Bahnhofstrasse 1", City: "Zürich"},
+		Membership:    &Membership{Membership: "Active", FirstJoiningDate: Time{joined}},
+	}
+
+	got, err := MapContact[mappedMember](contact, StrictMapping())
+	if err != nil {
+		t.Fatalf("MapContact() error = %v", err)
+	}
+
+	want := mappedMember{
+		ID:             42,
+		FirstName:      "Anna",
+		LastName:       "Muster",
+		Gender:         string(GenderFemale),
+		Birthdate:      birthdate,
+		Status:         "active",
+		Email:          "anna@example.com",
+		MembershipType: "Active",
+		Joined:         &joined,
+	}
+	want.Address.Street = "Bahnhofstrasse 1"
+	want.Address.City = "Zürich"
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MapContact() = %+v, want %+v", got, want)
+	}
+}
+
+func TestMapContact_MissingSection(t *testing.T) {
+	// Federation contacts have no membership.
+	contact := Contact{Basefields: ContactBasefields{ContactID: 7}}
+
+	got, err := MapContact[mappedMember](contact, StrictMapping())
+	if err != nil {
+		t.Fatalf("MapContact() error = %v", err)
+	}
+	if got.ID != 7 || got.MembershipType != "" || got.Joined != nil {
+		t.Errorf("MapContact() = %+v, want ID 7 without membership", got)
+	}
+}
+
+func TestMapContact_Errors(t *testing.T) {
+	type typo struct {
+		Email string `fairgate:"communication.primary_mail"`
+	}
+	type nestedTypo struct {
+		Joined time.Time `fairgate:"membership.joined"`
+	}
+	type mismatch struct {
+		ID string `fairgate:"basefields.contact_id"`
+	}
+
+	contact := Contact{Communication: Communication{PrimaryEmail: "anna@example.com"}}
+
+	tests := []struct {
+		name    string
+		mapping func(Contact, ...MapOption) error
+		opts    []MapOption
+		wantErr error
+	}{
+		{"unknown path", mapError[typo], nil, nil},
+		{"unknown path strict", mapError[typo], []MapOption{StrictMapping()}, ErrInvalidMapping},
+		// Paths into missing sections are validated as well.
+		{
+			"unknown path in missing section",
+			mapError[nestedTypo],
+			[]MapOption{StrictMapping()},
+			ErrInvalidMapping,
+		},
+		{"type mismatch", mapError[mismatch], nil, ErrInvalidMapping},
+		{"not a struct", mapError[string], nil, ErrInvalidMapping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.mapping(contact, tt.opts...); !errors.Is(err, tt.wantErr) {
+				t.Errorf("MapContact() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// mapError returns the error of mapping c to T.
+func mapError[T any](c Contact, opts ...MapOption) error {
+	_, err := MapContact[T](c, opts...)
+	return err
+}
@@ -0,0 +1,145 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// maxMergeHops limits the merges followed by [WithFollowMerges], in case
+// contacts were merged repeatedly.
+const maxMergeHops = 5
+
+// ErrContactMerged is returned by [Client.Contact] for a contact merged into
+// another contact. See [ContactMergedError] for the ID of the other contact.
+var ErrContactMerged = errors.New("contact merged")
+
+// ContactMergedError is returned by [Client.Contact] for a contact that was
+// merged into another contact, e.g. after removing duplicates. References to
+// ContactID should be updated to MergedInto. It wraps [ErrContactMerged].
+type ContactMergedError struct {
+	// ContactID is the ID of the merged contact, which no longer exists.
+	ContactID int
+	// MergedInto is the ID of the contact it was merged into.
+	MergedInto int
+}
+
+func (e *ContactMergedError) Error() string {
+	return fmt.Sprintf("%s: contact %d merged into contact %d",
+		ErrContactMerged, e.ContactID, e.MergedInto)
+}
+
+func (e *ContactMergedError) Unwrap() error {
+	return ErrContactMerged
+}
+
+// WithFollowMerges makes [Client.Contact] return the contact a requested
+// contact was merged into instead of a [ContactMergedError].
+func WithFollowMerges() ClientOption {
+	return func(c *Client) {
+		c.followMerges = true
+	}
+}
+
+// contactDetailPath matches the path of a contact and captures its ID.
+var contactDetailPath = regexp.MustCompile(`^/fsa/v2\.0/contact/[^/]+/contacts/(\d+)/extended$`)
+
+// contactDetailID returns the ID of the contact requested with path.
+func contactDetailID(path string) (int, bool) {
+	match := contactDetailPath.FindStringSubmatch(path)
+	if match == nil {
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(match[1])
+	return id, err == nil
+}
+
+// isMergeRedirect reports whether the redirect to req is the permanent
+// redirect of a merged contact, which is returned instead of followed.
+func isMergeRedirect(req *http.Request, via []*http.Request) bool {
+	if req.Response == nil {
+		return false
+	}
+	switch req.Response.StatusCode {
+	case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+	default:
+		return false
+	}
+
+	_, ok := contactDetailID(via[len(via)-1].URL.Path)
+	return ok
+}
+
+// mergeRedirectError returns a [ContactMergedError] if resp is a permanent
+// redirect of a contact request to the contact it was merged into.
+func mergeRedirectError(resp *http.Response) error {
+	id, ok := requestedContactID(resp)
+	if !ok {
+		return nil
+	}
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusPermanentRedirect:
+	default:
+		return nil
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return nil
+	}
+	mergedInto, ok := contactDetailID(location.Path)
+	if !ok {
+		return nil
+	}
+
+	return &ContactMergedError{ContactID: id, MergedInto: mergedInto}
+}
+
+// mergeEnvelopeError returns a [ContactMergedError] if the envelope of the
+// failed contact request resp reports the contact it was merged into in the
+// "merged_into" field of its data.
+func mergeEnvelopeError(resp *http.Response, envelope *Response[json.RawMessage]) error {
+	id, ok := requestedContactID(resp)
+	if !ok || envelope == nil || len(envelope.Data) == 0 {
+		return nil
+	}
+
+	var data struct {
+		MergedInto FlexInt `json:"merged_into"`
+	}
+	if json.Unmarshal(envelope.Data, &data) != nil || data.MergedInto.Int() <= 0 {
+		return nil
+	}
+
+	return &ContactMergedError{ContactID: id, MergedInto: data.MergedInto.Int()}
+}
+
+// requestedContactID returns the ID of the contact requested by the request
+// of resp.
+func requestedContactID(resp *http.Response) (int, bool) {
+	if resp.Request == nil {
+		return 0, false
+	}
+
+	return contactDetailID(resp.Request.URL.Path)
+}
+
+// followMerge fetches the contact a merged contact was merged into, if
+// [WithFollowMerges] is set. Otherwise, it returns err.
+func (c *Client) followMerge(
+	ctx context.Context,
+	err error,
+	hops int,
+) (*Response[Contact], error) {
+	var merged *ContactMergedError
+	if !c.followMerges || hops >= maxMergeHops || !errors.As(err, &merged) {
+		return nil, err
+	}
+
+	return c.contact(ctx, merged.MergedInto, hops+1)
+}
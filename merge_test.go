@@ -0,0 +1,106 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// mergingHandler serves contact 2 and reports contact 1 as merged into
+// contact 2 using merged.
+func mergingHandler(merged http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/contacts/1/extended") {
+			merged(w, r)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Response[Contact]{
+			Success: true,
+			Data:    Contact{Basefields: ContactBasefields{ContactID: 2}},
+		})
+	})
+}
+
+func TestClient_Contact_Merged(t *testing.T) {
+	redirect := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			location := strings.Replace(r.URL.Path, "/contacts/1/", "/contacts/2/", 1)
+			http.Redirect(w, r, location, status)
+		}
+	}
+	envelope := func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusGone, Response[map[string]any]{
+			Message: "contact merged",
+			Data:    map[string]any{"merged_into": "2"},
+		})
+	}
+
+	tests := []struct {
+		name   string
+		merged http.HandlerFunc
+	}{
+		{"moved permanently", redirect(http.StatusMovedPermanently)},
+		{"permanent redirect", redirect(http.StatusPermanentRedirect)},
+		{"envelope", envelope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, mergingHandler(tt.merged))
+
+			_, err := client.Contact(context.Background(), 1)
+			var merged *ContactMergedError
+			if !errors.As(err, &merged) || !errors.Is(err, ErrContactMerged) {
+				t.Fatalf("Contact() error = %v, want %v", err, ErrContactMerged)
+			}
+			if merged.ContactID != 1 || merged.MergedInto != 2 {
+				t.Errorf("ContactMergedError = %+v, want 1 merged into 2", merged)
+			}
+
+			client, _ = newTestClient(t, mergingHandler(tt.merged), WithFollowMerges())
+			contact, err := client.Contact(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Contact() with WithFollowMerges() error = %v", err)
+			}
+			if got := contact.Data.Basefields.ContactID; got != 2 {
+				t.Errorf("ContactID = %d, want 2", got)
+			}
+		})
+	}
+}
+
+func TestClient_Contact_MergeLoop(t *testing.T) {
+	requests := 0
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		id := strconv.Itoa(requests + 1)
+		http.Redirect(w, r, "../"+id+"/extended", http.StatusMovedPermanently)
+	}), WithFollowMerges())
+
+	_, err := client.Contact(context.Background(), 1)
+	if !errors.Is(err, ErrContactMerged) {
+		t.Fatalf("Contact() error = %v, want %v", err, ErrContactMerged)
+	}
+	if want := maxMergeHops + 1; requests != want {
+		t.Errorf("requests = %d, want %d", requests, want)
+	}
+}
+
+func TestClient_MergeRedirectScope(t *testing.T) {
+	// Redirects of other endpoints are still followed.
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("moved") == "" {
+			http.Redirect(w, r, r.URL.Path+"?moved=1", http.StatusMovedPermanently)
+			return
+		}
+		writeJSON(w, http.StatusOK, Response[ContactsList]{Success: true})
+	}))
+
+	if _, err := client.Contacts(context.Background(), PageParams{}); err != nil {
+		t.Fatalf("Contacts() error = %v", err)
+	}
+}
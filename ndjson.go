@@ -0,0 +1,152 @@
+package fairgate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"iter"
+	"time"
+)
+
+// ndjsonConfig holds the configuration of [Client.ContactsWriteNDJSON].
+type ndjsonConfig struct {
+	flatten    bool
+	fetchedAt  bool
+	flushEvery int
+}
+
+// NDJSONOption configures [Client.ContactsWriteNDJSON].
+type NDJSONOption func(*ndjsonConfig)
+
+// NDJSONFlatten flattens nested objects into dotted keys, e.g. "basefields.first_name".
+func NDJSONFlatten() NDJSONOption {
+	return func(c *ndjsonConfig) {
+		c.flatten = true
+	}
+}
+
+// NDJSONFetchedAt adds a "_fetched_at" timestamp to every row.
+func NDJSONFetchedAt() NDJSONOption {
+	return func(c *ndjsonConfig) {
+		c.fetchedAt = true
+	}
+}
+
+// NDJSONFlushEvery flushes the output at least every n rows. Defaults to 100.
+func NDJSONFlushEvery(n int) NDJSONOption {
+	return func(c *ndjsonConfig) {
+		c.flushEvery = n
+	}
+}
+
+// ContactsWriteNDJSON writes all contacts as newline-delimited JSON to w, one
+// compact JSON object per line. It returns the number of rows written.
+// If the iteration fails, the rows written so far are flushed and the error is
+// returned; a row is never written partially. Pages are fetched ahead while
+// writing, but never more than two, see [WithPageBuffer].
+func (c *Client) ContactsWriteNDJSON(
+	ctx context.Context,
+	w io.Writer,
+	opts ...NDJSONOption,
+) (int, error) {
+	seq := c.ContactsIter(ctx, WithPageBuffer(exportPageBuffer))
+	return writeContactsNDJSON(w, seq, c.clock.localNow, opts...)
+}
+
+// writeContactsNDJSON writes the contacts of seq as newline-delimited JSON to w.
+func writeContactsNDJSON(
+	w io.Writer,
+	seq iter.Seq2[Contact, error],
+	now func() time.Time,
+	opts ...NDJSONOption,
+) (int, error) {
+	cfg := ndjsonConfig{flushEvery: 100}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	bw := bufio.NewWriter(w)
+	n := 0
+	for contact, err := range seq {
+		if err != nil {
+			if ferr := bw.Flush(); ferr != nil {
+				return n, ferr
+			}
+			return n, err
+		}
+
+		line, err := ndjsonLine(contact, cfg, now)
+		if err != nil {
+			return n, err
+		}
+		if _, err := bw.Write(line); err != nil {
+			return n, err
+		}
+		n++
+
+		if cfg.flushEvery > 0 && n%cfg.flushEvery == 0 {
+			if err := bw.Flush(); err != nil {
+				return n, err
+			}
+		}
+	}
+
+	return n, bw.Flush()
+}
+
+// ndjsonLine encodes a contact as a single line including the newline.
+func ndjsonLine(contact Contact, cfg ndjsonConfig, now func() time.Time) ([]byte, error) {
+	data, err := json.Marshal(contact)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.flatten {
+		var nested map[string]any
+		if err := json.Unmarshal(data, &nested); err != nil {
+			return nil, err
+		}
+
+		flat := map[string]any{}
+		flattenJSON("", nested, flat)
+		if cfg.fetchedAt {
+			flat["_fetched_at"] = now().UTC().Format(time.RFC3339)
+		}
+
+		data, err = json.Marshal(flat)
+		if err != nil {
+			return nil, err
+		}
+	} else if cfg.fetchedAt {
+		fetchedAt, err := json.Marshal(now().UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, err
+		}
+
+		prefix := append([]byte(`{"_fetched_at":`), fetchedAt...)
+		if !bytes.Equal(data, []byte("{}")) {
+			prefix = append(prefix, ',')
+		}
+		data = append(prefix, data[1:]...)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// flattenJSON copies the values of nested into flat, joining the keys of
+// nested objects with dots. Arrays are kept as is.
+func flattenJSON(prefix string, nested map[string]any, flat map[string]any) {
+	for key, value := range nested {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if obj, ok := value.(map[string]any); ok {
+			flattenJSON(key, obj, flat)
+			continue
+		}
+		flat[key] = value
+	}
+}
@@ -0,0 +1,177 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"iter"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// ndjsonContacts returns the contacts used by the NDJSON golden tests.
+func ndjsonContacts() iter.Seq2[Contact, error] {
+	contacts := []Contact{
+		{
+			Basefields: ContactBasefields{
+				ContactID:   1,
+				FirstName:   "Anna",
+				LastName:    "Muster",
+				ContactType: ContactTypeSinglePerson,
+				LastUpdate:  Time{time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)},
+			},
+			Status:        ContactStatusActive,
+			Communication: Communication{PrimaryEmail: "anna@example.com"},
+			CorrAddress:   Address{Street: "Bahnhofstrasse 1", PostaleCode: "8001", City: "Zürich"},
+		},
+		{
+			Basefields: ContactBasefields{
+				ContactID:   2,
+				CompanyName: "Beispiel AG",
+				ContactType: ContactTypeCompany,
+				LastUpdate:  Time{time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)},
+			},
+			Status: ContactStatusArchived,
+			ClubAssignments: &ClubAssignments{
+				Secondary: []ClubAssignment{{OrganizationID: "club-a", Organization: "Club A"}},
+			},
+		},
+	}
+
+	return func(yield func(Contact, error) bool) {
+		for _, contact := range contacts {
+			if !yield(contact, nil) {
+				return
+			}
+		}
+	}
+}
+
+// assertGolden compares got with the golden file testdata/name.
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s:\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+func TestWriteContactsNDJSON_Golden(t *testing.T) {
+	now := func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		golden string
+		opts   []NDJSONOption
+	}{
+		{
+			name:   "nested",
+			golden: "contacts_nested.ndjson",
+		},
+		{
+			name:   "nested with fetched at",
+			golden: "contacts_nested_fetched_at.ndjson",
+			opts:   []NDJSONOption{NDJSONFetchedAt()},
+		},
+		{
+			name:   "flattened",
+			golden: "contacts_flat.ndjson",
+			opts:   []NDJSONOption{NDJSONFlatten(), NDJSONFetchedAt()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := writeContactsNDJSON(&buf, ndjsonContacts(), now, tt.opts...)
+			if err != nil {
+				t.Fatalf("writeContactsNDJSON() error = %v", err)
+			}
+			if n != 2 {
+				t.Errorf("writeContactsNDJSON() = %d rows, want 2", n)
+			}
+
+			assertGolden(t, tt.golden, buf.Bytes())
+		})
+	}
+}
+
+// countingWriter counts the calls to Write.
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+func TestWriteContactsNDJSON_FlushEvery(t *testing.T) {
+	var w countingWriter
+	n, err := writeContactsNDJSON(&w, ndjsonContacts(), time.Now, NDJSONFlushEvery(1))
+	if err != nil {
+		t.Fatalf("writeContactsNDJSON() error = %v", err)
+	}
+
+	if w.writes != n {
+		t.Errorf("got %d writes for %d rows, want one write per row", w.writes, n)
+	}
+}
+
+func TestClient_ContactsWriteNDJSON_Error(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		if pageNo > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{TotalRecords: 4, TotalPages: 2, PageNo: FlexInt(pageNo)},
+				Contacts: []Contact{
+					{Basefields: ContactBasefields{ContactID: 1}},
+					{Basefields: ContactBasefields{ContactID: 2}},
+				},
+			},
+		})
+	}))
+
+	var buf bytes.Buffer
+	n, err := client.ContactsWriteNDJSON(context.Background(), &buf)
+	if !errors.Is(err, ErrStatus) {
+		t.Errorf("ContactsWriteNDJSON() error = %v, want ErrStatus", err)
+	}
+	if n != 2 {
+		t.Errorf("ContactsWriteNDJSON() = %d rows, want 2", n)
+	}
+
+	out := buf.String()
+	if !strings.HasSuffix(out, "\n") {
+		t.Errorf("output ends with partial line: %q", out)
+	}
+	if lines := strings.Count(out, "\n"); lines != 2 {
+		t.Errorf("got %d lines, want 2", lines)
+	}
+}
@@ -0,0 +1,107 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidNote is returned when a note can't be created because its text is
+// empty or not valid UTF-8.
+var ErrInvalidNote = errors.New("invalid note")
+
+// Note represents a free-text note attached to a contact.
+type Note struct {
+	// NoteID is the ID of the note.
+	NoteID int `json:"note_id,omitempty"`
+	// Author is the name of the user who created the note.
+	Author string `json:"author,omitempty"`
+	// CreatedAt is the date and time the note was created.
+	CreatedAt Time `json:"created_at"`
+	// Text is the text of the note, which may span multiple lines.
+	Text string `json:"text,omitempty"`
+}
+
+type NotesList struct {
+	Pagination `json:",inline"`
+	Notes      []Note `json:"notes,omitempty"`
+}
+
+// NoteCreateRequest represents the request to create a note.
+type NoteCreateRequest struct {
+	Text string `json:"text"`
+}
+
+// ContactNotesIter returns an iterator over all notes of a contact.
+func (c *Client) ContactNotesIter(
+	ctx context.Context,
+	contactID int,
+	opts ...IterOption,
+) iter.Seq2[Note, error] {
+	return iterate(ctx, func(ctx context.Context, p PageParams) ([]Note, Pagination, error) {
+		list, err := c.ContactNotes(ctx, contactID, p)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return list.Notes, list.Pagination, nil
+	}, c.iterOptions(opts)...)
+}
+
+// ContactNotes retrieves a page of notes of a contact.
+func (c *Client) ContactNotes(
+	ctx context.Context,
+	contactID int,
+	params PageParams,
+) (*NotesList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/%d/notes", url.PathEscape(c.oid), contactID)
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[NotesList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
+
+// ContactNoteCreate appends a note to a contact and returns the created note.
+// The text must not be empty.
+func (c *Client) ContactNoteCreate(ctx context.Context, contactID int, text string) (*Note, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("%w: empty text", ErrInvalidNote)
+	}
+	if !utf8.ValidString(text) {
+		return nil, fmt.Errorf("%w: text is not valid UTF-8", ErrInvalidNote)
+	}
+
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/%d/notes", url.PathEscape(c.oid), contactID),
+		nil,
+		NoteCreateRequest{Text: text},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[Note]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
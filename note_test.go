@@ -0,0 +1,122 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestClient_ContactNotesIter(t *testing.T) {
+	pages := map[int][]Note{
+		1: {
+			{NoteID: 1, Author: "Anna", Text: "Knee injury 🤕\nNo training until May."},
+			{NoteID: 2, Author: "Beat", Text: "Pays in two instalments"},
+		},
+		2: {
+			{NoteID: 3, Author: "Anna", Text: "Recovered ✅"},
+		},
+	}
+
+	var requests []int
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fsa/v2.0/contact/test-org/contacts/42/notes" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		// The server uses a page limit of 2.
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		if got := r.URL.Query().Get("pageLimit"); pageNo == 1 && got != "100" {
+			t.Errorf("pageLimit = %q, want 100", got)
+		}
+		requests = append(requests, pageNo)
+		writeJSON(w, http.StatusOK, Response[NotesList]{
+			Success: true,
+			Data: NotesList{
+				Pagination: Pagination{
+					TotalRecords: 3,
+					TotalPages:   2,
+					PageNo:       FlexInt(pageNo),
+					PageLimit:    2,
+				},
+				Notes: pages[pageNo],
+			},
+		})
+	}))
+
+	var notes []Note
+	for note, err := range client.ContactNotesIter(context.Background(), 42) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		notes = append(notes, note)
+	}
+
+	if len(requests) != 2 || requests[0] != 1 || requests[1] != 2 {
+		t.Errorf("requested pages %v, want [1 2]", requests)
+	}
+	if len(notes) != 3 {
+		t.Fatalf("got %d notes, want 3", len(notes))
+	}
+	if notes[0].Text != pages[1][0].Text {
+		t.Errorf("Text = %q, want %q", notes[0].Text, pages[1][0].Text)
+	}
+}
+
+func TestClient_ContactNoteCreate(t *testing.T) {
+	text := "Zahlt in Raten.\nRückfragen an Anna 🙂 <admin>"
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if r.URL.Path != "/fsa/v2.0/contact/test-org/contacts/42/notes" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var body NoteCreateRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+			return
+		}
+		if body.Text != text {
+			t.Errorf("body text = %q, want %q", body.Text, text)
+		}
+
+		writeJSON(w, http.StatusOK, Response[Note]{
+			Success: true,
+			Data: Note{
+				NoteID:    7,
+				Author:    "API",
+				CreatedAt: Time{Time: createdAt},
+				Text:      body.Text,
+			},
+		})
+	}))
+
+	note, err := client.ContactNoteCreate(context.Background(), 42, text)
+	if err != nil {
+		t.Fatalf("ContactNoteCreate() error = %v", err)
+	}
+
+	if note.NoteID != 7 || note.Text != text || !note.CreatedAt.Equal(createdAt) {
+		t.Errorf("ContactNoteCreate() = %+v, want note 7 with the sent text", note)
+	}
+}
+
+func TestClient_ContactNoteCreate_Invalid(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	}))
+
+	for _, text := range []string{"", " \n\t", "invalid \xff"} {
+		_, err := client.ContactNoteCreate(context.Background(), 42, text)
+		if !errors.Is(err, ErrInvalidNote) {
+			t.Errorf("ContactNoteCreate(%q) error = %v, want ErrInvalidNote", text, err)
+		}
+	}
+}
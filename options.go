@@ -0,0 +1,106 @@
+package fairgate
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidOption is returned by [NewWithOptions] when an option is invalid or
+// conflicts with another option.
+var ErrInvalidOption = errors.New("invalid option")
+
+// OptionE configures a Client before use and reports invalid configuration.
+// Use [Option] to pass a [ClientOption] to [NewWithOptions].
+type OptionE func(*Client) error
+
+// Option adapts opt to an [OptionE]. Errors opt reports to requests, such as an
+// invalid URL passed to [WithBaseURLString], are returned by [NewWithOptions]
+// instead.
+func Option(opt ClientOption) OptionE {
+	return func(c *Client) error {
+		opt(c)
+		return nil
+	}
+}
+
+// NewWithOptions creates a Fairgate API client like [New], but validates the
+// configuration. Instead of failing at the first request or panicking, it
+// returns an error wrapping [ErrInvalidOption] if an option is invalid, such as
+// a nil base URL or HTTP client, or options conflict.
+func NewWithOptions(oid string, key *ecdsa.PublicKey, opts ...OptionE) (*Client, error) {
+	c := newClient(oid, key)
+
+	var errs []error
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidOption, err))
+		}
+	}
+	if c.err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidOption, c.err))
+	}
+	for _, err := range c.validate(key) {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrInvalidOption, err))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	c.applyDefaults()
+
+	return c, nil
+}
+
+// validate returns the problems of the configuration of c, created for key.
+func (c *Client) validate(key *ecdsa.PublicKey) []error {
+	var errs []error
+	if c.oid == "" {
+		errs = append(errs, errors.New("organisation ID is empty"))
+	}
+	if key == nil {
+		errs = append(errs, errors.New("public key is nil"))
+	}
+
+	if c.baseURL == nil {
+		errs = append(errs, errors.New("base URL is nil"))
+	} else if err := checkBaseURL(c.baseURL); err != nil {
+		errs = append(errs, err)
+	} else if err := c.checkInsecureBaseURL(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.httpClient == nil {
+		errs = append(errs, errors.New("HTTP client is nil"))
+	} else if c.pins != nil {
+		switch rt := c.httpClient.Transport.(type) {
+		case nil, *http.Transport:
+		default:
+			errs = append(errs, fmt.Errorf(
+				"pinned certificates conflict with HTTP client transport %T, pins require *http.Transport",
+				rt,
+			))
+		}
+	}
+	if c.pins != nil && len(c.pins) == 0 {
+		errs = append(
+			errs,
+			errors.New("pinned certificates are empty, no server would be accepted"),
+		)
+	}
+
+	errs = append(errs, c.transportSettings.validate(c.customHTTPClient)...)
+
+	if c.auth.parser == nil {
+		errs = append(errs, errors.New("JWT parser is nil"))
+	}
+	if c.maxRateLimitRetries < 0 {
+		errs = append(
+			errs,
+			fmt.Errorf("max rate limit retries %d is negative", c.maxRateLimitRetries),
+		)
+	}
+
+	return errs
+}
@@ -0,0 +1,172 @@
+package fairgate
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewWithOptions(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+	errCustom := errors.New("custom option failed")
+
+	tests := []struct {
+		name      string
+		noOID     bool
+		noKey     bool
+		opts      []OptionE
+		wantErrs  []string
+		wantErrIs []error
+	}{
+		{
+			name: "valid",
+			opts: []OptionE{
+				Option(WithTest()),
+				Option(WithHTTPClient(&http.Client{})),
+				Option(WithPinnedCertificates([][]byte{make([]byte, 32)})),
+			},
+		},
+		{
+			name:     "empty organisation ID",
+			noOID:    true,
+			wantErrs: []string{"organisation ID is empty"},
+		},
+		{
+			name:     "nil public key",
+			noKey:    true,
+			wantErrs: []string{"public key is nil"},
+		},
+		{
+			name:     "nil base URL",
+			opts:     []OptionE{Option(WithBaseURL(nil))},
+			wantErrs: []string{"base URL is nil"},
+		},
+		{
+			name:      "base URL without scheme",
+			opts:      []OptionE{Option(WithBaseURL(&url.URL{Host: "fsa.example.com"}))},
+			wantErrs:  []string{"scheme must be http or https"},
+			wantErrIs: []error{ErrInvalidBaseURL},
+		},
+		{
+			name:      "invalid base URL string",
+			opts:      []OptionE{Option(WithBaseURLString("https://"))},
+			wantErrs:  []string{"missing host"},
+			wantErrIs: []error{ErrInvalidBaseURL},
+		},
+		{
+			name:     "nil HTTP client",
+			opts:     []OptionE{Option(WithHTTPClient(nil))},
+			wantErrs: []string{"HTTP client is nil"},
+		},
+		{
+			name: "pins with custom transport",
+			opts: []OptionE{
+				Option(WithHTTPClient(&http.Client{Transport: roundTripperFunc(nil)})),
+				Option(WithPinnedCertificates([][]byte{make([]byte, 32)})),
+			},
+			wantErrs: []string{"pinned certificates conflict with HTTP client transport"},
+		},
+		{
+			name:     "empty pins",
+			opts:     []OptionE{Option(WithPinnedCertificates([][]byte{}))},
+			wantErrs: []string{"pinned certificates are empty"},
+		},
+		{
+			name:     "nil JWT parser",
+			opts:     []OptionE{Option(WithJWTParser(nil))},
+			wantErrs: []string{"JWT parser is nil"},
+		},
+		{
+			name:     "negative rate limit retries",
+			opts:     []OptionE{Option(WithMaxRateLimitRetries(-1))},
+			wantErrs: []string{"max rate limit retries -1 is negative"},
+		},
+		{
+			name: "custom option",
+			opts: []OptionE{func(*Client) error {
+				return errCustom
+			}},
+			wantErrs:  []string{"custom option failed"},
+			wantErrIs: []error{errCustom},
+		},
+		{
+			name: "multiple errors",
+			opts: []OptionE{
+				Option(WithBaseURL(nil)),
+				Option(WithHTTPClient(nil)),
+			},
+			wantErrs: []string{"base URL is nil", "HTTP client is nil"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oid := "test-org"
+			if tt.noOID {
+				oid = ""
+			}
+			key := publicKey
+			if tt.noKey {
+				key = nil
+			}
+
+			client, err := NewWithOptions(oid, key, tt.opts...)
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("NewWithOptions() error = %v", err)
+				}
+				if client == nil {
+					t.Fatal("NewWithOptions() returned nil client")
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("NewWithOptions() succeeded, want error")
+			}
+			if client != nil {
+				t.Errorf("NewWithOptions() returned client %v with error", client)
+			}
+			if !errors.Is(err, ErrInvalidOption) {
+				t.Errorf("NewWithOptions() error = %v, want %v", err, ErrInvalidOption)
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("NewWithOptions() error = %q, should contain %q", err, want)
+				}
+			}
+			for _, want := range tt.wantErrIs {
+				if !errors.Is(err, want) {
+					t.Errorf("NewWithOptions() error = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewWithOptions_Defaults(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+
+	client, err := NewWithOptions("test-org", publicKey, Option(WithTest()))
+	if err != nil {
+		t.Fatalf("NewWithOptions() error = %v", err)
+	}
+
+	if got, want := client.baseURL.String(), TestURL; got != want {
+		t.Errorf("baseURL = %q, want %q", got, want)
+	}
+	if client.userAgent == "" {
+		t.Error("userAgent is empty, want default")
+	}
+	if client.language != LanguageEN {
+		t.Errorf("language = %q, want %q", client.language, LanguageEN)
+	}
+}
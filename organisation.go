@@ -0,0 +1,109 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// ErrOrganisationMismatch is returned in addition to [ErrNotFound] if the token
+// was issued for another organisation than the one the client is configured
+// for, as the server hides the data of other organisations.
+var ErrOrganisationMismatch = errors.New("token issued for another organisation")
+
+// organisationMismatch returns err wrapped with [ErrOrganisationMismatch] if err
+// is a 404 Not Found and the token claims another organisation. Otherwise, err
+// is returned as is.
+func (c *Client) organisationMismatch(err error) error {
+	if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+
+	oid := c.TokenInfo().OrganisationID
+	if oid == "" || oid == c.oid {
+		return err
+	}
+
+	return fmt.Errorf(
+		"%w: token of organisation %q used for organisation %q, check the access key: %w",
+		ErrOrganisationMismatch,
+		oid,
+		c.oid,
+		err,
+	)
+}
+
+// OrganisationType is the type of an organisation affiliated with a
+// federation.
+type OrganisationType string
+
+const (
+	// OrganisationTypeClub is a club.
+	OrganisationTypeClub OrganisationType = "club"
+	// OrganisationTypeSubfederation is a sub federation, such as a regional
+	// association, which clubs can be affiliated with.
+	OrganisationTypeSubfederation OrganisationType = "subfederation"
+)
+
+// Organisation is a club or sub federation affiliated with the federation of
+// the client.
+type Organisation struct {
+	// OrganizationID is the oid of the organisation.
+	OrganizationID string `json:"organization_id,omitempty"`
+	// Organization is the name of the organisation.
+	Organization string `json:"organization,omitempty"`
+	// Type is the type of the organisation.
+	Type OrganisationType `json:"type,omitempty"`
+	// ParentOrganizationID is the oid of the federation or sub federation the
+	// organisation is affiliated with.
+	ParentOrganizationID string `json:"parent_organization_id,omitempty"`
+}
+
+// OrganisationsList represents a page of affiliated organisations.
+type OrganisationsList struct {
+	Pagination
+	Organisations []Organisation `json:"organizations,omitempty"`
+}
+
+// OrganisationsIter returns an iterator over all organisations affiliated with
+// the federation, including the clubs of sub federations. Federations only.
+func (c *Client) OrganisationsIter(
+	ctx context.Context,
+	opts ...IterOption,
+) iter.Seq2[Organisation, error] {
+	return iterate(
+		ctx,
+		func(ctx context.Context, p PageParams) ([]Organisation, Pagination, error) {
+			list, err := c.Organisations(ctx, p)
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+			return list.Organisations, list.Pagination, nil
+		},
+		c.iterOptions(opts)...)
+}
+
+// Organisations retrieves a page of the organisations affiliated with the
+// federation. Federations only.
+func (c *Client) Organisations(ctx context.Context, params PageParams) (*OrganisationsList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/organizations", url.PathEscape(c.oid))
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[OrganisationsList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
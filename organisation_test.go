@@ -0,0 +1,56 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"thde.io/fairgate/fairgatetest"
+)
+
+func TestClient_OrganisationMismatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		claims       fairgatetest.TokenClaims
+		wantMismatch bool
+	}{
+		{name: "matching organisation", claims: fairgatetest.Claims().WithOID("test-org")},
+		{
+			name:         "other organisation",
+			claims:       fairgatetest.Claims().WithOID("other-org"),
+			wantMismatch: true,
+		},
+		{name: "without organisation claim", claims: fairgatetest.Claims()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newScopedTestClient(t, tt.claims, http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, http.StatusNotFound, Response[any]{Message: "not found"})
+				},
+			))
+
+			_, err := client.Contact(context.Background(), 42)
+			if got := errors.Is(err, ErrOrganisationMismatch); got != tt.wantMismatch {
+				t.Errorf(
+					"Contact() error = %v, want ErrOrganisationMismatch: %v",
+					err,
+					tt.wantMismatch,
+				)
+			}
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("Contact() error = %v, want ErrNotFound", err)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Message != "not found" {
+				t.Errorf("Contact() error = %v, want *APIError with message", err)
+			}
+			if tt.wantMismatch && !strings.Contains(err.Error(), `"other-org"`) {
+				t.Errorf("Contact() error = %v, want organisation of token", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package fairgate
+
+import (
+	"context"
+	"iter"
+)
+
+// exportPageBuffer is the page buffer of export helpers such as
+// [Client.ContactsWriteCSV].
+const exportPageBuffer = 2
+
+// WithPageBuffer fetches up to pages pages ahead of the consumer of paginated
+// iterators such as [Client.ContactsIter], so writing to a slow sink overlaps
+// with fetching. Fetching pauses while pages pages were fetched but not yet
+// consumed completely, so no more requests are spent than the consumer keeps
+// up with. Stopping the iteration cancels the page being fetched. [WithProgress],
+// [WithSummary] and [VerifyCompleteness] still report the pages and items as
+// they are consumed. Disabled by default.
+func WithPageBuffer(pages int) IterOption {
+	return func(c *iterConfig) {
+		c.pageBuffer = max(pages, 0)
+		c.prefetching = false
+	}
+}
+
+// prefetchPageBuffer is the page buffer of [PrefetchNextPage]: the page being
+// consumed and the next one.
+const prefetchPageBuffer = 2
+
+// PrefetchNextPage fetches the next page of paginated iterators such as
+// [Client.ContactsIter] while the consumer processes the current one, so the
+// next page is usually ready when needed. At most one request is in flight,
+// and it counts against rate limits and [WithRequestBudget] like any other
+// request. Errors are yielded after the items of the preceding pages, as
+// without prefetching. Stopping the iteration cancels the prefetch. It is a
+// shorthand for [WithPageBuffer] with a buffer of the current and the next
+// page. PrefetchNextPage(false) undoes an earlier PrefetchNextPage(true),
+// restoring the buffer set before, such as by [WithPageBuffer] or the export
+// helpers.
+func PrefetchNextPage(prefetch bool) IterOption {
+	return func(c *iterConfig) {
+		switch {
+		case prefetch && !c.prefetching:
+			c.prefetching, c.prefetchPrevious = true, c.pageBuffer
+			c.pageBuffer = prefetchPageBuffer
+		case !prefetch && c.prefetching:
+			c.prefetching = false
+			c.pageBuffer = c.prefetchPrevious
+		}
+	}
+}
+
+// bufferedPage is a page passed from the fetching to the consuming goroutine of
+// [bufferPages].
+type bufferedPage[T any] struct {
+	items []T
+	// meta and size are the pagination and the number of items of the page as
+	// fetched, if fetched is set. Items beyond [MaxItems] aren't sent.
+	meta Pagination
+	size int
+	// fetched reports whether the page was fetched successfully, unlike pages
+	// skipped due to [ContinueOnError].
+	fetched bool
+	// err is the error ending the iteration, sent without items.
+	err error
+}
+
+// bufferPages returns an iterator like [iterate], but fetching up to
+// cfg.pageBuffer pages ahead in a separate goroutine. The goroutine ends before
+// the iterator returns.
+//
+// The summary, progress and completeness check of cfg are evaluated as pages
+// are consumed rather than fetched, so they reflect the items yielded to the
+// consumer.
+func bufferPages[T any](
+	ctx context.Context,
+	fetch paginatorFunc[T],
+	cfg iterConfig,
+	opts []IterOption,
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var summary IterationSummary
+		if cfg.summary != nil {
+			defer func() { *cfg.summary = summary }()
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		// tokens holds a token per page fetched but not consumed completely.
+		tokens := make(chan struct{}, cfg.pageBuffer)
+		buffer := make(chan bufferedPage[T], cfg.pageBuffer)
+		// stop tells the fetching goroutine that the consumer stopped.
+		stop := make(chan struct{})
+		done := make(chan struct{})
+		defer func() {
+			close(stop)
+			cancel()
+			<-done
+		}()
+
+		// fetched is the summary of the fetching goroutine, safe to read once
+		// buffer is closed.
+		var fetched IterationSummary
+		opts = append(
+			opts[:len(opts):len(opts)],
+			WithPageBuffer(0),
+			WithSummary(&fetched),
+			WithProgress(nil),
+			VerifyCompleteness(false),
+		)
+		go func() {
+			defer close(done)
+			defer close(buffer)
+			fillPageBuffer(ctx, fetch, opts, tokens, buffer, stop)
+		}()
+
+		var progress *progressTracker
+		if cfg.progress != nil {
+			progress = newProgressTracker(cfg.now())
+		}
+
+		expected := 0
+		for page := range buffer {
+			if page.err != nil {
+				summary.Err = page.err
+				yield(*new(T), page.err)
+				return
+			}
+			if page.fetched {
+				if summary.PagesFetched == 0 {
+					expected = page.meta.TotalRecords.Int()
+				}
+				summary.PagesFetched++
+				summary.ServerTotalRecords = page.meta.TotalRecords.Int()
+				if progress != nil {
+					p := progress.page(cfg.now(), page.size, page.meta)
+					if err := callSafely("progress", func() { cfg.progress(p) }); err != nil {
+						summary.Err = err
+						yield(*new(T), err)
+						return
+					}
+				}
+			}
+			for _, item := range page.items {
+				summary.ItemsYielded++
+				if !yield(item, nil) {
+					return
+				}
+			}
+			<-tokens
+		}
+
+		summary.Completed, summary.Limited = fetched.Completed, fetched.Limited
+		if summary.Completed && cfg.verifyCompleteness && expected > 0 &&
+			summary.ItemsYielded != expected {
+			summary.Err = &IncompleteIterationError{
+				Expected: expected,
+				Yielded:  summary.ItemsYielded,
+			}
+			yield(*new(T), summary.Err)
+		}
+	}
+}
+
+// fillPageBuffer fetches the pages using fetch and sends them to buffer,
+// taking a token for each page. It returns once all pages were sent or stop
+// was closed.
+func fillPageBuffer[T any](
+	ctx context.Context,
+	fetch paginatorFunc[T],
+	opts []IterOption,
+	tokens chan struct{},
+	buffer chan<- bufferedPage[T],
+	stop <-chan struct{},
+) {
+	send := func(page bufferedPage[T]) bool {
+		select {
+		case buffer <- page:
+			return true
+		case <-stop:
+			return false
+		}
+	}
+
+	var page *bufferedPage[T]
+	var last PageParams
+	buffered := func(ctx context.Context, params PageParams) ([]T, Pagination, error) {
+		// Retries of the same page use the token already taken.
+		if page == nil || params != last {
+			if page != nil && !send(*page) {
+				return nil, Pagination{}, context.Canceled
+			}
+			select {
+			case tokens <- struct{}{}:
+			case <-stop:
+				return nil, Pagination{}, context.Canceled
+			}
+			page, last = &bufferedPage[T]{}, params
+		}
+
+		items, meta, err := fetch(ctx, params)
+		if err == nil {
+			page.meta, page.size, page.fetched = meta, len(items), true
+		}
+		return items, meta, err
+	}
+
+	for item, err := range iterate(ctx, buffered, opts...) {
+		if err != nil {
+			if page != nil && !send(*page) {
+				return
+			}
+			send(bufferedPage[T]{err: err})
+			return
+		}
+		page.items = append(page.items, item)
+	}
+	if page != nil {
+		send(*page)
+	}
+}
@@ -0,0 +1,401 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/synctest"
+	"time"
+)
+
+// slowWriter counts the lines written, sleeping on each write. check is called
+// with the number of lines written before each write.
+type slowWriter struct {
+	lines int
+	check func(lines int)
+}
+
+func (w *slowWriter) Write(p []byte) (int, error) {
+	w.check(w.lines)
+	time.Sleep(100 * time.Microsecond)
+	w.lines += strings.Count(string(p), "\n")
+
+	return len(p), nil
+}
+
+func TestClient_ContactsWriteNDJSON_PageBuffer(t *testing.T) {
+	var fetched atomic.Int32
+	handler := contactsHandler(450)
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+
+	maxAhead := 0
+	w := &slowWriter{check: func(lines int) {
+		// Pages are consumed completely once their last line was written.
+		written := lines / 100
+		ahead := int(fetched.Load()) - written
+		if ahead > exportPageBuffer {
+			t.Errorf("fetched %d pages with %d written", fetched.Load(), written)
+		}
+		maxAhead = max(maxAhead, ahead)
+	}}
+
+	n, err := client.ContactsWriteNDJSON(context.Background(), w, NDJSONFlushEvery(1))
+	if err != nil {
+		t.Fatalf("ContactsWriteNDJSON() error = %v", err)
+	}
+	if n != 450 || fetched.Load() != 5 {
+		t.Errorf("wrote %d contacts from %d pages, want 450 from 5", n, fetched.Load())
+	}
+	if maxAhead != exportPageBuffer {
+		t.Errorf("fetched at most %d pages ahead, want %d", maxAhead, exportPageBuffer)
+	}
+}
+
+// pageFetcher returns a paginator of total items in pages of limit items,
+// failing on the page failAt.
+func pageFetcher(total, limit, failAt int, fetched *atomic.Int32) paginatorFunc[int] {
+	return func(ctx context.Context, p PageParams) ([]int, Pagination, error) {
+		fetched.Add(1)
+		if p.PageNo == failAt {
+			return nil, Pagination{}, errors.New("page failed")
+		}
+
+		var items []int
+		for i := (p.PageNo-1)*limit + 1; i <= min(p.PageNo*limit, total); i++ {
+			items = append(items, i)
+		}
+		return items, Pagination{TotalRecords: FlexInt(total), PageNo: FlexInt(p.PageNo)}, nil
+	}
+}
+
+func TestBufferPages(t *testing.T) {
+	tests := []struct {
+		name        string
+		failAt      int
+		stopAt      int
+		wantItems   int
+		wantFetched int32
+		wantErr     bool
+	}{
+		{name: "all pages", wantItems: 50, wantFetched: 5},
+		{name: "fetch fails", failAt: 3, wantItems: 20, wantFetched: 3, wantErr: true},
+		// Only the second page is fetched ahead while consuming the first one.
+		{name: "consumer stops", stopAt: 5, wantItems: 5, wantFetched: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The bubble fails if a goroutine is still blocked at the end.
+			synctest.Test(t, func(t *testing.T) {
+				var fetched atomic.Int32
+				seq := iterate(
+					context.Background(),
+					pageFetcher(50, 10, tt.failAt, &fetched),
+					WithPageLimit(10),
+					WithPageBuffer(2),
+				)
+
+				items := 0
+				var gotErr error
+				for _, err := range seq {
+					if err != nil {
+						gotErr = err
+						break
+					}
+					items++
+					// Let the fetching goroutine run ahead.
+					synctest.Wait()
+					if items == tt.stopAt {
+						break
+					}
+				}
+
+				if items != tt.wantItems || fetched.Load() != tt.wantFetched {
+					t.Errorf("got %d items from %d fetches, want %d from %d",
+						items, fetched.Load(), tt.wantItems, tt.wantFetched)
+				}
+				if (gotErr != nil) != tt.wantErr {
+					t.Errorf("got error %v, want error %v", gotErr, tt.wantErr)
+				}
+			})
+		})
+	}
+}
+
+func TestBufferPages_Summary(t *testing.T) {
+	tests := []struct {
+		name         string
+		stopAt       int
+		opts         []IterOption
+		wantSummary  IterationSummary
+		wantProgress int
+	}{
+		{
+			name:         "consumer stops",
+			stopAt:       3,
+			wantSummary:  IterationSummary{ItemsYielded: 3, PagesFetched: 2, ServerTotalRecords: 6},
+			wantProgress: 2,
+		},
+		{
+			name: "all pages",
+			opts: []IterOption{VerifyCompleteness(true)},
+			wantSummary: IterationSummary{
+				ItemsYielded:       6,
+				PagesFetched:       3,
+				ServerTotalRecords: 6,
+				Completed:          true,
+			},
+			wantProgress: 3,
+		},
+		{
+			name:   "limited",
+			opts:   []IterOption{MaxItems(3)},
+			stopAt: 0,
+			wantSummary: IterationSummary{
+				ItemsYielded:       3,
+				PagesFetched:       2,
+				ServerTotalRecords: 6,
+				Limited:            true,
+			},
+			wantProgress: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, buffer := range []int{0, 2} {
+			t.Run(fmt.Sprintf("%s with buffer %d", tt.name, buffer), func(t *testing.T) {
+				synctest.Test(t, func(t *testing.T) {
+					var summary IterationSummary
+					var progress int
+					var fetched atomic.Int32
+					opts := append([]IterOption{
+						WithPageLimit(2),
+						WithPageBuffer(buffer),
+						WithSummary(&summary),
+						WithProgress(func(Progress) { progress++ }),
+					}, tt.opts...)
+
+					items := 0
+					for _, err := range iterate(
+						context.Background(),
+						pageFetcher(6, 2, 0, &fetched),
+						opts...,
+					) {
+						if err != nil {
+							t.Fatalf("unexpected error: %v", err)
+						}
+						items++
+						// Let the fetching goroutine run ahead.
+						synctest.Wait()
+						if items == tt.stopAt {
+							break
+						}
+					}
+
+					if summary != tt.wantSummary {
+						t.Errorf("summary = %+v, want %+v", summary, tt.wantSummary)
+					}
+					if progress != tt.wantProgress {
+						t.Errorf("progress called %d times, want %d", progress, tt.wantProgress)
+					}
+				})
+			})
+		}
+	}
+}
+
+// collectedPages returns the items and the error yielded by seq, stopping after
+// stopAt items if positive, and sleeping for each item to let the prefetch run.
+func collectedPages(seq iter.Seq2[int, error], stopAt int) ([]int, error) {
+	var items []int
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+		time.Sleep(time.Millisecond)
+		if len(items) == stopAt {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+func TestPrefetchNextPage(t *testing.T) {
+	tests := []struct {
+		name         string
+		failAt       int
+		stopAt       int
+		wantCanceled int32
+	}{
+		{name: "all pages"},
+		{name: "fetch fails", failAt: 3},
+		// The prefetch of the second page is canceled once the consumer stops.
+		{name: "consumer stops", stopAt: 5, wantCanceled: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The bubble fails if a goroutine is still blocked at the end.
+			synctest.Test(t, func(t *testing.T) {
+				var fetched atomic.Int32
+				wantItems, wantErr := collectedPages(iterate(
+					context.Background(),
+					pageFetcher(50, 10, tt.failAt, &fetched),
+					WithPageLimit(10),
+				), tt.stopAt)
+
+				var inFlight, maxInFlight, canceled atomic.Int32
+				fetch := pageFetcher(50, 10, tt.failAt, &fetched)
+				items, err := collectedPages(iterate(
+					context.Background(),
+					func(ctx context.Context, p PageParams) ([]int, Pagination, error) {
+						maxInFlight.Store(max(maxInFlight.Load(), inFlight.Add(1)))
+						defer inFlight.Add(-1)
+
+						select {
+						case <-time.After(20 * time.Millisecond):
+						case <-ctx.Done():
+							canceled.Add(1)
+							return nil, Pagination{}, ctx.Err()
+						}
+						return fetch(ctx, p)
+					},
+					WithPageLimit(10),
+					PrefetchNextPage(true),
+				), tt.stopAt)
+
+				if !slices.Equal(items, wantItems) || (err == nil) != (wantErr == nil) {
+					t.Errorf("got %v, %v, want %v, %v as without prefetching",
+						items, err, wantItems, wantErr)
+				}
+				if maxInFlight.Load() != 1 {
+					t.Errorf("fetched %d pages at a time, want 1", maxInFlight.Load())
+				}
+				if got := canceled.Load(); got != tt.wantCanceled {
+					t.Errorf("canceled %d fetches, want %d", got, tt.wantCanceled)
+				}
+			})
+		})
+	}
+}
+
+func TestPrefetchNextPage_Disabled(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []IterOption
+		want int
+	}{
+		{name: "default", opts: []IterOption{PrefetchNextPage(false)}},
+		{
+			name: "after page buffer",
+			opts: []IterOption{WithPageBuffer(4), PrefetchNextPage(false)},
+			want: 4,
+		},
+		{
+			name: "after prefetch",
+			opts: []IterOption{PrefetchNextPage(true), PrefetchNextPage(false)},
+		},
+		{
+			name: "after page buffer and prefetch",
+			opts: []IterOption{
+				WithPageBuffer(4),
+				PrefetchNextPage(true),
+				PrefetchNextPage(true),
+				PrefetchNextPage(false),
+			},
+			want: 4,
+		},
+		{
+			name: "page buffer after prefetch",
+			opts: []IterOption{
+				PrefetchNextPage(true),
+				WithPageBuffer(4),
+				PrefetchNextPage(false),
+			},
+			want: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newIterConfig(tt.opts).pageBuffer; got != tt.want {
+				t.Errorf("page buffer = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_ContactsIter_PrefetchNextPage(t *testing.T) {
+	handler := contactsHandler(250)
+	started, canceled := make(chan struct{}), make(chan struct{})
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageNo") != "2" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		close(started)
+		<-r.Context().Done()
+		close(canceled)
+	}), WithRequestBudget(100, time.Hour))
+
+	for _, err := range client.ContactsIter(context.Background(), PrefetchNextPage(true)) {
+		if err != nil {
+			t.Fatalf("ContactsIter() error = %v", err)
+		}
+		// Stop while the second page is prefetched.
+		<-started
+		break
+	}
+
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("prefetch of the second page wasn't canceled")
+	}
+	if got := client.Stats().BudgetUsed; got != 2 {
+		t.Errorf("Stats().BudgetUsed = %d, want 2 including the prefetch", got)
+	}
+}
+
+func BenchmarkPrefetchNextPage(b *testing.B) {
+	// Fetching a page takes as long as consuming it.
+	fetch := func(ctx context.Context, p PageParams) ([]int, Pagination, error) {
+		time.Sleep(time.Millisecond)
+		items := make([]int, p.PageLimit)
+		return items, Pagination{TotalRecords: 1000, PageNo: FlexInt(p.PageNo)}, nil
+	}
+
+	for _, prefetch := range []bool{false, true} {
+		b.Run(fmt.Sprintf("prefetch=%v", prefetch), func(b *testing.B) {
+			for b.Loop() {
+				items := 0
+				for _, err := range iterate(
+					context.Background(),
+					fetch,
+					WithPageLimit(100),
+					PrefetchNextPage(prefetch),
+				) {
+					if err != nil {
+						b.Fatal(err)
+					}
+					// Consume a page in about a millisecond.
+					if items++; items%10 == 0 {
+						time.Sleep(100 * time.Microsecond)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package fairgate
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// pageLimitParam is the query parameter of the page limit.
+const pageLimitParam = "pageLimit"
+
+// lastNumber matches the last number in a string.
+var lastNumber = regexp.MustCompile(`(\d+)\D*$`)
+
+// pageLimits holds the maximum page limits of endpoints reported by the server.
+// It is safe for concurrent use.
+type pageLimits struct {
+	mu  sync.Mutex
+	max map[string]int
+}
+
+// get returns the maximum page limit of the endpoint path, if known.
+func (p *pageLimits) get(path string) (int, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n, ok := p.max[path]
+	return n, ok
+}
+
+// set records the maximum page limit of the endpoint path.
+func (p *pageLimits) set(path string, n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.max == nil {
+		p.max = map[string]int{}
+	}
+	p.max[path] = n
+}
+
+// clampPageLimit returns params with the page limit clamped to the maximum
+// known for the endpoint path.
+func (c *Client) clampPageLimit(path string, params url.Values) (url.Values, error) {
+	limit, err := strconv.Atoi(params.Get(pageLimitParam))
+	if err != nil {
+		return params, nil
+	}
+
+	maxLimit, ok := c.pageLimits.get(path)
+	if !ok || limit <= maxLimit {
+		return params, nil
+	}
+
+	if err := c.warn(
+		"clamped page limit %d of %s to the maximum %d", limit, path, maxLimit,
+	); err != nil {
+		return nil, err
+	}
+	clamped := maps.Clone(params)
+	clamped.Set(pageLimitParam, strconv.Itoa(maxLimit))
+
+	return clamped, nil
+}
+
+// discoverPageLimit checks whether a request failed as its page limit exceeds
+// the maximum of the endpoint. If so, it records the maximum reported by the
+// server, clamps the page limit of req, and reports whether req can be retried.
+// It fails if the warning handler panics.
+func (c *Client) discoverPageLimit(
+	req *http.Request,
+	resp *http.Response,
+	err error,
+) (bool, error) {
+	if resp == nil || resp.StatusCode != http.StatusBadRequest {
+		return false, nil
+	}
+
+	apiErr, ok := pageLimitError(err)
+	if !ok {
+		return false, nil
+	}
+	match := lastNumber.FindStringSubmatch(apiErr.Message)
+	if match == nil {
+		return false, nil
+	}
+	maxLimit, err := strconv.Atoi(match[1])
+	if err != nil || maxLimit <= 0 {
+		return false, nil
+	}
+
+	query := req.URL.Query()
+	limit, err := strconv.Atoi(query.Get(pageLimitParam))
+	if err != nil || limit <= maxLimit {
+		return false, nil
+	}
+
+	c.pageLimits.set(req.URL.Path, maxLimit)
+	if err := c.warn(
+		"server limits the page limit of %s to %d, retrying", req.URL.Path, maxLimit,
+	); err != nil {
+		return false, err
+	}
+	query.Set(pageLimitParam, strconv.Itoa(maxLimit))
+	req.URL.RawQuery = query.Encode()
+
+	return true, nil
+}
+
+// pageLimitError returns the error of the page limit parameter reported in
+// the envelope of a failed response.
+func pageLimitError(err error) (Error, bool) {
+	switch err := err.(type) {
+	case Error:
+		if err.Field == pageLimitParam {
+			return err, true
+		}
+	case interface{ Unwrap() error }:
+		return pageLimitError(err.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, err := range err.Unwrap() {
+			if apiErr, ok := pageLimitError(err); ok {
+				return apiErr, true
+			}
+		}
+	}
+
+	return Error{}, false
+}
@@ -0,0 +1,119 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// maxPageLimitHandler serves total notes, rejecting page limits above maxLimit.
+func maxPageLimitHandler(total, maxLimit int, requests *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests = append(*requests, r.URL.RawQuery)
+
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		pageLimit, _ := strconv.Atoi(r.URL.Query().Get("pageLimit"))
+		if pageLimit > maxLimit {
+			writeJSON(w, http.StatusBadRequest, Response[any]{
+				Code:    400,
+				Message: "Validation failed",
+				Errors: []Error{
+					{Field: "pageNo", Message: "ok"},
+					{
+						Field:   "pageLimit",
+						Message: "The page limit must be max " + strconv.Itoa(maxLimit),
+					},
+				},
+			})
+			return
+		}
+
+		var notes []Note
+		for i := (pageNo - 1) * pageLimit; i < min(pageNo*pageLimit, total); i++ {
+			notes = append(notes, Note{NoteID: i + 1})
+		}
+		writeJSON(w, http.StatusOK, Response[NotesList]{
+			Success: true,
+			Data: NotesList{
+				Pagination: Pagination{
+					TotalRecords: FlexInt(total),
+					TotalPages:   FlexInt((total + pageLimit - 1) / pageLimit),
+					PageNo:       FlexInt(pageNo),
+					PageLimit:    FlexInt(pageLimit),
+				},
+				Notes: notes,
+			},
+		})
+	})
+}
+
+func TestClient_DiscoverPageLimit(t *testing.T) {
+	var requests, warnings []string
+	client, _ := newTestClient(t, maxPageLimitHandler(450, 200, &requests),
+		WithWarningHandler(func(message string) {
+			warnings = append(warnings, message)
+		}),
+	)
+
+	var ids []int
+	for note, err := range client.ContactNotesIter(context.Background(), 42, WithPageLimit(500)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, note.NoteID)
+	}
+
+	if len(ids) != 450 || ids[449] != 450 {
+		t.Errorf("got %d notes, want 450", len(ids))
+	}
+	want := []string{
+		"pageLimit=500&pageNo=1",
+		"pageLimit=200&pageNo=1",
+		"pageLimit=200&pageNo=2",
+		"pageLimit=200&pageNo=3",
+	}
+	if !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	if retries := client.Stats().Retries; retries != 0 {
+		t.Errorf("Stats().Retries = %d, want 0 for page limit discovery", retries)
+	}
+
+	// Later requests are clamped without being rejected first.
+	requests, warnings = nil, nil
+	_, err := client.ContactNotes(context.Background(), 42, PageParams{PageNo: 1, PageLimit: 300})
+	if err != nil {
+		t.Fatalf("ContactNotes() error = %v", err)
+	}
+	if want := []string{"pageLimit=200&pageNo=1"}; !slices.Equal(requests, want) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("got warnings %q, want 1 warning", warnings)
+	}
+}
+
+func TestClient_DiscoverPageLimit_RetriedOnce(t *testing.T) {
+	// The server rejects the page limit even after clamping.
+	var requests []string
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		writeJSON(w, http.StatusBadRequest, Response[any]{
+			Code:   400,
+			Errors: []Error{{Field: "pageLimit", Message: "max 50"}},
+		})
+	}))
+
+	_, err := client.ContactNotes(context.Background(), 42, PageParams{PageNo: 1, PageLimit: 100})
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if want := []string{"pageLimit=100&pageNo=1", "pageLimit=50&pageNo=1"}; !slices.Equal(
+		requests,
+		want,
+	) {
+		t.Errorf("requests = %q, want %q", requests, want)
+	}
+}
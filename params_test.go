@@ -0,0 +1,148 @@
+package fairgate
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// paramsStructs lists a populated value of every struct encoded as query
+// parameters. TestParamsStructs fails if a struct is missing.
+var paramsStructs = map[string]any{
+	"PageParams":      PageParams{PageNo: 2, PageLimit: 50},
+	"DuplicateParams": DuplicateParams{PageParams: PageParams{PageNo: 1}, MinScore: 0.8},
+	"SponsorParams": SponsorParams{
+		PageParams: PageParams{PageNo: 1},
+		Season:     "2024/25",
+		Category:   SponsorCategoryDonor,
+	},
+	"ContactsFilter": ContactsFilter{IncludeArchived: true, GroupIDs: []int{4, 2}},
+	"ContactsParams": ContactsParams{
+		PageParams:     PageParams{PageNo: 1},
+		ContactsFilter: ContactsFilter{IncludeArchived: true},
+		Fields:         []ContactField{FieldBasefields, FieldCommunication},
+	},
+	"contactsSortedParams": contactsSortedParams{
+		ContactsParams: ContactsParams{PageParams: PageParams{PageNo: 1}},
+		SortBy:         "last_update",
+		SortOrder:      "desc",
+	},
+	"InvoiceParams": InvoiceParams{
+		PageParams: PageParams{PageNo: 1},
+		Year:       2024,
+		Status:     InvoiceStatusOpen,
+		ContactID:  7,
+	},
+	"invoicesSortedParams": invoicesSortedParams{
+		InvoiceParams: InvoiceParams{PageParams: PageParams{PageNo: 1}, Year: 2024},
+		SortBy:        "contact_id",
+		SortOrder:     "asc",
+	},
+	"DocumentParams": DocumentParams{
+		PageParams:   PageParams{PageNo: 1},
+		Folder:       "Protokolle",
+		UpdatedSince: QueryTimeRFC3339(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)),
+	},
+	"contactsCursorParams": contactsCursorParams{
+		PageLimit:      100,
+		SortBy:         "contact_id",
+		SortOrder:      "asc",
+		AfterContactID: 42,
+	},
+}
+
+// urlTaggedStructs returns the names of all struct types of the package with
+// fields tagged for query parameter encoding.
+func urlTaggedStructs(t *testing.T) []string {
+	t.Helper()
+
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("failed to list files: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	var names []string
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %v", name, err)
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+
+			for _, field := range st.Fields.List {
+				if field.Tag == nil {
+					continue
+				}
+				tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`"))
+				if _, ok := tag.Lookup("url"); ok {
+					names = append(names, spec.Name.Name)
+					break
+				}
+			}
+			return true
+		})
+	}
+
+	return names
+}
+
+func TestParamsStructs(t *testing.T) {
+	names := urlTaggedStructs(t)
+	if len(names) == 0 {
+		t.Fatal("found no parameter structs")
+	}
+
+	for _, name := range names {
+		if _, ok := paramsStructs[name]; !ok {
+			t.Errorf("parameter struct %s is missing in paramsStructs", name)
+		}
+	}
+
+	for name, params := range paramsStructs {
+		t.Run(name, func(t *testing.T) {
+			v, err := encodeParams("/test", params)
+			if err != nil {
+				t.Fatalf("encodeParams() error = %v", err)
+			}
+			if len(v) == 0 {
+				t.Error("encodeParams() returned no parameters for populated struct")
+			}
+
+			if _, err := encodeParams("/test", reflect.Zero(reflect.TypeOf(params)).Interface()); err != nil {
+				t.Errorf("encodeParams() of zero value error = %v", err)
+			}
+		})
+	}
+}
+
+func TestEncodeParams_Error(t *testing.T) {
+	_, err := encodeParams("/fsa/v2.0/contact/org/contacts/extended", 42)
+	if err == nil {
+		t.Fatal("encodeParams() succeeded for invalid parameters")
+	}
+
+	for _, want := range []string{"int", "/fsa/v2.0/contact/org/contacts/extended"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("encodeParams() error = %q, want it to contain %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidPhoneNumber is returned when a phone number cannot be normalized.
+var ErrInvalidPhoneNumber = errors.New("invalid phone number")
+
+// phoneRegion describes the numbering plan of a country.
+type phoneRegion struct {
+	// countryCode is the international calling code.
+	countryCode string
+	// trunkPrefix is dropped from national numbers, if set.
+	trunkPrefix string
+	// minLen and maxLen bound the length of the national significant number.
+	minLen, maxLen int
+}
+
+// phoneRegions lists the supported regions by ISO 3166-1 alpha-2 code.
+var phoneRegions = map[string]phoneRegion{
+	"CH": {countryCode: "41", trunkPrefix: "0", minLen: 9, maxLen: 9},
+	"LI": {countryCode: "423", minLen: 7, maxLen: 9},
+	"DE": {countryCode: "49", trunkPrefix: "0", minLen: 6, maxLen: 13},
+	"AT": {countryCode: "43", trunkPrefix: "0", minLen: 4, maxLen: 13},
+	"FR": {countryCode: "33", trunkPrefix: "0", minLen: 9, maxLen: 9},
+	"IT": {countryCode: "39", minLen: 6, maxLen: 11},
+}
+
+// NormalizePhoneNumber returns number in E.164 format, e.g. "+41791234567".
+// Numbers without international prefix are interpreted as national numbers of
+// defaultRegion, which is an ISO 3166-1 alpha-2 code such as "CH".
+// Spaces, dashes, dots, slashes, and parentheses are ignored.
+func NormalizePhoneNumber(number, defaultRegion string) (string, error) {
+	cleaned := strings.ReplaceAll(strings.TrimSpace(number), "(0)", "")
+	cleaned = strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\u00a0', '-', '.', '/', '(', ')':
+			return -1
+		}
+		return r
+	}, cleaned)
+
+	var digits string
+	international := true
+	switch {
+	case strings.HasPrefix(cleaned, "+"):
+		digits = cleaned[1:]
+	case strings.HasPrefix(cleaned, "00"):
+		digits = cleaned[2:]
+	default:
+		digits = cleaned
+		international = false
+	}
+
+	if digits == "" || strings.ContainsFunc(digits, func(r rune) bool {
+		return r < '0' || r > '9'
+	}) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, number)
+	}
+
+	if !international {
+		region, ok := phoneRegions[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", fmt.Errorf("%w: unsupported region %q", ErrInvalidPhoneNumber, defaultRegion)
+		}
+
+		national, ok := nationalNumber(region, digits)
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, number)
+		}
+		return "+" + region.countryCode + national, nil
+	}
+
+	for _, region := range phoneRegions {
+		rest, ok := strings.CutPrefix(digits, region.countryCode)
+		if !ok {
+			continue
+		}
+
+		national, ok := nationalNumber(region, rest)
+		if !ok {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, number)
+		}
+		return "+" + region.countryCode + national, nil
+	}
+
+	// E.164 numbers have at most 15 digits.
+	if len(digits) < 8 || len(digits) > 15 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhoneNumber, number)
+	}
+
+	return "+" + digits, nil
+}
+
+// nationalNumber strips the trunk prefix from digits and validates the length.
+func nationalNumber(region phoneRegion, digits string) (string, bool) {
+	if region.trunkPrefix != "" {
+		// The trunk prefix is commonly, but wrongly, kept in international numbers.
+		digits = strings.TrimPrefix(digits, region.trunkPrefix)
+	}
+	if len(digits) < region.minLen || len(digits) > region.maxLen {
+		return "", false
+	}
+
+	return digits, true
+}
+
+// MobileE164 returns the mobile number in E.164 format.
+// See [NormalizePhoneNumber] for details.
+func (c Communication) MobileE164(defaultRegion string) (string, error) {
+	return NormalizePhoneNumber(c.Mobile, defaultRegion)
+}
+
+// WithPhoneNormalization normalizes phone numbers of decoded responses to E.164
+// format, populating [Communication.NormalizedMobile] and
+// [Communication.NormalizedHandy2]. Numbers without international prefix are
+// interpreted as national numbers of defaultRegion, e.g. "CH".
+// Numbers which can't be normalized are left empty.
+func WithPhoneNormalization(defaultRegion string) ClientOption {
+	return func(c *Client) {
+		c.phoneRegion = defaultRegion
+	}
+}
+
+// normalizePhoneNumbers populates the normalized phone numbers of all
+// [Communication] values reachable from v.
+func normalizePhoneNumbers(v any, defaultRegion string) {
+	walk(reflect.ValueOf(v), func(c *Communication) {
+		c.NormalizedMobile, _ = NormalizePhoneNumber(c.Mobile, defaultRegion)
+		c.NormalizedHandy2, _ = NormalizePhoneNumber(c.Handy2, defaultRegion)
+	})
+}
@@ -0,0 +1,144 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  string
+		region  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "swiss mobile with spaces",
+			number: "079 123 45 67",
+			region: "CH",
+			want:   "+41791234567",
+		},
+		{name: "swiss mobile e164", number: "+41791234567", region: "CH", want: "+41791234567"},
+		{
+			name:   "swiss mobile 0041 prefix",
+			number: "0041 79 123 45 67",
+			region: "CH",
+			want:   "+41791234567",
+		},
+		{
+			name:   "swiss mobile with trunk prefix",
+			number: "+41 (0)79 123 45 67",
+			region: "CH",
+			want:   "+41791234567",
+		},
+		{
+			name:   "swiss mobile with wrong trunk",
+			number: "+41 079 123 45 67",
+			region: "CH",
+			want:   "+41791234567",
+		},
+		{
+			name:   "swiss mobile with dashes",
+			number: "079-123-45-67",
+			region: "CH",
+			want:   "+41791234567",
+		},
+		{
+			name:   "swiss mobile with dots",
+			number: "079.123.45.67",
+			region: "CH",
+			want:   "+41791234567",
+		},
+		{name: "swiss landline", number: "044 123 45 67", region: "CH", want: "+41441234567"},
+		{
+			name:   "swiss region lower case",
+			number: "079 123 45 67",
+			region: "ch",
+			want:   "+41791234567",
+		},
+		{name: "german mobile", number: "0151 23456789", region: "DE", want: "+4915123456789"},
+		{name: "german landline", number: "030 1234567", region: "DE", want: "+49301234567"},
+		{
+			name:   "german international",
+			number: "+49 (0)30 1234567",
+			region: "CH",
+			want:   "+49301234567",
+		},
+		{name: "french mobile", number: "06 12 34 56 78", region: "FR", want: "+33612345678"},
+		{
+			name:   "french international",
+			number: "+33 6 12 34 56 78",
+			region: "CH",
+			want:   "+33612345678",
+		},
+		{name: "french with 0033", number: "0033 612 345 678", region: "DE", want: "+33612345678"},
+		{name: "other country", number: "+1 415 555 2671", region: "CH", want: "+14155552671"},
+		{name: "swiss too short", number: "079 123 45 6", region: "CH", wantErr: true},
+		{name: "swiss too long", number: "079 123 45 678", region: "CH", wantErr: true},
+		{name: "french too short", number: "06 12 34 56", region: "FR", wantErr: true},
+		{name: "letters", number: "079 CALL ME", region: "CH", wantErr: true},
+		{name: "empty", number: "", region: "CH", wantErr: true},
+		{name: "only prefix", number: "+", region: "CH", wantErr: true},
+		{name: "unsupported region", number: "079 123 45 67", region: "XX", wantErr: true},
+		{name: "international too short", number: "+1 234", region: "CH", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhoneNumber(tt.number, tt.region)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizePhoneNumber() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, ErrInvalidPhoneNumber) {
+				t.Errorf("NormalizePhoneNumber() error = %v, want ErrInvalidPhoneNumber", err)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizePhoneNumber() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_WithPhoneNormalization(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Contacts: []Contact{
+					{
+						Communication: Communication{
+							Mobile: "079 123 45 67",
+							Handy2: "0041 78 765 43 21",
+						},
+					},
+					{Communication: Communication{Mobile: "not a number"}},
+				},
+			},
+		})
+	}), WithPhoneNormalization("CH"))
+
+	list, err := client.Contacts(context.Background(), PageParams{})
+	if err != nil {
+		t.Fatalf("Contacts() error = %v", err)
+	}
+
+	first := list.Contacts[0].Communication
+	if first.NormalizedMobile != "+41791234567" {
+		t.Errorf("NormalizedMobile = %q, want +41791234567", first.NormalizedMobile)
+	}
+	if first.NormalizedHandy2 != "+41787654321" {
+		t.Errorf("NormalizedHandy2 = %q, want +41787654321", first.NormalizedHandy2)
+	}
+
+	second := list.Contacts[1].Communication
+	if second.NormalizedMobile != "" {
+		t.Errorf("NormalizedMobile = %q, want empty for invalid number", second.NormalizedMobile)
+	}
+	if second.Mobile != "not a number" {
+		t.Errorf("Mobile = %q, should not be modified", second.Mobile)
+	}
+}
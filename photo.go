@@ -0,0 +1,75 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// MaxPhotoSize is the maximum size of a contact photo accepted by the API.
+const MaxPhotoSize = 5 << 20
+
+// ErrInvalidPhoto is returned by [Client.ContactPhotoUpload] for photos the API
+// would reject, such as an unsupported content type or a photo exceeding
+// [MaxPhotoSize]. No request is sent.
+var ErrInvalidPhoto = errors.New("invalid photo")
+
+// photoContentTypes are the media types of photos accepted by the API.
+var photoContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+}
+
+// ContactPhotoUpload replaces the photo of the contact, e.g. for license cards,
+// with size bytes read from r, starting at its current offset. contentType
+// must be "image/jpeg" or "image/png", and size at most [MaxPhotoSize].
+//
+// r must be seekable, so the upload can be resent when rate limited.
+func (c *Client) ContactPhotoUpload(
+	ctx context.Context,
+	contactID int,
+	r io.ReadSeeker,
+	contentType string,
+	size int64,
+) error {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !photoContentTypes[mediaType] {
+		return fmt.Errorf(
+			"contact photo upload: %w: content type %q is not image/jpeg or image/png",
+			ErrInvalidPhoto,
+			contentType,
+		)
+	}
+	if size <= 0 || size > MaxPhotoSize {
+		return fmt.Errorf(
+			"contact photo upload: %w: size %d is not between 1 and %d bytes",
+			ErrInvalidPhoto,
+			size,
+			MaxPhotoSize,
+		)
+	}
+
+	req, err := c.newEndpointWriteRequest(
+		ctx,
+		http.MethodPut,
+		fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/%d/photo", url.PathEscape(c.oid), contactID),
+		nil,
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+	if err := setReaderBody(req, r, size); err != nil {
+		return fmt.Errorf("contact photo upload: %w", err)
+	}
+	req.Header.Set("Content-Type", mediaType)
+
+	var result Response[json.RawMessage]
+	_, err = c.doJSON(req, &result)
+	return err
+}
@@ -0,0 +1,125 @@
+package fairgate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClient_ContactPhotoUpload_RetriesIdenticalBody(t *testing.T) {
+	photo := bytes.Repeat([]byte{0xff, 0xd8, 0xff, 0xe0}, 1024)
+
+	var mu sync.Mutex
+	var bodies [][]byte
+	client, _ := newTestClient(
+		t,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPut ||
+				r.URL.Path != "/fsa/v2.0/contact/test-org/contacts/7/photo" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			if got := r.Header.Get("Content-Type"); got != "image/jpeg" {
+				t.Errorf("Content-Type = %q, want image/jpeg", got)
+			}
+			if r.ContentLength != int64(len(photo)) {
+				t.Errorf("Content-Length = %d, want %d", r.ContentLength, len(photo))
+			}
+			body, _ := io.ReadAll(r.Body)
+
+			mu.Lock()
+			bodies = append(bodies, body)
+			first := len(bodies) == 1
+			mu.Unlock()
+
+			if first {
+				w.Header().Set(
+					"X-Ratelimit-Retry-After",
+					strconv.FormatInt(time.Now().Unix(), 10),
+				)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			writeJSON(w, http.StatusOK, Response[any]{Success: true})
+		}),
+	)
+
+	// Upload starting at the current offset of the reader.
+	r := bytes.NewReader(append([]byte("prefix"), photo...))
+	if _, err := r.Seek(int64(len("prefix")), io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+
+	err := client.ContactPhotoUpload(
+		context.Background(),
+		7,
+		r,
+		"image/jpeg",
+		int64(len(photo)),
+	)
+	if err != nil {
+		t.Fatalf("ContactPhotoUpload() error = %v", err)
+	}
+
+	if len(bodies) != 2 {
+		t.Fatalf("server saw %d uploads, want 2", len(bodies))
+	}
+	for i, body := range bodies {
+		if !bytes.Equal(body, photo) {
+			t.Errorf("upload %d sent %d bytes differing from the photo", i+1, len(body))
+		}
+	}
+}
+
+func TestClient_ContactPhotoUpload_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		size        int64
+		wantErr     error
+	}{
+		{name: "jpeg", contentType: "image/jpeg", size: 3},
+		{name: "png with parameter", contentType: "image/png; charset=binary", size: 3},
+		{name: "gif", contentType: "image/gif", size: 3, wantErr: ErrInvalidPhoto},
+		{name: "invalid content type", contentType: "jpeg", size: 3, wantErr: ErrInvalidPhoto},
+		{name: "empty", contentType: "image/jpeg", size: 0, wantErr: ErrInvalidPhoto},
+		{
+			name:        "too large",
+			contentType: "image/jpeg",
+			size:        MaxPhotoSize + 1,
+			wantErr:     ErrInvalidPhoto,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					requests++
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				}),
+			)
+
+			err := client.ContactPhotoUpload(
+				context.Background(),
+				1,
+				bytes.NewReader([]byte("abc")),
+				tt.contentType,
+				tt.size,
+			)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ContactPhotoUpload() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && requests != 0 {
+				t.Errorf("server saw %d requests for an invalid photo, want none", requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package fairgate
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrCertificatePinMismatch is returned when the server does not present a
+// certificate matching one of the pins of [WithPinnedCertificates].
+var ErrCertificatePinMismatch = errors.New("certificate pin mismatch")
+
+// CertificatePin returns the SHA-256 hash of the certificate's subject public
+// key info, as used by [WithPinnedCertificates].
+func CertificatePin(cert *x509.Certificate) []byte {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return sum[:]
+}
+
+// WithPinnedCertificates only accepts TLS connections where a certificate of
+// the presented chain matches one of pins, the SHA-256 hashes of subject public
+// key infos (see [CertificatePin]). Only certificates of the verified chains
+// count, so the regular certificate verification still applies and cannot be
+// skipped. A VerifyConnection callback of [WithTLSConfig] runs first. Pinning
+// applies to all requests, including authentication.
+//
+// Pinning is applied to the HTTP client after all options, so it composes with
+// [WithHTTPClient] without modifying the provided client. If the provided
+// client uses a transport other than [*http.Transport], pins cannot be checked
+// and all requests fail with [ErrCertificatePinMismatch].
+func WithPinnedCertificates(pins [][]byte) ClientOption {
+	return func(c *Client) {
+		c.pins = pins
+	}
+}
+
+// pinnedHTTPClient returns a copy of httpClient which verifies pins.
+func pinnedHTTPClient(httpClient *http.Client, pins [][]byte) *http.Client {
+	pinned := *httpClient
+
+	var transport *http.Transport
+	switch rt := httpClient.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = rt.Clone()
+	default:
+		pinned.Transport = failingTransport{
+			err: fmt.Errorf("%w: cannot pin certificates of transport %T", ErrCertificatePinMismatch, rt),
+		}
+		return &pinned
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.VerifyConnection = verifyPins(
+		pins,
+		transport.TLSClientConfig.VerifyConnection,
+	)
+	pinned.Transport = transport
+
+	return &pinned
+}
+
+// verifyPins returns a connection verification func checking the verified
+// chains against pins after calling next, if any. Unlike peer certificate
+// verification, connection verification also runs on resumed sessions.
+func verifyPins(
+	pins [][]byte,
+	next func(tls.ConnectionState) error,
+) func(tls.ConnectionState) error {
+	return func(cs tls.ConnectionState) error {
+		if next != nil {
+			if err := next(cs); err != nil {
+				return err
+			}
+		}
+
+		for _, chain := range cs.VerifiedChains {
+			for _, cert := range chain {
+				pin := CertificatePin(cert)
+				for _, want := range pins {
+					if bytes.Equal(pin, want) {
+						return nil
+					}
+				}
+			}
+		}
+
+		return fmt.Errorf(
+			"%w: none of the %d verified chains contains a pinned certificate",
+			ErrCertificatePinMismatch,
+			len(cs.VerifiedChains),
+		)
+	}
+}
+
+// failingTransport fails all requests with err.
+type failingTransport struct {
+	err error
+}
+
+// RoundTrip implements [http.RoundTripper].
+func (t failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	return nil, t.err
+}
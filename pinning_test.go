@@ -0,0 +1,197 @@
+package fairgate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// wrappedTransport is a custom [http.RoundTripper] delegating to another one.
+type wrappedTransport struct {
+	http.RoundTripper
+}
+
+func TestWithPinnedCertificates(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"POST /fsa/v1.1/auth/create/{oid}/token",
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Success: true,
+				Data: CreateTokenResponse{
+					Token:        createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+					RefreshToken: "refresh-token",
+				},
+			})
+		},
+	)
+	mux.HandleFunc(
+		"GET /fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+		func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+		},
+	)
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	knownPin := CertificatePin(server.Certificate())
+	unknownPin := sha256.Sum256([]byte("unknown"))
+
+	tests := []struct {
+		name      string
+		pins      [][]byte
+		transport http.RoundTripper
+		wantErr   bool
+	}{
+		{
+			name: "known certificate",
+			pins: [][]byte{unknownPin[:], knownPin},
+		},
+		{
+			name:    "unknown certificate",
+			pins:    [][]byte{unknownPin[:]},
+			wantErr: true,
+		},
+		{
+			name:      "unsupported transport",
+			pins:      [][]byte{knownPin},
+			transport: &wrappedTransport{server.Client().Transport},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpClient := server.Client()
+			if tt.transport != nil {
+				httpClient.Transport = tt.transport
+			}
+			originalTransport := httpClient.Transport
+
+			client := New("test-org", publicKey,
+				WithHTTPClient(httpClient),
+				WithBaseURL(mustParseURL(server.URL)),
+				WithPinnedCertificates(tt.pins),
+			)
+
+			if httpClient.Transport != originalTransport {
+				t.Error("WithPinnedCertificates modified the provided HTTP client")
+			}
+
+			err := client.TokenCreate(context.Background(), "access-key")
+			if tt.wantErr {
+				if !errors.Is(err, ErrCertificatePinMismatch) {
+					t.Errorf("TokenCreate() error = %v, want ErrCertificatePinMismatch", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("TokenCreate() error = %v", err)
+			}
+
+			if _, err := client.Contact(context.Background(), 1); err != nil {
+				t.Errorf("Contact() error = %v", err)
+			}
+		})
+	}
+}
+
+// generateTestCertificate returns a self-signed certificate unrelated to the
+// certificate of [httptest.Server].
+func generateTestCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "pinned"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	return cert
+}
+
+func TestWithPinnedCertificates_UnverifiedCertificate(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	// The server presents its regular, non-pinned leaf with the pinned
+	// certificate appended, which is not part of any verified chain.
+	pinned := generateTestCertificate(t)
+	server.TLS.Certificates[0].Certificate = append(
+		server.TLS.Certificates[0].Certificate,
+		pinned.Raw,
+	)
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+		WithPinnedCertificates([][]byte{CertificatePin(pinned)}),
+	)
+
+	err := client.TokenCreate(context.Background(), "access-key")
+	if !errors.Is(err, ErrCertificatePinMismatch) {
+		t.Errorf("TokenCreate() error = %v, want ErrCertificatePinMismatch", err)
+	}
+}
+
+func TestWithPinnedCertificates_VerifyConnection(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}))
+	t.Cleanup(server.Close)
+
+	errCustom := errors.New("custom verification")
+	var called bool
+	config := server.Client().Transport.(*http.Transport).TLSClientConfig.Clone()
+	config.VerifyConnection = func(tls.ConnectionState) error {
+		called = true
+		return errCustom
+	}
+
+	client := New("test-org", publicKey,
+		WithTLSConfig(config),
+		WithBaseURL(mustParseURL(server.URL)),
+		WithPinnedCertificates([][]byte{CertificatePin(server.Certificate())}),
+	)
+
+	err := client.TokenCreate(context.Background(), "access-key")
+	if !called {
+		t.Error("VerifyConnection of WithTLSConfig not called")
+	}
+	if !errors.Is(err, errCustom) {
+		t.Errorf("TokenCreate() error = %v, want custom verification error", err)
+	}
+}
@@ -0,0 +1,70 @@
+package fairgate
+
+import (
+	"time"
+)
+
+// Progress describes the progress of an iteration. It is reported after each
+// page using [WithProgress].
+type Progress struct {
+	// ItemsSeen is the number of items fetched so far.
+	ItemsSeen int
+	// TotalRecords is the total number of items reported by the server,
+	// or zero if unknown.
+	TotalRecords int
+	// PagesSeen is the number of pages fetched so far.
+	PagesSeen int
+	// TotalPages is the total number of pages reported by the server,
+	// or zero if unknown.
+	TotalPages int
+	// StartedAt is the time the iteration started.
+	StartedAt time.Time
+	// EstimatedCompletion is the estimated time the iteration completes,
+	// based on the average time per page so far. It is zero if unknown.
+	EstimatedCompletion time.Time
+}
+
+// Percent returns the completed percentage of pages. It reports false if the
+// total number of pages is unknown.
+func (p Progress) Percent() (float64, bool) {
+	if p.TotalPages <= 0 {
+		return 0, false
+	}
+
+	return min(100, 100*float64(p.PagesSeen)/float64(p.TotalPages)), true
+}
+
+// WithProgress calls fn with the progress of the iteration after each page.
+func WithProgress(fn func(Progress)) IterOption {
+	return func(c *iterConfig) {
+		c.progress = fn
+	}
+}
+
+// progressTracker estimates the progress of an iteration.
+type progressTracker struct {
+	progress Progress
+}
+
+// newProgressTracker returns a tracker for an iteration started at start.
+func newProgressTracker(start time.Time) *progressTracker {
+	return &progressTracker{progress: Progress{StartedAt: start}}
+}
+
+// page records a page with n items fetched at now and returns the progress.
+func (t *progressTracker) page(now time.Time, n int, meta Pagination) Progress {
+	p := &t.progress
+	p.ItemsSeen += n
+	p.PagesSeen++
+	p.TotalRecords = meta.TotalRecords.Int()
+	p.TotalPages = meta.TotalPages.Int()
+
+	p.EstimatedCompletion = time.Time{}
+	if p.TotalPages > 0 {
+		remaining := max(0, p.TotalPages-p.PagesSeen)
+		perPage := now.Sub(p.StartedAt) / time.Duration(p.PagesSeen)
+		p.EstimatedCompletion = now.Add(perPage * time.Duration(remaining))
+	}
+
+	return *p
+}
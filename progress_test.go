@@ -0,0 +1,167 @@
+package fairgate
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestProgressTracker(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	type page struct {
+		elapsed time.Duration
+		items   int
+		meta    Pagination
+	}
+	tests := []struct {
+		name  string
+		pages []page
+		want  Progress
+	}{
+		{
+			name: "first page",
+			pages: []page{
+				{10 * time.Second, 100, Pagination{TotalRecords: 350, TotalPages: 4}},
+			},
+			want: Progress{
+				ItemsSeen:           100,
+				TotalRecords:        350,
+				PagesSeen:           1,
+				TotalPages:          4,
+				StartedAt:           start,
+				EstimatedCompletion: start.Add(40 * time.Second),
+			},
+		},
+		{
+			name: "varying latency",
+			pages: []page{
+				{10 * time.Second, 100, Pagination{TotalRecords: 350, TotalPages: 4}},
+				{40 * time.Second, 100, Pagination{TotalRecords: 350, TotalPages: 4}},
+			},
+			want: Progress{
+				ItemsSeen:           200,
+				TotalRecords:        350,
+				PagesSeen:           2,
+				TotalPages:          4,
+				StartedAt:           start,
+				EstimatedCompletion: start.Add(80 * time.Second),
+			},
+		},
+		{
+			name: "completed",
+			pages: []page{
+				{10 * time.Second, 100, Pagination{TotalRecords: 150, TotalPages: 2}},
+				{15 * time.Second, 50, Pagination{TotalRecords: 150, TotalPages: 2}},
+			},
+			want: Progress{
+				ItemsSeen:           150,
+				TotalRecords:        150,
+				PagesSeen:           2,
+				TotalPages:          2,
+				StartedAt:           start,
+				EstimatedCompletion: start.Add(15 * time.Second),
+			},
+		},
+		{
+			name: "unknown total pages",
+			pages: []page{
+				{10 * time.Second, 100, Pagination{}},
+				{20 * time.Second, 100, Pagination{}},
+			},
+			want: Progress{
+				ItemsSeen: 200,
+				PagesSeen: 2,
+				StartedAt: start,
+			},
+		},
+		{
+			name: "total pages no longer reported",
+			pages: []page{
+				{10 * time.Second, 100, Pagination{TotalPages: 3}},
+				{20 * time.Second, 100, Pagination{}},
+			},
+			want: Progress{
+				ItemsSeen: 200,
+				PagesSeen: 2,
+				StartedAt: start,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newProgressTracker(start)
+
+			var got Progress
+			for _, p := range tt.pages {
+				got = tracker.page(start.Add(p.elapsed), p.items, p.meta)
+			}
+
+			if got != tt.want {
+				t.Errorf("page() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgress_Percent(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress Progress
+		want     float64
+		wantOK   bool
+	}{
+		{name: "unknown", progress: Progress{PagesSeen: 2}},
+		{name: "partial", progress: Progress{PagesSeen: 1, TotalPages: 4}, want: 25, wantOK: true},
+		{name: "done", progress: Progress{PagesSeen: 5, TotalPages: 4}, want: 100, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.progress.Percent()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Percent() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIterate_WithProgress(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	fakeClock := func(c *iterConfig) {
+		c.now = func() time.Time { return now }
+	}
+
+	fetcher := func(ctx context.Context, params PageParams) ([]string, Pagination, error) {
+		now = now.Add(time.Duration(params.PageNo) * time.Second)
+		return []string{"a", "b"}, Pagination{TotalRecords: 6, TotalPages: 3}, nil
+	}
+
+	var reports []Progress
+	for _, err := range iterate(context.Background(), fetcher, fakeClock, WithProgress(
+		func(p Progress) { reports = append(reports, p) },
+	)) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(reports) != 3 {
+		t.Fatalf("got %d progress reports, want 3", len(reports))
+	}
+
+	// Pages take 1s, 2s, and 3s: the estimate after the second page is based
+	// on an average of 1.5s per page.
+	second := reports[1]
+	if second.ItemsSeen != 4 || second.PagesSeen != 2 {
+		t.Errorf("second report = %+v, want 4 items in 2 pages", second)
+	}
+	if want := start.Add(4500 * time.Millisecond); !second.EstimatedCompletion.Equal(want) {
+		t.Errorf("EstimatedCompletion = %v, want %v", second.EstimatedCompletion, want)
+	}
+	if last := reports[2]; !last.EstimatedCompletion.Equal(start.Add(6 * time.Second)) {
+		t.Errorf("final EstimatedCompletion = %v, want completion time", last.EstimatedCompletion)
+	}
+}
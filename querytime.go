@@ -0,0 +1,81 @@
+package fairgate
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// QueryTimeFormat is the format a [QueryTime] is encoded with.
+type QueryTimeFormat int
+
+const (
+	// QueryTimeFormatRFC3339 encodes times as RFC 3339, e.g.
+	// "2024-05-01T10:00:00+02:00".
+	QueryTimeFormatRFC3339 QueryTimeFormat = iota
+	// QueryTimeFormatUnix encodes times as Unix seconds, e.g. "1714550400".
+	QueryTimeFormatUnix
+	// QueryTimeFormatDateOnly encodes the date of times in their location,
+	// e.g. "2024-05-01".
+	QueryTimeFormatDateOnly
+)
+
+// QueryTime is a time filter of query parameters, encoded in the format the
+// endpoint expects. Endpoints ignore filters they can't parse instead of
+// failing, so a wrong format silently returns unfiltered or empty results.
+// Create it with [QueryTimeRFC3339], [QueryTimeUnix] or [QueryDateOnly].
+// Zero times are omitted.
+//
+// Fields of type [time.Time] are encoded as RFC 3339 as well, unless tagged
+// with the "unix", "unixmilli" or layout options of
+// [github.com/google/go-querystring/query], e.g.
+//
+//	Since time.Time `url:"since,unix,omitempty"`
+//	Day   time.Time `url:"day,omitempty" layout:"2006-01-02"`
+type QueryTime struct {
+	Time   time.Time
+	Format QueryTimeFormat
+}
+
+// QueryTimeRFC3339 returns a time filter encoding t as RFC 3339.
+func QueryTimeRFC3339(t time.Time) QueryTime {
+	return QueryTime{Time: t, Format: QueryTimeFormatRFC3339}
+}
+
+// QueryTimeUnix returns a time filter encoding t as Unix seconds.
+func QueryTimeUnix(t time.Time) QueryTime {
+	return QueryTime{Time: t, Format: QueryTimeFormatUnix}
+}
+
+// QueryDateOnly returns a time filter encoding the date of t in its location.
+func QueryDateOnly(t time.Time) QueryTime {
+	return QueryTime{Time: t, Format: QueryTimeFormatDateOnly}
+}
+
+// IsZero reports whether the time is zero, omitting the filter.
+func (q QueryTime) IsZero() bool {
+	return q.Time.IsZero()
+}
+
+// String returns the time in its format.
+func (q QueryTime) String() string {
+	switch q.Format {
+	case QueryTimeFormatUnix:
+		return strconv.FormatInt(q.Time.Unix(), 10)
+	case QueryTimeFormatDateOnly:
+		return q.Time.Format(time.DateOnly)
+	default:
+		return q.Time.Format(time.RFC3339)
+	}
+}
+
+// EncodeValues implements the [github.com/google/go-querystring/query.Encoder]
+// interface. Zero times are omitted.
+func (q QueryTime) EncodeValues(key string, v *url.Values) error {
+	if q.IsZero() {
+		return nil
+	}
+
+	v.Set(key, q.String())
+	return nil
+}
@@ -0,0 +1,66 @@
+package fairgate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-querystring/query"
+)
+
+func TestQueryTime_Encode(t *testing.T) {
+	type params struct {
+		Since    QueryTime  `url:"since"`
+		Until    QueryTime  `url:"until,omitempty"`
+		Day      *QueryTime `url:"day,omitempty"`
+		Updated  time.Time  `url:"updated,unix,omitempty"`
+		Received time.Time  `url:"received,omitempty"     layout:"2006-01-02"`
+	}
+
+	zurich := time.FixedZone("CEST", 2*60*60)
+	at := time.Date(2024, 5, 1, 0, 30, 0, 0, zurich)
+	day := QueryDateOnly(at)
+
+	tests := []struct {
+		name   string
+		params params
+		want   string
+	}{
+		{
+			name:   "rfc3339",
+			params: params{Since: QueryTimeRFC3339(at)},
+			want:   "since=2024-05-01T00%3A30%3A00%2B02%3A00",
+		},
+		{
+			name:   "unix",
+			params: params{Since: QueryTimeUnix(at)},
+			want:   "since=1714516200",
+		},
+		{
+			name:   "date only",
+			params: params{Since: QueryDateOnly(at), Until: QueryDateOnly(at.UTC()), Day: &day},
+			want:   "day=2024-05-01&since=2024-05-01&until=2024-04-30",
+		},
+		{
+			name:   "struct tags",
+			params: params{Updated: at, Received: at},
+			want:   "received=2024-05-01&updated=1714516200",
+		},
+		{
+			name:   "zero omitted",
+			params: params{Since: QueryTimeUnix(time.Time{}), Day: &QueryTime{}},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := query.Values(tt.params)
+			if err != nil {
+				t.Fatalf("query.Values() error = %v", err)
+			}
+			if got := v.Encode(); got != tt.want {
+				t.Errorf("encoded %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
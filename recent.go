@@ -0,0 +1,126 @@
+package fairgate
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// contactsSortedParams represents the parameters for listing sorted contacts.
+type contactsSortedParams struct {
+	ContactsParams
+	SortBy    string `url:"sortBy"`
+	SortOrder string `url:"sortOrder"`
+}
+
+// ContactsRecentlyUpdatedIter returns an iterator over the contacts updated at
+// or after since, most recently updated first. The server sorts the contacts,
+// so the iteration stops at the first contact updated before since.
+//
+// If a page shows that the server ignores the sort order, a warning is
+// reported and the remaining contacts are all fetched and filtered by the
+// client, in the order returned by the server.
+//
+// If [WithContactFields] restricts the sections, the base fields are still
+// requested.
+func (c *Client) ContactsRecentlyUpdatedIter(
+	ctx context.Context,
+	since time.Time,
+	opts ...IterOption,
+) iter.Seq2[Contact, error] {
+	opts = append(opts[:len(opts):len(opts)], requireContactFields(FieldBasefields))
+	cfg := newIterConfig(opts)
+
+	return func(yield func(Contact, error) bool) {
+		sorted := true
+		var last time.Time
+		fetch := func(ctx context.Context, p PageParams) ([]Contact, Pagination, error) {
+			list, err := c.contactsSorted(ctx, contactsSortedParams{
+				ContactsParams: ContactsParams{
+					PageParams:     p,
+					ContactsFilter: cfg.contactsFilter,
+					Fields:         cfg.contactFields,
+				},
+				SortBy:    "last_update",
+				SortOrder: "desc",
+			})
+			if err != nil {
+				return nil, Pagination{}, err
+			}
+
+			if sorted && !descendingByLastUpdate(list.Contacts, last) {
+				sorted = false
+				if err := c.warn(
+					"server ignored sorting contacts by last update on page %d, filtering all contacts",
+					p.PageNo,
+				); err != nil {
+					return nil, Pagination{}, err
+				}
+			}
+			if n := len(list.Contacts); n > 0 {
+				last = list.Contacts[n-1].Basefields.LastUpdate.Time
+			}
+
+			return list.Contacts, list.Pagination, nil
+		}
+
+		for contact, err := range iterate(ctx, fetch, c.iterOptions(opts)...) {
+			if err != nil {
+				yield(Contact{}, err)
+				return
+			}
+
+			if contact.Basefields.LastUpdate.Before(since) {
+				if sorted {
+					return
+				}
+				continue
+			}
+			if !yield(contact, nil) {
+				return
+			}
+		}
+	}
+}
+
+// descendingByLastUpdate reports whether contacts are sorted by last update,
+// most recent first, and not updated after the previous page ending at last.
+func descendingByLastUpdate(contacts []Contact, last time.Time) bool {
+	for _, contact := range contacts {
+		updated := contact.Basefields.LastUpdate.Time
+		if !last.IsZero() && updated.After(last) {
+			return false
+		}
+		last = updated
+	}
+
+	return true
+}
+
+// contactsSorted retrieves a page of sorted contacts.
+func (c *Client) contactsSorted(
+	ctx context.Context,
+	params contactsSortedParams,
+) (*ContactsList, error) {
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/contacts/extended", url.PathEscape(c.oid))
+	params.ContactsFilter = params.ContactsFilter.normalized()
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[ContactsList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
@@ -0,0 +1,170 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// lastUpdateHandler serves contacts 1 to n, contact i last updated i hours
+// after base, in pages. If sorts is set, the contacts are sorted by last update,
+// most recent first, when requested. Otherwise they are ordered by ID.
+func lastUpdateHandler(base time.Time, n int, sorts bool, pages *[]string) http.Handler {
+	var mu sync.Mutex
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		mu.Lock()
+		*pages = append(*pages, q.Get("pageNo"))
+		mu.Unlock()
+
+		ids := make([]int, n)
+		for i := range ids {
+			ids[i] = i + 1
+		}
+		if sorts && q.Get("sortBy") == "last_update" && q.Get("sortOrder") == "desc" {
+			slices.Reverse(ids)
+		}
+
+		pageNo, _ := strconv.Atoi(q.Get("pageNo"))
+		pageLimit, _ := strconv.Atoi(q.Get("pageLimit"))
+		start := min((pageNo-1)*pageLimit, n)
+		end := min(start+pageLimit, n)
+
+		var contacts []Contact
+		for _, id := range ids[start:end] {
+			contacts = append(contacts, Contact{Basefields: ContactBasefields{
+				ContactID:  FlexInt(id),
+				LastUpdate: Time{base.Add(time.Duration(id) * time.Hour)},
+			}})
+		}
+
+		writeJSON(w, http.StatusOK, Response[ContactsList]{
+			Success: true,
+			Data: ContactsList{
+				Pagination: Pagination{
+					TotalRecords: FlexInt(n),
+					TotalPages:   FlexInt((n + pageLimit - 1) / pageLimit),
+					PageNo:       FlexInt(pageNo),
+					PageLimit:    FlexInt(pageLimit),
+				},
+				Contacts: contacts,
+			},
+		})
+	})
+}
+
+func TestClient_ContactsRecentlyUpdatedIter(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		sorts        bool
+		since        time.Time
+		wantIDs      []int
+		wantPages    []string
+		wantWarnings int
+	}{
+		{
+			name:      "sorted",
+			sorts:     true,
+			since:     base.Add(6 * time.Hour),
+			wantIDs:   []int{10, 9, 8, 7, 6},
+			wantPages: []string{"1", "2"},
+		},
+		{
+			name:      "sorted, none updated",
+			sorts:     true,
+			since:     base.Add(11 * time.Hour),
+			wantPages: []string{"1"},
+		},
+		{
+			name:      "sorted, all updated",
+			sorts:     true,
+			since:     base,
+			wantIDs:   []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+			wantPages: []string{"1", "2", "3", "4"},
+		},
+		{
+			name:         "sort ignored",
+			since:        base.Add(6 * time.Hour),
+			wantIDs:      []int{6, 7, 8, 9, 10},
+			wantPages:    []string{"1", "2", "3", "4"},
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var pages, warnings []string
+			client, _ := newTestClient(
+				t,
+				lastUpdateHandler(base, 10, tt.sorts, &pages),
+				WithWarningHandler(func(message string) {
+					warnings = append(warnings, message)
+				}),
+			)
+
+			var ids []int
+			for contact, err := range client.ContactsRecentlyUpdatedIter(
+				context.Background(),
+				tt.since,
+				WithPageLimit(3),
+			) {
+				if err != nil {
+					t.Fatalf("ContactsRecentlyUpdatedIter() error = %v", err)
+				}
+				ids = append(ids, contact.Basefields.ContactID.Int())
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("got contacts %v, want %v", ids, tt.wantIDs)
+			}
+			if !slices.Equal(pages, tt.wantPages) {
+				t.Errorf("requested pages %v, want %v", pages, tt.wantPages)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Fatalf("got warnings %q, want %d", warnings, tt.wantWarnings)
+			}
+			for _, warning := range warnings {
+				if !strings.Contains(warning, "ignored sorting") {
+					t.Errorf("warning %q should report the ignored sort order", warning)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ContactsRecentlyUpdatedIter_ContactFields(t *testing.T) {
+	var pages []string
+	var fields []string
+	handler := lastUpdateHandler(time.Now(), 3, true, &pages)
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = append(fields, r.URL.Query().Get("fields"))
+		handler.ServeHTTP(w, r)
+	}))
+
+	var n int
+	for _, err := range client.ContactsRecentlyUpdatedIter(
+		context.Background(),
+		time.Time{},
+		WithContactFields(FieldCommunication),
+	) {
+		if err != nil {
+			t.Fatalf("ContactsRecentlyUpdatedIter() error = %v", err)
+		}
+		n++
+	}
+
+	if n != 3 {
+		t.Errorf("ContactsRecentlyUpdatedIter() yielded %d contacts, want 3", n)
+	}
+	if len(fields) == 0 || fields[0] != "communication,basefields" {
+		t.Errorf("fields = %q, want communication,basefields", fields)
+	}
+}
@@ -0,0 +1,63 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrCrossHostRedirect is returned when the server redirects a request to
+// another host, which would leak the token to that host.
+var ErrCrossHostRedirect = errors.New("cross-host redirect refused")
+
+// ErrInsecureRedirect is returned when the server redirects an HTTPS request to
+// plain HTTP, which would send the token unencrypted.
+var ErrInsecureRedirect = errors.New("insecure redirect refused")
+
+// maxRedirects is the number of redirects followed, as by [http.Client].
+const maxRedirects = 10
+
+// redirectSafeHTTPClient returns a copy of httpClient refusing redirects to
+// another host than the one of the original request, including another port,
+// and redirects of HTTPS requests to plain HTTP. A CheckRedirect policy of
+// httpClient is applied to the remaining redirects.
+//
+// The standard library only drops the Authorization header on redirects to
+// other domains, but keeps it for subdomains and other ports.
+func redirectSafeHTTPClient(httpClient *http.Client) *http.Client {
+	safe := *httpClient
+	checkRedirect := httpClient.CheckRedirect
+	safe.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if isMergeRedirect(req, via) {
+			return http.ErrUseLastResponse
+		}
+		if origin := via[0].URL; !strings.EqualFold(req.URL.Host, origin.Host) {
+			return fmt.Errorf("%w: from %s to %s", ErrCrossHostRedirect, origin.Host, req.URL.Host)
+		}
+		if origin := via[0].URL; origin.Scheme == "https" && req.URL.Scheme != "https" {
+			return fmt.Errorf(
+				"%w: from %s to %s",
+				ErrInsecureRedirect,
+				origin.Scheme,
+				req.URL.Scheme,
+			)
+		}
+		if checkRedirect != nil {
+			var err error
+			if panicErr := callSafely("CheckRedirect", func() {
+				err = checkRedirect(req, via)
+			}); panicErr != nil {
+				return panicErr
+			}
+			return err
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		return nil
+	}
+
+	return &safe
+}
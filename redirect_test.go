@@ -0,0 +1,109 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClient_CrossHostRedirect(t *testing.T) {
+	var leaked atomic.Int32
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked.Add(1)
+		t.Errorf(
+			"other host received %s %s with Authorization %q",
+			r.Method,
+			r.URL.Path,
+			r.Header.Get("Authorization"),
+		)
+	}))
+	t.Cleanup(other.Close)
+
+	tests := []struct {
+		name   string
+		status int
+		call   func(*Client) error
+	}{
+		{
+			name:   "request",
+			status: http.StatusFound,
+			call: func(c *Client) error {
+				_, err := c.Contact(context.Background(), 1)
+				return err
+			},
+		},
+		{
+			name:   "token create",
+			status: http.StatusTemporaryRedirect,
+			call: func(c *Client) error {
+				return c.TokenCreate(context.Background(), "access-key")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					http.Redirect(w, r, other.URL+r.URL.Path, tt.status)
+				}),
+			)
+
+			err := tt.call(client)
+			if !errors.Is(err, ErrCrossHostRedirect) {
+				t.Errorf("error = %v, want %v", err, ErrCrossHostRedirect)
+			}
+			if n := leaked.Load(); n != 0 {
+				t.Errorf("other host received %d requests, want 0", n)
+			}
+		})
+	}
+}
+
+func TestClient_SameHostRedirect(t *testing.T) {
+	var redirects atomic.Int32
+	client, _ := newTestClient(
+		t,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Has("redirected") {
+				if r.Header.Get("Authorization") == "" {
+					t.Error("redirected request lacks Authorization header")
+				}
+				writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+				return
+			}
+			http.Redirect(w, r, r.URL.Path+"?redirected", http.StatusFound)
+		}),
+		WithHTTPClient(&http.Client{
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				redirects.Add(1)
+				return nil
+			},
+		}),
+	)
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+	if n := redirects.Load(); n != 1 {
+		t.Errorf("custom CheckRedirect called %d times, want 1", n)
+	}
+}
+
+func TestClient_InsecureRedirect(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://"+server.Listener.Addr().String()+r.URL.Path, http.StatusFound)
+	}))
+	t.Cleanup(server.Close)
+	client := newTestClientForServer(t, server)
+
+	_, err := client.Contact(context.Background(), 1)
+	if !errors.Is(err, ErrInsecureRedirect) {
+		t.Errorf("Contact() error = %v, want %v", err, ErrInsecureRedirect)
+	}
+}
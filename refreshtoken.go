@@ -0,0 +1,96 @@
+package fairgate
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// defaultRefreshTokenWarning is the default remaining lifetime of the refresh
+// token below which a warning is reported.
+const defaultRefreshTokenWarning = 7 * 24 * time.Hour
+
+// WithRefreshTokenWarning reports a warning to the [WarningHandler] once the
+// refresh token expires within threshold, so a new token can be created with
+// an access key before requests fail. Defaults to 7 days; zero disables the
+// warning. Only refresh tokens which are JWTs carry an expiry, see
+// [Client.RefreshTokenExpiresAt].
+func WithRefreshTokenWarning(threshold time.Duration) ClientOption {
+	return func(c *Client) {
+		c.refreshWarning = threshold
+	}
+}
+
+// RefreshTokenExpiresAt returns the expiry of the refresh token. It returns
+// false if there is no refresh token or the refresh token is opaque, i.e. not
+// a JWT with an expiry.
+func (c *Client) RefreshTokenExpiresAt() (time.Time, bool) {
+	expiresAt := c.TokenInfo().RefreshExpiresAt
+
+	return expiresAt, !expiresAt.IsZero()
+}
+
+// setRefreshToken sets the refresh token and its expiry, if it is a JWT, and
+// publishes the token information along with the current claims.
+func (ts *tokenStore) setRefreshToken(refreshToken string) {
+	if refreshToken != ts.refreshToken {
+		ts.refreshWarned = false
+	}
+	ts.refreshToken = refreshToken
+	ts.refreshExpiresAt = refreshTokenExpiry(refreshToken)
+	ts.state.setToken(ts.claim, ts.refreshExpiresAt)
+}
+
+// refreshTokenExpiry returns the expiry of a JWT refresh token. The signature
+// isn't verified, as the refresh token is only sent to the API. It returns the
+// zero time for opaque refresh tokens.
+func refreshTokenExpiry(refreshToken string) time.Time {
+	if refreshToken == "" {
+		return time.Time{}
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(refreshToken, &claims); err != nil {
+		return time.Time{}
+	}
+	if claims.ExpiresAt == nil {
+		return time.Time{}
+	}
+
+	return claims.ExpiresAt.Time
+}
+
+// checkRefreshTokenExpiry warns once per refresh token if it expires within
+// the configured threshold.
+func (c *Client) checkRefreshTokenExpiry() error {
+	if c.refreshWarning <= 0 || c.warningHandler == nil {
+		return nil
+	}
+
+	now := c.clock.serverNow()
+
+	c.auth.Lock()
+	expiresAt := c.auth.refreshExpiresAt
+	due := !expiresAt.IsZero() && !c.auth.refreshWarned &&
+		now.Add(c.refreshWarning).After(expiresAt)
+	if due {
+		c.auth.refreshWarned = true
+	}
+	c.auth.Unlock()
+
+	if !due {
+		return nil
+	}
+
+	if remaining := expiresAt.Sub(now); remaining > 0 {
+		return c.warn(
+			"refresh token expires at %s in %s, create a new token with an access key before it becomes unusable",
+			expiresAt.UTC().Format(time.RFC3339),
+			remaining.Round(time.Minute),
+		)
+	}
+	return c.warn(
+		"refresh token expired at %s, create a new token with an access key",
+		expiresAt.UTC().Format(time.RFC3339),
+	)
+}
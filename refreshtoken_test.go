@@ -0,0 +1,119 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_RefreshTokenExpiresAt(t *testing.T) {
+	// The refresh token is signed with a different key than the tokens, as
+	// its signature isn't verified.
+	privateKey, _ := generateTestKeyPair(t)
+	soon := time.Now().Add(48 * time.Hour).Truncate(time.Second)
+	later := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second)
+	expired := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		name         string
+		refreshToken string
+		opts         []ClientOption
+		wantExpiry   time.Time
+		wantOK       bool
+		wantWarnings []string
+	}{
+		{
+			name:         "jwt",
+			refreshToken: createTestToken(t, privateKey, later),
+			wantExpiry:   later,
+			wantOK:       true,
+		},
+		{
+			name:         "jwt expiring soon",
+			refreshToken: createTestToken(t, privateKey, soon),
+			wantExpiry:   soon,
+			wantOK:       true,
+			wantWarnings: []string{"refresh token expires at " + soon.UTC().Format(time.RFC3339)},
+		},
+		{
+			name:         "jwt expired",
+			refreshToken: createTestToken(t, privateKey, expired),
+			wantExpiry:   expired,
+			wantOK:       true,
+			wantWarnings: []string{"refresh token expired at"},
+		},
+		{
+			name:         "custom threshold",
+			refreshToken: createTestToken(t, privateKey, later),
+			opts:         []ClientOption{WithRefreshTokenWarning(60 * 24 * time.Hour)},
+			wantExpiry:   later,
+			wantOK:       true,
+			wantWarnings: []string{"refresh token expires at"},
+		},
+		{
+			name:         "warning disabled",
+			refreshToken: createTestToken(t, privateKey, soon),
+			opts:         []ClientOption{WithRefreshTokenWarning(0)},
+			wantExpiry:   soon,
+			wantOK:       true,
+		},
+		{
+			name:         "opaque",
+			refreshToken: "refresh-token-123",
+		},
+		{
+			name:         "jwt without expiry",
+			refreshToken: "eyJhbGciOiJub25lIn0.e30.",
+		},
+		{
+			name: "missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			opts := append([]ClientOption{
+				WithWarningHandler(func(message string) {
+					warnings = append(warnings, message)
+				}),
+			}, tt.opts...)
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+				}),
+				opts...,
+			)
+			client.auth.Lock()
+			client.auth.setRefreshToken(tt.refreshToken)
+			client.auth.Unlock()
+
+			expiresAt, ok := client.RefreshTokenExpiresAt()
+			if ok != tt.wantOK || !expiresAt.Equal(tt.wantExpiry) {
+				t.Errorf(
+					"RefreshTokenExpiresAt() = %v, %v, want %v, %v",
+					expiresAt, ok, tt.wantExpiry, tt.wantOK,
+				)
+			}
+
+			// The warning is only reported once per refresh token.
+			for range 2 {
+				if _, err := client.Contact(context.Background(), 1); err != nil {
+					t.Fatalf("Contact() error = %v", err)
+				}
+			}
+
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("got warnings %q, want %q", warnings, tt.wantWarnings)
+			}
+			for i, want := range tt.wantWarnings {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warning %q should contain %q", warnings[i], want)
+				}
+			}
+		})
+	}
+}
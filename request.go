@@ -1,7 +1,6 @@
 package fairgate
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,6 +9,8 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/google/go-querystring/query"
 )
 
 // newRequest creates a new HTTP request.
@@ -19,119 +20,536 @@ func (c *Client) newRequest(
 	params url.Values,
 	body any,
 ) (*http.Request, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if err := c.checkInsecureBaseURL(); err != nil {
+		return nil, err
+	}
+	if err := c.checkEndpoint(method, path); err != nil {
+		return nil, err
+	}
+
 	rel := &url.URL{Path: path}
 	u := c.baseURL.ResolveReference(rel)
+	params, err := c.clampPageLimit(path, params)
+	if err != nil {
+		return nil, err
+	}
 	u.RawQuery = params.Encode()
 
-	var reqBody io.Reader
+	var data []byte
+	compressed := false
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		data, err = c.jsonCodec().Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("marshal request: %w", err)
 		}
-		reqBody = bytes.NewReader(jsonData)
+
+		if c.compression && len(data) > compressionThreshold {
+			data, err = gzipBytes(data)
+			if err != nil {
+				return nil, fmt.Errorf("compress request: %w", err)
+			}
+			compressed = true
+		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
+	if data != nil {
+		setBody(req, data)
+	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept-Language", "en")
+	req.Header.Set("Accept-Language", string(c.language))
 	req.Header.Set("User-Agent", c.userAgent)
+	if c.compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if err := c.setHeaders(req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// newEndpointRequest creates a new HTTP request like newRequest for an API
+// endpoint whose path segments, such as the organisation ID, were escaped with
+// [url.PathEscape], so they may contain reserved characters such as slashes.
+func (c *Client) newEndpointRequest(
+	ctx context.Context,
+	method, path string,
+	params url.Values,
+	body any,
+) (*http.Request, error) {
+	return escapedRequest(ctx, c.newRequest, method, path, params, body)
+}
+
+// newEndpointWriteRequest is like newEndpointRequest, but creates the request
+// using newWriteRequest.
+func (c *Client) newEndpointWriteRequest(
+	ctx context.Context,
+	method, path string,
+	params url.Values,
+	body any,
+) (*http.Request, error) {
+	return escapedRequest(ctx, c.newWriteRequest, method, path, params, body)
+}
+
+// escapedRequest creates a request using newRequest for the unescaped form of
+// path, and sends path as is.
+func escapedRequest(
+	ctx context.Context,
+	newRequest func(context.Context, string, string, url.Values, any) (*http.Request, error),
+	method, path string,
+	params url.Values,
+	body any,
+) (*http.Request, error) {
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", path, err)
+	}
+
+	req, err := newRequest(ctx, method, unescaped, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawPath = path
 
 	return req, nil
 }
 
 // doJSON executes the request and decodes JSON response.
+// If v is a response envelope, its error is returned. Errors of write requests
+// carry their idempotency key, see [IdempotencyKey].
 func (c *Client) doJSON(req *http.Request, v any) (*http.Response, error) {
+	resp, err := c.doJSONUnkeyed(req, v)
+	return resp, withIdempotencyKey(req, err)
+}
+
+// doJSONUnkeyed implements doJSON without annotating errors.
+func (c *Client) doJSONUnkeyed(req *http.Request, v any) (*http.Response, error) {
+	cached := c.etags.lookup(req, v)
+	if cached != nil {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
 	resp, err := c.do(req)
 	if err != nil {
 		return resp, err
 	}
-	if resp != nil && resp.Body != nil {
-		defer resp.Body.Close()
+	defer resp.Body.Close()
+
+	if v == nil {
+		return resp, nil
+	}
+	if cached != nil && notModified(req, resp) {
+		cached.load(v)
+		return resp, nil
 	}
 
-	if v != nil {
-		err = json.NewDecoder(resp.Body).Decode(v)
+	if err := c.decodeJSON(req, resp.Body, v); err != nil {
+		return resp, err
+	}
+	if r, ok := v.(interface{ Error() error }); ok {
+		if err := r.Error(); err != nil {
+			return resp, err
+		}
 	}
 
-	return resp, err
+	c.prepareDecoded(v)
+	c.etags.store(req, resp, v)
+
+	return resp, nil
+}
+
+// prepareDecoded applies the client configuration to the decoded response v.
+func (c *Client) prepareDecoded(v any) {
+	if !c.sensitiveFields {
+		dropSensitiveFields(v)
+	}
+	if c.phoneRegion != "" {
+		normalizePhoneNumbers(v, c.phoneRegion)
+	}
+	if loc := c.location(); loc != APILocation {
+		relocateTimes(v, loc)
+	}
+}
+
+// decodeJSON decodes the response body r into v.
+func (c *Client) decodeJSON(req *http.Request, r io.Reader, v any) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if err := unmarshalResponse(c.jsonCodec(), req.URL.Path, data, v); err != nil {
+		return err
+	}
+	if c.schemas != nil {
+		if err := c.trackSchema(req.URL.Path, data); err != nil {
+			return err
+		}
+	}
+	if c.deprecations.enabled() {
+		return c.deprecations.check(req.URL.Path, data, v)
+	}
+
+	return nil
 }
 
 // do executes the request with automatic token refresh and rate limit retries.
+// Rate limited requests are retried up to the configured maximum, after which a
+// [RateLimitError] is returned. Requests exceeding the maximum page limit of
+// the endpoint are retried once with the maximum reported by the server.
 func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.dryRunRequest(req) {
+		return nil, c.renderDryRun(req)
+	}
+
+	pageLimitRetried := false
+	for attempt := 0; ; attempt++ {
+		resp, retry, err := c.attempt(req, attempt)
+		rateLimited := retry
+		if !retry && !pageLimitRetried {
+			discovered, discoverErr := c.discoverPageLimit(req, resp, err)
+			if discoverErr != nil {
+				return resp, discoverErr
+			}
+			pageLimitRetried, retry = discovered, discovered
+		}
+		if !retry {
+			return resp, err
+		}
+
+		if err := c.rewindBody(req); err != nil {
+			if rateLimited {
+				return resp, fmt.Errorf("cannot rewind body: %w, %w", err, ErrRateLimit)
+			}
+			return resp, fmt.Errorf("cannot rewind body: %w", err)
+		}
+
+		// Discovering the page limit isn't counted as retry.
+		if rateLimited {
+			c.stats.retries.Add(1)
+		}
+	}
+}
+
+// attempt sends the request once and reports whether it should be retried
+// because it was rate limited.
+func (c *Client) attempt(req *http.Request, attempt int) (*http.Response, bool, error) {
+	op := Operation{
+		Name:    OperationRequest,
+		Method:  req.Method,
+		Path:    req.URL.Path,
+		Attempt: attempt + 1,
+	}
+	if operation, ok := authOperation(req.Context()); ok {
+		op.Name = operation
+	}
+	ctx, span, err := c.startSpan(req.Context(), op)
+	if err != nil {
+		return nil, false, err
+	}
+	if ctx != req.Context() {
+		req = req.WithContext(ctx)
+	}
+	resp, retry, err := c.attemptTraced(req, attempt, span)
+
+	statusCode := 0
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	endErr := span.end(statusCode, err)
+	if endErr != nil && err == nil {
+		if !retry {
+			closeBody(resp.Body)
+		}
+		return resp, false, endErr
+	}
+
+	return resp, retry, endErr
+}
+
+// attemptTraced implements attempt, reporting to span.
+func (c *Client) attemptTraced(
+	req *http.Request,
+	attempt int,
+	span guardedSpan,
+) (*http.Response, bool, error) {
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		if (resp.StatusCode < 200 || resp.StatusCode >= 300) && !notModified(req, resp) {
+			err := c.responseError(req, resp)
+			closeBody(resp.Body)
+			return resp, false, err
+		}
+
+		resp.Body = newContextBody(req.Context(), resp.Body)
+		return resp, false, nil
+	}
+
+	closeBody(resp.Body)
+	c.stats.rateLimited.Add(1)
+
+	err = c.handleRetryAfter(resp.Header.Get("X-Ratelimit-Retry-After"))
+	if err != nil {
+		return resp, false, fmt.Errorf("too many requests: %w, %w", err, ErrRateLimit)
+	}
+	c.setRateLimitBucket(resp.Header.Get("X-Ratelimit-Bucket"))
+	if err := span.rateLimited(c.RateLimit().RetryAfter); err != nil {
+		return resp, false, err
+	}
+
+	if c.failFastOnRateLimit || attempt >= c.maxRateLimitRetries {
+		return resp, false, c.rateLimitError()
+	}
+
+	return resp, true, nil
+}
+
+// send waits for the rate limit, authenticates, and sends the request once.
+// The token is only refreshed if it is about to expire.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
 	if err := c.wait(req.Context()); err != nil {
 		return nil, err
 	}
 
-	if err := c.TokenRefresh(req.Context()); err != nil {
+	if err := c.authorize(req); err != nil {
+		return nil, err
+	}
+
+	_, auth := authOperation(req.Context())
+	if err := c.spendBudget(req.Context(), auth); err != nil {
 		return nil, err
 	}
-	c.auth.Lock()
-	req.Header.Set("Authorization", "Bearer "+c.auth.token)
-	c.auth.Unlock()
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.roundTrip(req)
 	if err != nil {
-		if resp != nil && resp.Body != nil {
-			_ = resp.Body.Close()
+		if resp != nil {
+			closeBody(resp.Body)
 		}
 
 		return nil, err
 	}
+	c.clock.observe(resp)
+	if err := c.observeSunset(resp); err != nil {
+		closeBody(resp.Body)
+		return nil, err
+	}
 
-	if resp.StatusCode == http.StatusTooManyRequests {
-		if resp.Body != nil {
-			_ = resp.Body.Close()
-		}
+	if err := decompressResponse(resp); err != nil {
+		closeBody(resp.Body)
+		return nil, err
+	}
 
-		err := c.handleRetryAfter(resp.Header.Get("X-Ratelimit-Retry-After"))
-		if err != nil {
-			return nil, fmt.Errorf("too many requests: %w, %w", err, ErrRateLimit)
-		}
+	return resp, nil
+}
 
-		if err := c.rewindBody(req); err != nil {
-			return resp, fmt.Errorf("cannot rewind body: %w, %w", err, ErrRateLimit)
-		}
+// authorize sets the Authorization header of req, refreshing the token if it
+// is about to expire, unless set by [WithRawAuthorization]. Requests to the
+// auth endpoints are sent as is, as they are sent while the token store lock
+// is held.
+func (c *Client) authorize(req *http.Request) error {
+	if _, ok := authOperation(req.Context()); ok {
+		return nil
+	}
+	if authorization, ok := rawAuthorization(req.Context()); ok {
+		req.Header.Set("Authorization", authorization)
+		return nil
+	}
 
-		return c.do(req)
+	if err := c.ensureToken(req.Context()); err != nil {
+		return err
 	}
+	if err := c.checkRefreshTokenExpiry(); err != nil {
+		return err
+	}
+	c.auth.Lock()
+	req.Header.Set("Authorization", "Bearer "+c.auth.token)
+	c.auth.Unlock()
+
+	return nil
+}
+
+// maxErrorBodySize limits how much of an error response is read for details.
+const maxErrorBodySize = 1 << 20
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if resp.Body != nil {
-			_ = resp.Body.Close()
+// responseError returns the error for the response to req with an unexpected
+// status code. Failures of the auth endpoints are classified by [authError].
+func (c *Client) responseError(req *http.Request, resp *http.Response) error {
+	if _, ok := authOperation(req.Context()); ok {
+		envelope, err := envelopeStatusError(resp)
+		if envelope != nil {
+			return authError(*envelope, err)
 		}
-		return resp, fmt.Errorf(
-			"%s: %d, %w",
-			http.StatusText(resp.StatusCode),
-			resp.StatusCode,
-			ErrStatus,
-		)
+		return err
 	}
 
-	return resp, nil
+	return c.organisationMismatch(statusError(resp))
+}
+
+// statusError returns the error for a response with an unexpected status code.
+// Error details reported in the response envelope are included.
+func statusError(resp *http.Response) error {
+	if err := mergeRedirectError(resp); err != nil {
+		return err
+	}
+
+	envelope, err := envelopeStatusError(resp)
+	if merged := mergeEnvelopeError(resp, envelope); merged != nil {
+		return merged
+	}
+	if archived := archivedEnvelopeError(resp, envelope, err); archived != nil {
+		return archived
+	}
+	return err
+}
+
+// envelopeStatusError implements statusError. It also returns the response
+// envelope, if the body contains one.
+func envelopeStatusError(resp *http.Response) (*Response[json.RawMessage], error) {
+	err := fmt.Errorf(
+		"%s: %d, %w",
+		http.StatusText(resp.StatusCode),
+		resp.StatusCode,
+		ErrStatus,
+	)
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		err = fmt.Errorf("%w: %w", ErrAuthFailed, err)
+	case http.StatusForbidden:
+		err = fmt.Errorf("%w: %w", ErrForbidden, err)
+	case http.StatusNotFound:
+		err = fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if resp.Body == nil {
+		return nil, err
+	}
+
+	path := ""
+	if resp.Request != nil {
+		path = resp.Request.URL.Path
+	}
+	var envelope Response[json.RawMessage]
+	if decodeResponse(path, io.LimitReader(resp.Body, maxErrorBodySize), &envelope) != nil {
+		return nil, err
+	}
+
+	// The status code already reports the failure if there are no details.
+	hasDetails := envelope.Message != "" || len(envelope.Errors) > 0
+	if apiErr := envelope.Error(); apiErr != nil && hasDetails {
+		err = fmt.Errorf("%w: %w", err, apiErr)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusConflict:
+		return &envelope, newConflictError(err, envelope)
+	case http.StatusForbidden:
+		return &envelope, forbiddenError(err, envelope)
+	}
+
+	return &envelope, err
 }
 
 // wait checks if the client is currently rate-limited.
 // If so, it blocks until the reset time or until the context is canceled.
 func (c *Client) wait(ctx context.Context) error {
-	c.retryAftertMU.Lock()
-	waitUntil := c.retryAfter
-	c.retryAftertMU.Unlock()
+	waitUntil := c.RateLimit().RetryAfter
 
 	if time.Now().After(waitUntil) {
 		return nil
 	}
+	if c.failFastOnRateLimit {
+		return c.rateLimitError()
+	}
+
+	_, span, err := c.startSpan(ctx, Operation{Name: OperationRateLimitWait})
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	defer func() {
+		c.stats.rateLimitWait.Add(int64(time.Since(start)))
+	}()
 
 	select {
 	case <-ctx.Done():
-		return ctx.Err()
+		return span.end(0, ctx.Err())
 	case <-time.After(time.Until(waitUntil)):
-		return nil
+		return span.end(0, nil)
+	}
+}
+
+// RateLimit describes the rate limiting state of the client.
+type RateLimit struct {
+	// RetryAfter is the time until which requests are delayed.
+	// It is zero if the client has not been rate limited yet.
+	RetryAfter time.Time
+}
+
+// Limited reports whether requests made at t are delayed.
+func (r RateLimit) Limited(t time.Time) bool {
+	return t.Before(r.RetryAfter)
+}
+
+// RateLimitError is returned when a request is still rate limited after all
+// retries, or right away using [WithFailFastOnRateLimit]. It matches
+// [ErrRateLimit] using [errors.Is].
+type RateLimitError struct {
+	// RetryAt is the time after which requests are accepted again.
+	RetryAt time.Time
+	// Bucket is the rate limit bucket reported by the server, if any.
+	Bucket string
+}
+
+// Error implements the error interface.
+func (e *RateLimitError) Error() string {
+	msg := fmt.Sprintf("%s: retry at %s", ErrRateLimit, e.RetryAt.Format(time.RFC3339))
+	if e.Bucket != "" {
+		msg += fmt.Sprintf(" (bucket %s)", e.Bucket)
 	}
+	return msg
+}
+
+// Is reports whether target is [ErrRateLimit].
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimit
+}
+
+// rateLimitError returns the error for the current rate limiting state.
+func (c *Client) rateLimitError() *RateLimitError {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+
+	return &RateLimitError{RetryAt: c.state.retryAfter, Bucket: c.state.retryBucket}
+}
+
+// setRateLimitBucket records the rate limit bucket reported by the server.
+func (c *Client) setRateLimitBucket(bucket string) {
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
+
+	c.state.retryBucket = bucket
+}
+
+// RateLimit returns the current rate limiting state of the client.
+func (c *Client) RateLimit() RateLimit {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+
+	return RateLimit{RetryAfter: c.state.retryAfter}
 }
 
 // handleRetryAfter updates the client's retry-after timestamp based on the
@@ -148,11 +566,11 @@ func (c *Client) handleRetryAfter(header string) error {
 
 	t := time.Unix(ts, 0)
 
-	c.retryAftertMU.Lock()
-	defer c.retryAftertMU.Unlock()
+	c.state.mu.Lock()
+	defer c.state.mu.Unlock()
 
-	if t.After(c.retryAfter) {
-		c.retryAfter = t
+	if t.After(c.state.retryAfter) {
+		c.state.retryAfter = t
 	}
 
 	return nil
@@ -176,6 +594,22 @@ func (c *Client) rewindBody(req *http.Request) error {
 		return err
 	}
 
+	// Bodies of known length, such as the ones of setBody, recompute the
+	// Content-Length, so a retry can't send a truncated or padded body.
+	if b, ok := freshBody.(interface{ Len() int }); ok {
+		req.ContentLength = int64(b.Len())
+	}
+
 	req.Body = freshBody
 	return nil
 }
+
+// encodeParams encodes the query parameters of a request to path.
+func encodeParams(path string, params any) (url.Values, error) {
+	v, err := query.Values(params)
+	if err != nil {
+		return nil, fmt.Errorf("encode %T parameters for %s: %w", params, path, err)
+	}
+
+	return v, nil
+}
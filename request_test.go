@@ -3,10 +3,12 @@ package fairgate
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -81,7 +83,7 @@ func TestClient_handleRetryAfter_UpdatesTime(t *testing.T) {
 
 	// Set initial retry time to a past time
 	pastTime := time.Now().Add(-1 * time.Hour)
-	c.retryAfter = pastTime
+	c.state.retryAfter = pastTime
 
 	// Update with a future time
 	futureTimestamp := time.Now().Add(1 * time.Hour).Unix()
@@ -90,14 +92,14 @@ func TestClient_handleRetryAfter_UpdatesTime(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if c.retryAfter.Before(pastTime) || c.retryAfter.Equal(pastTime) {
-		t.Errorf("retryAfter should be updated to future time, got %v", c.retryAfter)
+	if c.state.retryAfter.Before(pastTime) || c.state.retryAfter.Equal(pastTime) {
+		t.Errorf("retryAfter should be updated to future time, got %v", c.state.retryAfter)
 	}
 
 	// Verify the exact time
 	expectedTime := time.Unix(futureTimestamp, 0)
-	if !c.retryAfter.Equal(expectedTime) {
-		t.Errorf("retryAfter = %v, want %v", c.retryAfter, expectedTime)
+	if !c.state.retryAfter.Equal(expectedTime) {
+		t.Errorf("retryAfter = %v, want %v", c.state.retryAfter, expectedTime)
 	}
 }
 
@@ -106,7 +108,7 @@ func TestClient_handleRetryAfter_OnlyUpdatesIfLater(t *testing.T) {
 
 	// Set initial retry time to a future time
 	futureTime := time.Now().Add(2 * time.Hour)
-	c.retryAfter = futureTime
+	c.state.retryAfter = futureTime
 
 	// Try to update with an earlier time
 	earlierTimestamp := time.Now().Add(1 * time.Hour).Unix()
@@ -116,10 +118,10 @@ func TestClient_handleRetryAfter_OnlyUpdatesIfLater(t *testing.T) {
 	}
 
 	// Retry time should not have changed
-	if !c.retryAfter.Equal(futureTime) {
+	if !c.state.retryAfter.Equal(futureTime) {
 		t.Errorf(
 			"retryAfter should not be updated to earlier time, got %v, want %v",
-			c.retryAfter,
+			c.state.retryAfter,
 			futureTime,
 		)
 	}
@@ -129,7 +131,7 @@ func TestClient_wait_NoWaiting(t *testing.T) {
 	c := &Client{}
 
 	// Set retry time to the past
-	c.retryAfter = time.Now().Add(-1 * time.Second)
+	c.state.retryAfter = time.Now().Add(-1 * time.Second)
 
 	start := time.Now()
 	err := c.wait(context.Background())
@@ -150,7 +152,7 @@ func TestClient_wait_WaitsUntilTime(t *testing.T) {
 
 	// Set retry time to 200ms in the future
 	waitDuration := 200 * time.Millisecond
-	c.retryAfter = time.Now().Add(waitDuration)
+	c.state.retryAfter = time.Now().Add(waitDuration)
 
 	start := time.Now()
 	err := c.wait(context.Background())
@@ -178,7 +180,7 @@ func TestClient_wait_ContextCancellation(t *testing.T) {
 	c := &Client{}
 
 	// Set retry time far in the future
-	c.retryAfter = time.Now().Add(10 * time.Second)
+	c.state.retryAfter = time.Now().Add(10 * time.Second)
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -206,7 +208,7 @@ func TestClient_wait_ContextTimeout(t *testing.T) {
 	c := &Client{}
 
 	// Set retry time far in the future
-	c.retryAfter = time.Now().Add(10 * time.Second)
+	c.state.retryAfter = time.Now().Add(10 * time.Second)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 	defer cancel()
@@ -351,14 +353,14 @@ func TestClient_handleRetryAfter_ConcurrentAccess(t *testing.T) {
 	}
 
 	// Just verify no panic occurred and retryAfter is set
-	if c.retryAfter.IsZero() {
+	if c.state.retryAfter.IsZero() {
 		t.Error("retryAfter should be set after concurrent updates")
 	}
 }
 
 func TestClient_wait_ConcurrentAccess(t *testing.T) {
 	c := &Client{}
-	c.retryAfter = time.Now().Add(100 * time.Millisecond)
+	c.state.retryAfter = time.Now().Add(100 * time.Millisecond)
 
 	// Multiple goroutines waiting concurrently
 	done := make(chan bool)
@@ -377,3 +379,137 @@ func TestClient_wait_ConcurrentAccess(t *testing.T) {
 		<-done
 	}
 }
+
+func TestClient_do_RateLimitRetriesBounded(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []ClientOption
+		wantAttempts int64
+	}{
+		{name: "default", wantAttempts: defaultMaxRateLimitRetries + 1},
+		{name: "custom", opts: []ClientOption{WithMaxRateLimitRetries(2)}, wantAttempts: 3},
+		{name: "disabled", opts: []ClientOption{WithMaxRateLimitRetries(0)}, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryAt := time.Now().Truncate(time.Second)
+
+			var attempts, authRequests atomic.Int64
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					if strings.Contains(r.URL.Path, "/auth/") {
+						authRequests.Add(1)
+					}
+					attempts.Add(1)
+
+					// The retry-after has already passed, so retries don't wait.
+					w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(retryAt.Unix(), 10))
+					w.WriteHeader(http.StatusTooManyRequests)
+				}),
+				tt.opts...,
+			)
+
+			_, err := client.Contact(context.Background(), 1)
+			if !errors.Is(err, ErrRateLimit) {
+				t.Fatalf("Contact() error = %v, want ErrRateLimit", err)
+			}
+
+			var rlErr *RateLimitError
+			if !errors.As(err, &rlErr) {
+				t.Fatalf("Contact() error = %T, want *RateLimitError", err)
+			}
+			if !rlErr.RetryAt.Equal(retryAt) {
+				t.Errorf("RetryAt = %v, want %v", rlErr.RetryAt, retryAt)
+			}
+
+			if got := attempts.Load(); got != tt.wantAttempts {
+				t.Errorf("server saw %d attempts, want %d", got, tt.wantAttempts)
+			}
+			if got := authRequests.Load(); got != 0 {
+				t.Errorf("server saw %d auth requests, want none", got)
+			}
+		})
+	}
+}
+
+func TestClient_WithFailFastOnRateLimit(t *testing.T) {
+	retryAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	var requests atomic.Int64
+	client, _ := newTestClient(
+		t,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) == 1 {
+				w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(retryAt.Unix(), 10))
+				w.Header().Set("X-Ratelimit-Bucket", "contacts")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+		}),
+		WithFailFastOnRateLimit(),
+	)
+
+	// Both the rate limited request and the following one fail right away.
+	for i := range 2 {
+		start := time.Now()
+		_, err := client.Contact(context.Background(), 1)
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("request %d took %v, want no waiting", i, elapsed)
+		}
+
+		var rlErr *RateLimitError
+		if !errors.As(err, &rlErr) || !errors.Is(err, ErrRateLimit) {
+			t.Fatalf("request %d: error = %v, want RateLimitError", i, err)
+		}
+		if !rlErr.RetryAt.Equal(retryAt) {
+			t.Errorf("request %d: RetryAt = %v, want %v", i, rlErr.RetryAt, retryAt)
+		}
+		if rlErr.Bucket != "contacts" {
+			t.Errorf("request %d: Bucket = %q, want %q", i, rlErr.Bucket, "contacts")
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+
+	// Let the rate limit window pass.
+	client.state.mu.Lock()
+	client.state.retryAfter = time.Now().Add(-time.Second)
+	client.state.mu.Unlock()
+
+	start := time.Now()
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() after window error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("request after window took %v, want no waiting", elapsed)
+	}
+}
+
+func TestClient_EscapesOrganisationID(t *testing.T) {
+	var gotPath string
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}))
+	client.oid = "org/1"
+
+	if _, err := client.Contact(t.Context(), 42); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+	if want := "/fsa/v2.0/contact/org%2F1/contacts/42/extended"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+
+	// Paths passed to Do are unescaped, so a literal percent sign is escaped.
+	if _, err := Do[Contact](t.Context(), client, http.MethodGet, "/custom/100%", nil, nil); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if want := "/custom/100%25"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+}
@@ -1,8 +1,11 @@
 package fairgate
 
 import (
-	"errors"
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Response represents a response from the Fairgate API.
@@ -14,21 +17,99 @@ type Response[T any] struct {
 	Errors  []Error `json:"errors,omitempty"`
 }
 
-// Error returns an error if the response is not successful.
+// UnmarshalJSON decodes the response, accepting the code as JSON number or
+// string, depending on the API version.
+// Errors are decoded as reported by API version 2.0.
+func (r *Response[T]) UnmarshalJSON(data []byte) error {
+	return r.unmarshalEnvelope(standardJSONCodec{}, data, envelopeV2{})
+}
+
+// unmarshalEnvelope implements [envelopeDecoder].
+func (r *Response[T]) unmarshalEnvelope(codec JSONCodec, data []byte, e envelope) error {
+	var raw struct {
+		Code    FlexInt         `json:"code"`
+		Success bool            `json:"success"`
+		Message string          `json:"message"`
+		Data    json.RawMessage `json:"data"`
+		Errors  json.RawMessage `json:"errors,omitempty"`
+	}
+	if err := unmarshalJSON(codec, data, &raw); err != nil {
+		return err
+	}
+
+	r.Code = int(raw.Code)
+	r.Success = raw.Success
+	r.Message = raw.Message
+	r.Errors = nil
+	if len(raw.Errors) > 0 && string(raw.Errors) != "null" {
+		errs, err := e.decodeErrors(raw.Errors)
+		if err != nil {
+			return fmt.Errorf("decode errors: %w", err)
+		}
+		r.Errors = errs
+	}
+	if len(raw.Data) == 0 {
+		return nil
+	}
+
+	// Failed responses of API version 1.1 report empty data as [], which
+	// must not hide the errors.
+	if err := unmarshalJSON(codec, raw.Data, &r.Data); err != nil && r.Success {
+		return err
+	}
+
+	return nil
+}
+
+// Error returns an [*APIError] if the response is not successful.
+// A failed response without message or error details still results in an error.
 func (r Response[T]) Error() error {
 	if r.Success {
 		return nil
 	}
 
-	errs := []error{}
-	if r.Message != "" {
-		errs = append(errs, errors.New(r.Message))
+	return &APIError{Code: r.Code, Message: r.Message, Errors: r.Errors}
+}
+
+// APIError is a failure reported in the body of an API response. It is
+// returned wrapped with technical details, such as the HTTP status, so use
+// [errors.As] to extract it, e.g. to show Message to users.
+type APIError struct {
+	// Code is the code reported by the API. It doesn't depend on the language.
+	Code int
+	// Message is the message reported by the API, verbatim. It is localized
+	// according to [WithLanguage].
+	Message string
+	// Errors are the field errors reported by the API.
+	Errors []Error
+}
+
+// Error implements the error interface. It lists the message and field errors
+// on separate lines.
+func (e *APIError) Error() string {
+	if e.Message == "" && len(e.Errors) == 0 {
+		return fmt.Sprintf("request failed (code %d) with no error details", e.Code)
+	}
+
+	lines := make([]string, 0, len(e.Errors)+1)
+	if e.Message != "" {
+		lines = append(lines, e.Message)
 	}
-	for _, e := range r.Errors {
-		errs = append(errs, error(e))
+	for _, fieldErr := range e.Errors {
+		lines = append(lines, fieldErr.Error())
 	}
 
-	return errors.Join(errs...)
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the field errors, so they can be extracted using [errors.As].
+func (e *APIError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, fieldErr := range e.Errors {
+		errs = append(errs, fieldErr)
+	}
+
+	return errs
 }
 
 // Error represents an error from the API.
@@ -39,15 +120,55 @@ type Error struct {
 
 // Error implements the error interface.
 func (e Error) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+
 	return fmt.Sprintf("%s: %s", e.Field, e.Message)
 }
 
 // Pagination represents pagination information from the API.
 type Pagination struct {
-	TotalRecords int `json:"totalRecords,omitempty"`
-	TotalPages   int `json:"totalPages,omitempty"`
-	PageNo       int `json:"pageNo,omitempty"`
-	PageLimit    int `json:"pageLimit,omitempty"`
+	TotalRecords FlexInt `json:"totalRecords,omitempty"`
+	TotalPages   FlexInt `json:"totalPages,omitempty"`
+	PageNo       FlexInt `json:"pageNo,omitempty"`
+	PageLimit    FlexInt `json:"pageLimit,omitempty"`
+}
+
+// FlexInt is an int the API encodes as JSON number or string, depending on
+// the endpoint and backend version. It is encoded as JSON number.
+type FlexInt int
+
+// Int returns n as int.
+func (n FlexInt) Int() int {
+	return int(n)
+}
+
+// UnmarshalJSON decodes a JSON number or a string containing an integer.
+// An empty string decodes to 0.
+func (n *FlexInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	if s, ok := bytes.CutPrefix(data, []byte(`"`)); ok {
+		data, ok = bytes.CutSuffix(s, []byte(`"`))
+		if !ok {
+			return fmt.Errorf("invalid integer %s", s)
+		}
+		if len(data) == 0 {
+			*n = 0
+			return nil
+		}
+	}
+
+	v, err := strconv.Atoi(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid integer %q: %w", data, err)
+	}
+
+	*n = FlexInt(v)
+	return nil
 }
 
 // PageParams represents pagination parameters for API requests.
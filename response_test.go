@@ -0,0 +1,243 @@
+package fairgate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResponse_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		resp    Response[any]
+		wantErr string
+	}{
+		{
+			name: "success",
+			resp: Response[any]{Code: 200, Success: true},
+		},
+		{
+			name:    "failure without details",
+			resp:    Response[any]{Code: 500},
+			wantErr: "request failed (code 500) with no error details",
+		},
+		{
+			name:    "failure with message",
+			resp:    Response[any]{Code: 400, Message: "invalid request"},
+			wantErr: "invalid request",
+		},
+		{
+			name: "failure with errors",
+			resp: Response[any]{
+				Code:   400,
+				Errors: []Error{{Field: "email", Message: "is invalid"}},
+			},
+			wantErr: "email: is invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.resp.Error()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Error() = %v, want nil", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("Error() = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_doJSON_UnsuccessfulResponse(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Response[any]{Code: 500})
+	}))
+
+	_, err := client.Contact(context.Background(), 1)
+	if err == nil || !strings.Contains(err.Error(), "no error details") {
+		t.Errorf("Contact() error = %v, want error without details", err)
+	}
+}
+
+func TestAPIError_LocalizedMessage(t *testing.T) {
+	const message = "L'adresse e-mail « jean@example » n'est pas valide."
+
+	tests := []struct {
+		name   string
+		status int
+		wantIs error
+	}{
+		{name: "bad request", status: http.StatusBadRequest, wantIs: ErrStatus},
+		{name: "forbidden", status: http.StatusForbidden, wantIs: ErrForbidden},
+		{name: "unsuccessful envelope", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var language string
+			client, _ := newTestClient(
+				t,
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					language = r.Header.Get("Accept-Language")
+					writeJSON(w, tt.status, Response[any]{
+						Code:    4711,
+						Message: message,
+						Errors:  []Error{{Field: "primary_email", Message: "n'est pas valide"}},
+					})
+				}),
+				WithLanguage(LanguageFR),
+			)
+
+			_, err := client.Contact(context.Background(), 1)
+			if language != "fr" {
+				t.Errorf("Accept-Language = %q, want fr", language)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Contact() error = %v, want *APIError", err)
+			}
+			if apiErr.Message != message || apiErr.Code != 4711 {
+				t.Errorf("APIError = %+v, want message %q with code 4711", apiErr, message)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("Contact() error = %v, want %v", err, tt.wantIs)
+			}
+
+			var fieldErr Error
+			if !errors.As(err, &fieldErr) || fieldErr.Field != "primary_email" {
+				t.Errorf("Contact() error = %v, want field error", err)
+			}
+		})
+	}
+}
+
+func TestClient_Contacts_PaginationTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture string
+	}{
+		{name: "numbers", fixture: "pagination_numbers.json"},
+		{name: "strings", fixture: "pagination_strings.json"},
+		{name: "mixed", fixture: "pagination_mixed.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, fixtureHandler(t, tt.fixture))
+
+			list, err := client.Contacts(context.Background(), PageParams{PageNo: 1})
+			if err != nil {
+				t.Fatalf("Contacts() error = %v", err)
+			}
+
+			want := Pagination{TotalRecords: 3, TotalPages: 2, PageNo: 1, PageLimit: 2}
+			if list.Pagination != want {
+				t.Errorf("Pagination = %+v, want %+v", list.Pagination, want)
+			}
+			if len(list.Contacts) != 2 || list.Contacts[1].Basefields.FirstName != "Beat" {
+				t.Errorf("Contacts = %+v, want Anna and Beat", list.Contacts)
+			}
+		})
+	}
+}
+
+func TestResponse_UnmarshalJSON_Code(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		wantCode int
+		wantErr  bool
+	}{
+		{name: "number", data: `{"code": 404}`, wantCode: 404},
+		{name: "string", data: `{"code": "404"}`, wantCode: 404},
+		{name: "empty string", data: `{"code": ""}`, wantCode: 0},
+		{name: "null", data: `{"code": null}`, wantCode: 0},
+		{name: "missing", data: `{}`, wantCode: 0},
+		{name: "not a number", data: `{"code": "not found"}`, wantErr: true},
+		{name: "fraction", data: `{"code": 404.5}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp Response[json.RawMessage]
+			err := json.Unmarshal([]byte(tt.data), &resp)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if resp.Code != tt.wantCode {
+				t.Errorf("Code = %d, want %d", resp.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestFlexInt(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    FlexInt
+		wantErr bool
+	}{
+		{name: "number", data: `42`, want: 42},
+		{name: "string", data: `"42"`, want: 42},
+		{name: "negative string", data: `"-7"`, want: -7},
+		{name: "empty string", data: `""`},
+		{name: "null", data: `null`},
+		{name: "invalid string", data: `"4x"`, wantErr: true},
+		{name: "float", data: `4.2`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got FlexInt
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %d, want %d", tt.data, got, tt.want)
+			}
+		})
+	}
+
+	b, err := json.Marshal(FlexInt(42))
+	if err != nil || string(b) != "42" {
+		t.Errorf("Marshal() = %s, %v, want 42", b, err)
+	}
+}
+
+func TestClient_Contact_IDTypes(t *testing.T) {
+	for _, fixture := range []string{"contact_ids_numbers.json", "contact_ids_strings.json"} {
+		t.Run(fixture, func(t *testing.T) {
+			client, _ := newTestClient(t, fixtureHandler(t, fixture))
+
+			resp, err := client.Contact(context.Background(), 12345)
+			if err != nil {
+				t.Fatalf("Contact() error = %v", err)
+			}
+
+			if got := resp.Data.Basefields.ContactID.Int(); got != 12345 {
+				t.Errorf("ContactID = %d, want 12345", got)
+			}
+			fed := resp.Data.FederationData
+			if fed == nil || fed.FederationContactID == nil || *fed.FederationContactID != 777 {
+				t.Errorf("FederationData = %+v, want federation contact ID 777", fed)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package fairgate
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxSchemaEndpoints limits the number of endpoints tracked by
+	// [WithSchemaTracking].
+	maxSchemaEndpoints = 256
+	// maxSchemaChanges limits the number of distinct schema changes reported
+	// per endpoint.
+	maxSchemaChanges = 16
+)
+
+// WithSchemaTracking detects changes of the response schema of endpoints, e.g.
+// after a backend deployment altering fields without a new API version. The
+// key paths of the data of the first response of each endpoint are recorded,
+// and each distinct set of key paths differing from it is reported once to the
+// warning handler, listing the added and removed keys. [Stats.SchemaChanges]
+// counts the changes.
+//
+// Key paths of objects in arrays are merged, so e.g. a contact with a
+// communication object adds the path "contacts[].communication.mobile".
+func WithSchemaTracking() ClientOption {
+	return func(c *Client) {
+		c.schemas = &schemaTracker{endpoints: map[string]*endpointSchema{}}
+	}
+}
+
+// schemaTracker records the schemas of endpoints. It is safe for concurrent
+// use.
+type schemaTracker struct {
+	mu        sync.Mutex
+	endpoints map[string]*endpointSchema
+}
+
+// endpointSchema is the schema first seen for an endpoint and the
+// fingerprints of the changes reported since.
+type endpointSchema struct {
+	fingerprint [sha256.Size]byte
+	keys        []string
+	reported    map[[sha256.Size]byte]bool
+}
+
+// schemaChange describes the key paths added and removed from the schema of
+// an endpoint.
+type schemaChange struct {
+	endpoint       string
+	added, removed []string
+}
+
+// observe records the schema of the response envelope data returned by
+// endpoint and returns the change to the first schema of the endpoint, if
+// not yet reported.
+func (s *schemaTracker) observe(endpoint string, data []byte) *schemaChange {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if json.Unmarshal(data, &envelope) != nil || len(envelope.Data) == 0 {
+		return nil
+	}
+	var v any
+	if json.Unmarshal(envelope.Data, &v) != nil {
+		return nil
+	}
+
+	set := map[string]bool{}
+	collectKeyPaths(v, "", set)
+	keys := slices.Sorted(maps.Keys(set))
+	fingerprint := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schema, ok := s.endpoints[endpoint]
+	if !ok {
+		if len(s.endpoints) < maxSchemaEndpoints {
+			s.endpoints[endpoint] = &endpointSchema{
+				fingerprint: fingerprint,
+				keys:        keys,
+				reported:    map[[sha256.Size]byte]bool{},
+			}
+		}
+		return nil
+	}
+	if fingerprint == schema.fingerprint || schema.reported[fingerprint] ||
+		len(schema.reported) >= maxSchemaChanges {
+		return nil
+	}
+	schema.reported[fingerprint] = true
+
+	change := &schemaChange{endpoint: endpoint}
+	for _, key := range keys {
+		if _, found := slices.BinarySearch(schema.keys, key); !found {
+			change.added = append(change.added, key)
+		}
+	}
+	for _, key := range schema.keys {
+		if !set[key] {
+			change.removed = append(change.removed, key)
+		}
+	}
+
+	return change
+}
+
+// collectKeyPaths adds the key paths of the objects reachable from v to set.
+func collectKeyPaths(v any, prefix string, set map[string]bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			set[path] = true
+			collectKeyPaths(value, path, set)
+		}
+	case []any:
+		for _, item := range v {
+			collectKeyPaths(item, prefix+"[]", set)
+		}
+	}
+}
+
+// schemaEndpoint returns path with the organisation ID and numeric IDs
+// replaced by placeholders, so all contacts share a single endpoint.
+func (c *Client) schemaEndpoint(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment == c.oid {
+			segments[i] = "{oid}"
+		} else if _, err := strconv.Atoi(segment); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// trackSchema reports a change of the schema of the response data returned by
+// path to the warning handler.
+func (c *Client) trackSchema(path string, data []byte) error {
+	change := c.schemas.observe(c.schemaEndpoint(path), data)
+	if change == nil {
+		return nil
+	}
+
+	c.stats.schemaChanges.Add(1)
+	return c.warn(
+		"response schema of %s changed: added %s, removed %s",
+		change.endpoint,
+		formatKeyPaths(change.added),
+		formatKeyPaths(change.removed),
+	)
+}
+
+// formatKeyPaths formats a list of key paths for a warning.
+func formatKeyPaths(keys []string) string {
+	if len(keys) == 0 {
+		return "none"
+	}
+
+	return fmt.Sprintf("[%s]", strings.Join(keys, ", "))
+}
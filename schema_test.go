@@ -0,0 +1,97 @@
+package fairgate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestWithSchemaTracking(t *testing.T) {
+	pages := map[string]string{
+		"1": `[{"basefields":{"contact_id":1},"communication":{"mobile":"079 123 45 67"}}]`,
+		"2": `[{"basefields":{"contact_id":2,"birthdate":"2010-01-01"}}]`,
+		"3": `[{"basefields":{"contact_id":3,"birthdate":"2011-01-01"}}]`,
+	}
+
+	var warnings []string
+	client, _ := newTestClient(
+		t,
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pageNo := r.URL.Query().Get("pageNo")
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"success":true,"data":{"totalRecords":3,"totalPages":3,` +
+				`"pageNo":` + pageNo + `,"pageLimit":1,"contacts":` + pages[pageNo] + `}}`))
+		}),
+		WithSchemaTracking(),
+		WithWarningHandler(func(message string) {
+			warnings = append(warnings, message)
+		}),
+	)
+
+	n := 0
+	for _, err := range client.ContactsIter(context.Background(), WithPageLimit(1)) {
+		if err != nil {
+			t.Fatalf("ContactsIter() error = %v", err)
+		}
+		n++
+	}
+	if n != 3 {
+		t.Fatalf("iterated %d contacts, want 3", n)
+	}
+
+	want := []string{
+		"response schema of /fsa/v2.0/contact/{oid}/contacts/extended changed: " +
+			"added [contacts[].basefields.birthdate], " +
+			"removed [contacts[].communication, contacts[].communication.mobile]",
+	}
+	if !slices.Equal(warnings, want) {
+		t.Errorf("warnings = %q, want %q", warnings, want)
+	}
+	if got := client.Stats().SchemaChanges; got != 1 {
+		t.Errorf("Stats().SchemaChanges = %d, want 1", got)
+	}
+}
+
+func TestClient_schemaEndpoint(t *testing.T) {
+	client := New("org-1", nil)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/fsa/v2.0/contact/org-1/contacts/extended", "/fsa/v2.0/contact/{oid}/contacts/extended"},
+		{
+			"/fsa/v2.0/contact/org-1/contacts/42/extended",
+			"/fsa/v2.0/contact/{oid}/contacts/{id}/extended",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := client.schemaEndpoint(tt.path); got != tt.want {
+			t.Errorf("schemaEndpoint(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestSchemaTracker_Bounded(t *testing.T) {
+	tracker := &schemaTracker{endpoints: map[string]*endpointSchema{}}
+	for i := range maxSchemaEndpoints + 10 {
+		tracker.observe(fmt.Sprintf("/endpoint/%d", i), []byte(`{"data":{"a":1}}`))
+	}
+	if got := len(tracker.endpoints); got != maxSchemaEndpoints {
+		t.Errorf("tracked %d endpoints, want %d", got, maxSchemaEndpoints)
+	}
+
+	reports := 0
+	for i := range maxSchemaChanges + 10 {
+		data := fmt.Appendf(nil, `{"data":{"key%d":1}}`, i)
+		if tracker.observe("/endpoint/0", data) != nil {
+			reports++
+		}
+	}
+	if reports != maxSchemaChanges {
+		t.Errorf("reported %d changes, want %d", reports, maxSchemaChanges)
+	}
+}
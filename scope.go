@@ -0,0 +1,69 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Scopes granted by tokens.
+const (
+	ScopeReadContacts  = "read_contacts"
+	ScopeWriteContacts = "write_contacts"
+	ScopeReadFinance   = "read_finance"
+)
+
+// ErrMissingScope is returned by clients using [WithScopeChecks] for requests
+// the token lacks the scope for.
+var ErrMissingScope = errors.New("missing scope")
+
+// WithScopeChecks fails requests modifying data with [ErrMissingScope] before
+// sending them, if the token obviously lacks the required scope. Requests are
+// sent as usual if no token was created yet or it carries no scopes claim.
+func WithScopeChecks() ClientOption {
+	return func(c *Client) {
+		c.scopeChecks = true
+	}
+}
+
+// Scopes returns the scopes granted by the current token. It returns nil if no
+// token was created yet or the token carries no scopes claim.
+func (c *Client) Scopes() []string {
+	return c.TokenInfo().Scopes
+}
+
+// HasScope reports whether the current token grants scope.
+func (c *Client) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes(), scope)
+}
+
+// checkScope returns an error if the token lacks the scope required to modify
+// data using path. Unknown scopes are not checked.
+func (c *Client) checkScope(method, path string) error {
+	if !c.scopeChecks {
+		return nil
+	}
+
+	scope := writeScope(path)
+	if scope == "" {
+		return nil
+	}
+
+	scopes := c.Scopes()
+	if scopes == nil || slices.Contains(scopes, scope) {
+		return nil
+	}
+
+	return fmt.Errorf("%w %q for %s %s", ErrMissingScope, scope, method, path)
+}
+
+// writeScope returns the scope required to modify data using path, or "" if
+// unknown.
+func writeScope(path string) string {
+	if strings.HasPrefix(path, "/fsa/v2.0/contact/") {
+		return ScopeWriteContacts
+	}
+
+	return ""
+}
@@ -0,0 +1,132 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"thde.io/fairgate/fairgatetest"
+)
+
+// newScopedTestClient returns a client talking to a test server backed by
+// handler, authenticated with a token carrying claims.
+func newScopedTestClient(
+	t *testing.T,
+	claims fairgatetest.TokenClaims,
+	handler http.Handler,
+	opts ...ClientOption,
+) *Client {
+	t.Helper()
+
+	privateKey, publicKey := generateTestKeyPair(t)
+	_, server := newTestClient(t, handler)
+	client := New("test-org", publicKey, append([]ClientOption{
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+	}, opts...)...)
+
+	token, err := fairgatetest.SignToken(privateKey, claims, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	if err := client.auth.updateToken(CreateTokenResponse{Token: token}); err != nil {
+		t.Fatalf("failed to set up token: %v", err)
+	}
+
+	return client
+}
+
+func TestClient_Scopes(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims fairgatetest.TokenClaims
+		want   []string
+	}{
+		{
+			name:   "with scopes",
+			claims: fairgatetest.Claims().WithScopes(ScopeReadContacts, ScopeWriteContacts),
+			want:   []string{ScopeReadContacts, ScopeWriteContacts},
+		},
+		{
+			name:   "without scopes claim",
+			claims: fairgatetest.Claims(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newScopedTestClient(t, tt.claims, http.NotFoundHandler())
+
+			if got := client.Scopes(); !slices.Equal(got, tt.want) {
+				t.Errorf("Scopes() = %v, want %v", got, tt.want)
+			}
+			for _, scope := range []string{ScopeReadContacts, ScopeWriteContacts, ScopeReadFinance} {
+				if got, want := client.HasScope(scope), slices.Contains(tt.want, scope); got != want {
+					t.Errorf("HasScope(%q) = %v, want %v", scope, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("without token", func(t *testing.T) {
+		_, publicKey := generateTestKeyPair(t)
+		if got := New("test-org", publicKey).Scopes(); got != nil {
+			t.Errorf("Scopes() = %v, want nil", got)
+		}
+	})
+}
+
+func TestWithScopeChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		claims   fairgatetest.TokenClaims
+		opts     []ClientOption
+		wantSent bool
+	}{
+		{
+			name:   "missing scope",
+			claims: fairgatetest.Claims().WithScopes(ScopeReadContacts),
+			opts:   []ClientOption{WithScopeChecks()},
+		},
+		{
+			name:     "granted scope",
+			claims:   fairgatetest.Claims().WithScopes(ScopeWriteContacts),
+			opts:     []ClientOption{WithScopeChecks()},
+			wantSent: true,
+		},
+		{
+			name:     "without scopes claim",
+			claims:   fairgatetest.Claims(),
+			opts:     []ClientOption{WithScopeChecks()},
+			wantSent: true,
+		},
+		{
+			name:     "checks disabled",
+			claims:   fairgatetest.Claims().WithScopes(ScopeReadContacts),
+			wantSent: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent := false
+			client := newScopedTestClient(t, tt.claims, http.HandlerFunc(
+				func(w http.ResponseWriter, r *http.Request) {
+					sent = true
+					writeJSON(w, http.StatusOK, Response[any]{Success: true})
+				},
+			), tt.opts...)
+
+			err := client.ContactUpdate(context.Background(), 42, ContactUpdate{})
+			if sent != tt.wantSent {
+				t.Errorf("sent = %v, want %v", sent, tt.wantSent)
+			}
+			if got, want := errors.Is(err, ErrMissingScope), !tt.wantSent; got != want {
+				t.Errorf("ContactUpdate() error = %v, want ErrMissingScope: %v", err, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package fairgate
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client talking to a test server backed by handler.
+// The client is pre-authenticated with a long-lived token so requests don't
+// hit the auth endpoints.
+func newTestClient(
+	t *testing.T,
+	handler http.Handler,
+	opts ...ClientOption,
+) (*Client, *httptest.Server) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return newTestClientForServer(t, server, opts...), server
+}
+
+// newTestClientForServer returns a pre-authenticated client talking to server.
+func newTestClientForServer(t *testing.T, server *httptest.Server, opts ...ClientOption) *Client {
+	t.Helper()
+
+	privateKey, publicKey := generateTestKeyPair(t)
+
+	opts = append([]ClientOption{
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+	}, opts...)
+	client := New("test-org", publicKey, opts...)
+
+	err := client.auth.updateToken(CreateTokenResponse{
+		Token:        createTestToken(t, privateKey, time.Now().Add(1*time.Hour)),
+		RefreshToken: "refresh-token-123",
+	})
+	if err != nil {
+		t.Fatalf("failed to set up token: %v", err)
+	}
+
+	return client
+}
+
+// writeJSON writes v as JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,126 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrInvalidSeason is returned when a season is not in the "2024/25" format.
+var ErrInvalidSeason = errors.New("invalid season")
+
+// SponsorCategory defines the category of a sponsor.
+type SponsorCategory string
+
+const (
+	SponsorCategorySponsor SponsorCategory = "sponsor"
+	SponsorCategoryDonor   SponsorCategory = "donor"
+	SponsorCategoryPatron  SponsorCategory = "patron"
+)
+
+// Sponsor represents the pledge of a sponsor or donor for a season.
+type Sponsor struct {
+	// ContactID is the ID of the sponsoring contact.
+	ContactID int `json:"contact_id,omitempty"`
+	// Category is the category of the sponsor.
+	Category SponsorCategory `json:"category,omitempty"`
+	// PledgedAmount is the pledged amount in minor units of Currency,
+	// e.g. 15000 for CHF 150.00.
+	PledgedAmount int64 `json:"pledged_amount,omitempty"`
+	// Currency is the ISO 4217 currency code, e.g. "CHF".
+	Currency string `json:"currency,omitempty"`
+	// Season is the season of the pledge, e.g. "2024/25".
+	Season string `json:"season,omitempty"`
+	// ContactRef contains the base fields of the sponsoring contact, if included.
+	ContactRef *ContactBasefields `json:"contact,omitempty"`
+}
+
+type SponsorsList struct {
+	Pagination `json:",inline"`
+	Sponsors   []Sponsor `json:"sponsors,omitempty"`
+}
+
+// SponsorParams represents the parameters for listing sponsors.
+type SponsorParams struct {
+	PageParams
+	// Season only returns pledges of this season, e.g. "2024/25".
+	Season string `url:"season,omitempty"`
+	// Category only returns sponsors of this category.
+	Category SponsorCategory `url:"category,omitempty"`
+}
+
+// ParseSeason parses a season such as "2024/25" and returns its first year.
+// The second year must directly follow the first one.
+func ParseSeason(season string) (int, error) {
+	if len(season) != 7 || season[4] != '/' {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSeason, season)
+	}
+
+	start, err := strconv.ParseUint(season[:4], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSeason, season)
+	}
+	end, err := strconv.ParseUint(season[5:], 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidSeason, season)
+	}
+	if end != (start+1)%100 {
+		return 0, fmt.Errorf("%w: %q does not span consecutive years", ErrInvalidSeason, season)
+	}
+
+	return int(start), nil
+}
+
+// FormatSeason returns the season starting in year, e.g. "2024/25" for 2024.
+func FormatSeason(year int) string {
+	return fmt.Sprintf("%04d/%02d", year, (year+1)%100)
+}
+
+// SponsorsIter returns an iterator over all sponsors matching params.
+// An invalid season fails with [ErrInvalidSeason] before any request is sent.
+func (c *Client) SponsorsIter(
+	ctx context.Context,
+	params SponsorParams,
+	opts ...IterOption,
+) iter.Seq2[Sponsor, error] {
+	return iterate(ctx, func(ctx context.Context, p PageParams) ([]Sponsor, Pagination, error) {
+		params.PageParams = p
+
+		list, err := c.Sponsors(ctx, params)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+		return list.Sponsors, list.Pagination, nil
+	}, c.iterOptions(opts)...)
+}
+
+// Sponsors retrieves a page of sponsors matching params.
+func (c *Client) Sponsors(ctx context.Context, params SponsorParams) (*SponsorsList, error) {
+	if params.Season != "" {
+		if _, err := ParseSeason(params.Season); err != nil {
+			return nil, err
+		}
+	}
+
+	path := fmt.Sprintf("/fsa/v2.0/contact/%s/sponsors", url.PathEscape(c.oid))
+	v, err := encodeParams(path, params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newEndpointRequest(ctx, http.MethodGet, path, v, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Response[SponsorsList]
+	if _, err := c.doJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Data, nil
+}
@@ -0,0 +1,107 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestParseSeason(t *testing.T) {
+	tests := []struct {
+		season  string
+		want    int
+		wantErr bool
+	}{
+		{season: "2024/25", want: 2024},
+		{season: "1999/00", want: 1999},
+		{season: "2024/26", wantErr: true},
+		{season: "2024-25", wantErr: true},
+		{season: "2024/2025", wantErr: true},
+		{season: "24/25", wantErr: true},
+		{season: "+024/25", wantErr: true},
+		{season: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.season, func(t *testing.T) {
+			got, err := ParseSeason(tt.season)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSeason) {
+					t.Errorf("ParseSeason() error = %v, want ErrInvalidSeason", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSeason() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSeason() = %d, want %d", got, tt.want)
+			}
+			if s := FormatSeason(got); s != tt.season {
+				t.Errorf("FormatSeason() = %q, want %q", s, tt.season)
+			}
+		})
+	}
+}
+
+func TestClient_SponsorsIter(t *testing.T) {
+	pages := map[string]http.Handler{
+		"1": fixtureHandler(t, "sponsors_page1.json"),
+		"2": fixtureHandler(t, "sponsors_page2.json"),
+	}
+
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fsa/v2.0/contact/test-org/sponsors" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if got := q.Get("season"); got != "2024/25" {
+			t.Errorf("season = %q, want 2024/25", got)
+		}
+		if got := q.Get("category"); got != "donor" {
+			t.Errorf("category = %q, want donor", got)
+		}
+
+		pages[q.Get("pageNo")].ServeHTTP(w, r)
+	}))
+
+	params := SponsorParams{Season: "2024/25", Category: SponsorCategoryDonor}
+	var sponsors []Sponsor
+	for sponsor, err := range client.SponsorsIter(context.Background(), params) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sponsors = append(sponsors, sponsor)
+	}
+
+	if len(sponsors) != 3 {
+		t.Fatalf("got %d sponsors, want 3", len(sponsors))
+	}
+	if ref := sponsors[0].ContactRef; ref == nil || ref.CompanyName != "Bäckerei Muster AG" {
+		t.Errorf("ContactRef = %+v, want company Bäckerei Muster AG", ref)
+	}
+	if sponsors[1].ContactRef != nil {
+		t.Errorf("ContactRef = %+v, want nil", sponsors[1].ContactRef)
+	}
+	// Amounts beyond the float64 precision are decoded exactly.
+	if got := sponsors[1].PledgedAmount; got != 9007199254740993 {
+		t.Errorf("PledgedAmount = %d, want 9007199254740993", got)
+	}
+	if got := sponsors[2]; got.PledgedAmount != 5005 || got.Currency != "EUR" {
+		t.Errorf("sponsor = %+v, want 5005 EUR", got)
+	}
+}
+
+func TestClient_SponsorsIter_InvalidSeason(t *testing.T) {
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s", r.URL)
+	}))
+
+	params := SponsorParams{Season: "2024/2025"}
+	for _, err := range client.SponsorsIter(context.Background(), params) {
+		if !errors.Is(err, ErrInvalidSeason) {
+			t.Errorf("error = %v, want ErrInvalidSeason", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package fairgate
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// TokenInfo describes the current token of a client.
+type TokenInfo struct {
+	// ExpiresAt is the expiry of the token. It is zero if no token was created
+	// yet.
+	ExpiresAt time.Time
+	// RefreshExpiresAt is the expiry of the refresh token. It is zero if there
+	// is no refresh token or it is opaque, see [Client.RefreshTokenExpiresAt].
+	RefreshExpiresAt time.Time
+	// Scopes are the scopes granted by the token, see [Client.Scopes].
+	Scopes []string
+	// OrganisationID is the organisation the token was issued for, if claimed.
+	OrganisationID string
+}
+
+// ClientSnapshot is a copy of the observable state of a client, see
+// [Client.Snapshot]. Changing it doesn't affect the client.
+type ClientSnapshot struct {
+	// Token describes the current token.
+	Token TokenInfo
+	// RateLimit is the rate limiting state, see [Client.RateLimit].
+	RateLimit RateLimit
+	// Stats are the request counters, see [Client.Stats].
+	Stats Stats
+	// Deprecations are the endpoints announced as deprecated, see
+	// [Client.Deprecations].
+	Deprecations map[string]DeprecationInfo
+}
+
+// clientState holds the observable state of a client behind a single lock, so
+// it can be read coherently without waiting for token requests holding the
+// token store lock. It is safe for concurrent use.
+type clientState struct {
+	mu sync.RWMutex
+
+	token        TokenInfo
+	retryAfter   time.Time
+	retryBucket  string
+	deprecations map[string]DeprecationInfo
+}
+
+// Snapshot returns a copy of the token, rate limiting, counter and deprecation
+// state of the client, read under a single lock, so the rate limiting state,
+// the token and the deprecations are consistent with each other. Counters are
+// incremented without the lock and may be ahead of the other state. It is safe
+// to call concurrently with requests, e.g. from a monitoring goroutine.
+func (c *Client) Snapshot() ClientSnapshot {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+
+	return ClientSnapshot{
+		Token:        c.state.tokenInfo(),
+		RateLimit:    RateLimit{RetryAfter: c.state.retryAfter},
+		Stats:        c.Stats(),
+		Deprecations: maps.Clone(c.state.deprecations),
+	}
+}
+
+// TokenInfo describes the current token of the client.
+func (c *Client) TokenInfo() TokenInfo {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+
+	return c.state.tokenInfo()
+}
+
+// tokenInfo returns a copy of the token state. The caller must hold the lock.
+func (s *clientState) tokenInfo() TokenInfo {
+	info := s.token
+	info.Scopes = slices.Clone(info.Scopes)
+
+	return info
+}
+
+// setToken publishes the claims of the current token and the expiry of the
+// refresh token. A nil state is ignored.
+func (s *clientState) setToken(claim *jwtClaim, refreshExpiresAt time.Time) {
+	if s == nil {
+		return
+	}
+
+	info := TokenInfo{RefreshExpiresAt: refreshExpiresAt}
+	if claim != nil {
+		if claim.ExpiresAt != nil {
+			info.ExpiresAt = claim.ExpiresAt.Time
+		}
+		info.Scopes = slices.Clone(claim.Scopes)
+		info.OrganisationID = claim.OID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.token = info
+}
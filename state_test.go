@@ -0,0 +1,93 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"thde.io/fairgate/fairgatetest"
+)
+
+func TestClient_Snapshot(t *testing.T) {
+	retryAt := time.Now().Truncate(time.Second)
+	var requests atomic.Int64
+	client := newScopedTestClient(t,
+		fairgatetest.Claims().WithOID("test-org").WithScopes(ScopeReadContacts),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1)%5 == 0 {
+				// The retry-after has already passed, so retries don't wait.
+				w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(retryAt.Unix(), 10))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			w.Header().Set("Deprecation", "true")
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+		}),
+		WithWarningHandler(func(string) {}),
+	)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for i := range 20 {
+				if _, err := client.Contact(context.Background(), i); err != nil {
+					t.Errorf("Contact() error = %v", err)
+					return
+				}
+			}
+		})
+	}
+
+	done := make(chan struct{})
+	monitored := make(chan struct{})
+	go func() {
+		defer close(monitored)
+
+		var last ClientSnapshot
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			snapshot := client.Snapshot()
+			if snapshot.Stats.RateLimited < last.Stats.RateLimited ||
+				snapshot.RateLimit.RetryAfter.Before(last.RateLimit.RetryAfter) {
+				t.Errorf("Snapshot() = %+v went back from %+v", snapshot, last)
+			}
+			if !slices.Equal(snapshot.Token.Scopes, []string{ScopeReadContacts}) {
+				t.Errorf(
+					"Snapshot().Token.Scopes = %v, want [%s]",
+					snapshot.Token.Scopes,
+					ScopeReadContacts,
+				)
+			}
+			// Snapshots are copies, so changing them doesn't race with the client.
+			snapshot.Token.Scopes[0] = ""
+			clear(snapshot.Deprecations)
+			last = snapshot
+		}
+	}()
+
+	wg.Wait()
+	close(done)
+	<-monitored
+
+	snapshot := client.Snapshot()
+	if snapshot.Stats.RateLimited == 0 || !snapshot.RateLimit.RetryAfter.Equal(retryAt) {
+		t.Errorf("Snapshot() = %+v, want rate limited until %v", snapshot, retryAt)
+	}
+	if len(snapshot.Deprecations) != 1 {
+		t.Errorf("Snapshot().Deprecations = %v, want 1 endpoint", snapshot.Deprecations)
+	}
+	if snapshot.Token.OrganisationID != "test-org" || snapshot.Token.ExpiresAt.IsZero() {
+		t.Errorf("Snapshot().Token = %+v, want token of test-org", snapshot.Token)
+	}
+}
@@ -0,0 +1,203 @@
+package fairgate
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidStatistics is returned for invalid [StatisticsParams].
+var ErrInvalidStatistics = errors.New("invalid statistics parameters")
+
+// StatDimension is a dimension membership statistics are grouped by.
+type StatDimension string
+
+const (
+	// StatAgeGroup groups members by age group, see
+	// [StatisticsParams.AgeGroups].
+	StatAgeGroup StatDimension = "age_group"
+	// StatGender groups members by [Gender].
+	StatGender StatDimension = "gender"
+	// StatClub groups members by the organisation ID of their primary club,
+	// or of the client for contacts of a club.
+	StatClub StatDimension = "club"
+)
+
+// StatisticsParams represents the parameters of [Client.MembershipStatistics].
+type StatisticsParams struct {
+	// Year is the year of the statistics. Members are counted if they joined
+	// until the end of the year, and their age is the one reached in the year.
+	// Defaults to the current year.
+	Year int
+	// GroupBy are the dimensions to group the members by, in order. Without
+	// dimensions, only the total is counted.
+	GroupBy []StatDimension
+	// AgeGroups are the age groups of [StatAgeGroup], evaluated on 31 December
+	// of Year. Defaults to the age reached in Year, e.g. "17".
+	AgeGroups []AgeGroupRule
+}
+
+// MembershipStatistics are the member counts of a year grouped by dimensions.
+type MembershipStatistics struct {
+	// Year is the year of the statistics.
+	Year int
+	// GroupBy are the dimensions of the bucket labels.
+	GroupBy []StatDimension
+	// Buckets are the counts of the label combinations present, sorted by
+	// labels, numerically for ages.
+	Buckets []StatBucket
+	// Total is the number of members.
+	Total int
+}
+
+// StatBucket is the member count of a combination of labels.
+type StatBucket struct {
+	// Labels are the values of the dimensions of [MembershipStatistics.GroupBy],
+	// in order. A label is empty if the value is unknown, e.g. the gender of
+	// a company.
+	Labels []string
+	// Count is the number of members with the labels.
+	Count int
+}
+
+// Count returns the member count of the bucket with labels, or 0 if there is
+// none.
+func (s *MembershipStatistics) Count(labels ...string) int {
+	for _, bucket := range s.Buckets {
+		if slices.Equal(bucket.Labels, labels) {
+			return bucket.Count
+		}
+	}
+
+	return 0
+}
+
+// MembershipStatistics counts the members of a year grouped by the dimensions
+// of params, e.g. for the yearly statistics of a federation. As the FSA has no
+// statistics endpoint, all contacts are fetched and grouped locally; use
+// [WithProgress] to report the progress and [WithContactsFilter] to restrict
+// the contacts. Contacts without membership are not counted. If
+// [WithContactFields] restricts the sections, the base fields, membership and
+// club assignments are still requested.
+func (c *Client) MembershipStatistics(
+	ctx context.Context,
+	params StatisticsParams,
+	opts ...IterOption,
+) (*MembershipStatistics, error) {
+	for _, dim := range params.GroupBy {
+		switch dim {
+		case StatAgeGroup, StatGender, StatClub:
+		default:
+			return nil, fmt.Errorf("%w: unknown dimension %q", ErrInvalidStatistics, dim)
+		}
+	}
+	if params.Year == 0 {
+		params.Year = c.clock.serverNow().In(APILocation).Year()
+	}
+	endOfYear := time.Date(params.Year, time.December, 31, 0, 0, 0, 0, APILocation)
+
+	opts = append(
+		opts[:len(opts):len(opts)],
+		requireContactFields(FieldBasefields, FieldMembership, FieldAssignments),
+	)
+
+	stats := &MembershipStatistics{Year: params.Year, GroupBy: params.GroupBy}
+	buckets := map[string]*StatBucket{}
+	for contact, err := range c.ContactsIter(ctx, opts...) {
+		if err != nil {
+			return nil, err
+		}
+		if !memberOn(contact, endOfYear) {
+			continue
+		}
+
+		labels := make([]string, len(params.GroupBy))
+		for i, dim := range params.GroupBy {
+			labels[i] = c.statLabel(contact, dim, params.AgeGroups, endOfYear)
+		}
+		// The unit separator doesn't occur in labels.
+		key := strings.Join(labels, "\x1f")
+		if buckets[key] == nil {
+			buckets[key] = &StatBucket{Labels: labels}
+		}
+		buckets[key].Count++
+		stats.Total++
+	}
+
+	for _, bucket := range buckets {
+		stats.Buckets = append(stats.Buckets, *bucket)
+	}
+	slices.SortFunc(stats.Buckets, func(a, b StatBucket) int {
+		return slices.CompareFunc(a.Labels, b.Labels, compareLabels)
+	})
+
+	return stats, nil
+}
+
+// compareLabels compares the labels a and b, numerically if both are integers,
+// so ages are sorted as numbers.
+func compareLabels(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		return cmp.Compare(x, y)
+	}
+
+	return strings.Compare(a, b)
+}
+
+// memberOn reports whether the contact has a membership of the club or the
+// federation that started on or before the date on.
+func memberOn(contact Contact, on time.Time) bool {
+	var joined Time
+	switch {
+	case contact.Membership != nil && contact.Membership.Membership != "":
+		joined = contact.Membership.FirstJoiningDate
+	case contact.FederationData != nil && contact.FederationData.FederationMembership != "":
+		joined = contact.FederationData.FederationFirstJoiningDate
+	default:
+		return false
+	}
+
+	return joined.IsZero() || !dateAfter(joined.Time, on)
+}
+
+// statLabel returns the label of the contact in the dimension dim on the date
+// on.
+func (c *Client) statLabel(
+	contact Contact,
+	dim StatDimension,
+	ageGroups []AgeGroupRule,
+	on time.Time,
+) string {
+	switch dim {
+	case StatAgeGroup:
+		if len(ageGroups) > 0 {
+			label, _ := contact.AgeGroup(ageGroups, on)
+			return label
+		}
+		birthdate := contact.Basefields.Birthdate
+		if birthdate.IsZero() || dateAfter(birthdate.Time, on) {
+			return ""
+		}
+		return strconv.Itoa(on.Year() - birthdate.Year())
+	case StatGender:
+		return string(contact.Basefields.Gender)
+	case StatClub:
+		if assignments := contact.ClubAssignments; assignments != nil &&
+			assignments.Primary != nil {
+			return assignments.Primary.OrganizationID
+		}
+		if contact.FederationData != nil {
+			return ""
+		}
+		return c.oid
+	}
+
+	return ""
+}
@@ -0,0 +1,164 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// statContact returns a contact born in the year with the gender and the
+// membership of the primary club, if any.
+func statContact(year int, gender Gender, club string) Contact {
+	contact := Contact{
+		Basefields: ContactBasefields{
+			Gender:    gender,
+			Birthdate: Time{time.Date(year, time.June, 1, 0, 0, 0, 0, APILocation)},
+		},
+		FederationData: &Federation{FederationMembership: "active"},
+	}
+	if club != "" {
+		contact.ClubAssignments = &ClubAssignments{
+			Primary: &ClubAssignment{OrganizationID: club},
+		}
+	}
+
+	return contact
+}
+
+func TestClient_MembershipStatistics(t *testing.T) {
+	joined2025 := statContact(2000, GenderMale, "club-a")
+	joined2025.FederationData.FederationFirstJoiningDate = Time{
+		time.Date(2025, time.March, 1, 0, 0, 0, 0, APILocation),
+	}
+	contacts := []Contact{
+		statContact(2010, GenderFemale, "club-a"),
+		statContact(2010, GenderFemale, "club-a"),
+		statContact(2010, GenderMale, "club-b"),
+		statContact(1980, GenderFemale, "club-b"),
+		statContact(2015, "", ""),
+		joined2025,
+		{Basefields: ContactBasefields{Gender: GenderMale}},
+	}
+	rules := []AgeGroupRule{
+		{Label: "U18", MaxAge: 17, ByBirthYear: true},
+		{Label: "adults", MinAge: 18, ByBirthYear: true},
+	}
+
+	tests := []struct {
+		name      string
+		params    StatisticsParams
+		want      []StatBucket
+		wantTotal int
+	}{
+		{
+			name:      "total",
+			params:    StatisticsParams{Year: 2024},
+			want:      []StatBucket{{Labels: []string{}, Count: 5}},
+			wantTotal: 5,
+		},
+		{
+			name:   "ages",
+			params: StatisticsParams{Year: 2024, GroupBy: []StatDimension{StatAgeGroup}},
+			want: []StatBucket{
+				{Labels: []string{"9"}, Count: 1},
+				{Labels: []string{"14"}, Count: 3},
+				{Labels: []string{"44"}, Count: 1},
+			},
+			wantTotal: 5,
+		},
+		{
+			name: "club, gender and age group",
+			params: StatisticsParams{
+				Year:      2025,
+				GroupBy:   []StatDimension{StatClub, StatGender, StatAgeGroup},
+				AgeGroups: rules,
+			},
+			want: []StatBucket{
+				{Labels: []string{"", "", "U18"}, Count: 1},
+				{Labels: []string{"club-a", "female", "U18"}, Count: 2},
+				{Labels: []string{"club-a", "male", "adults"}, Count: 1},
+				{Labels: []string{"club-b", "female", "adults"}, Count: 1},
+				{Labels: []string{"club-b", "male", "U18"}, Count: 1},
+			},
+			wantTotal: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, contactsPageHandler(contacts...))
+
+			got, err := client.MembershipStatistics(context.Background(), tt.params)
+			if err != nil {
+				t.Fatalf("MembershipStatistics() error = %v", err)
+			}
+
+			if got.Total != tt.wantTotal || got.Year != tt.params.Year {
+				t.Errorf("statistics of %d with total %d, want %d with %d",
+					got.Year, got.Total, tt.params.Year, tt.wantTotal)
+			}
+			if len(got.Buckets) != len(tt.want) {
+				t.Fatalf("Buckets = %+v, want %+v", got.Buckets, tt.want)
+			}
+			for i, bucket := range tt.want {
+				if got.Count(bucket.Labels...) != bucket.Count ||
+					!slices.Equal(got.Buckets[i].Labels, bucket.Labels) {
+					t.Errorf("Buckets = %+v, want %+v", got.Buckets, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestClient_MembershipStatistics_ClubDefaults(t *testing.T) {
+	member := Contact{
+		Basefields: ContactBasefields{Gender: GenderFemale},
+		Membership: &Membership{Membership: "active"},
+	}
+	client, _ := newTestClient(t, contactsPageHandler(member, member, Contact{}))
+
+	got, err := client.MembershipStatistics(context.Background(), StatisticsParams{
+		GroupBy: []StatDimension{StatClub, StatAgeGroup},
+	})
+	if err != nil {
+		t.Fatalf("MembershipStatistics() error = %v", err)
+	}
+	year := time.Now().In(APILocation).Year()
+	if got.Year != year || got.Count(client.OrganisationID(), "") != 2 {
+		t.Errorf("MembershipStatistics() = %+v, want 2 members of the club in %d", got, year)
+	}
+
+	_, err = client.MembershipStatistics(context.Background(), StatisticsParams{
+		GroupBy: []StatDimension{"canton"},
+	})
+	if !errors.Is(err, ErrInvalidStatistics) {
+		t.Errorf("MembershipStatistics() error = %v, want %v", err, ErrInvalidStatistics)
+	}
+}
+
+func TestClient_MembershipStatistics_ContactFields(t *testing.T) {
+	var fields string
+	handler := contactsPageHandler(statContact(2000, GenderFemale, ""))
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		handler.ServeHTTP(w, r)
+	}))
+
+	_, err := client.MembershipStatistics(
+		context.Background(),
+		StatisticsParams{Year: 2024},
+		WithContactFields(FieldMembership),
+	)
+	if err != nil {
+		t.Fatalf("MembershipStatistics() error = %v", err)
+	}
+
+	want := "membership,basefields,club_assignments"
+	if fields != want {
+		t.Errorf("fields = %q, want %q", fields, want)
+	}
+}
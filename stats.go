@@ -0,0 +1,60 @@
+package fairgate
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats holds counters about the requests made by a client.
+type Stats struct {
+	// RateLimitWait is the total time spent waiting due to rate limiting.
+	RateLimitWait time.Duration
+	// RateLimited is the number of responses with status 429 Too Many Requests.
+	RateLimited int64
+	// Retries is the number of requests the client retried internally because
+	// they were rate limited.
+	Retries int64
+	// SchemaChanges is the number of response schema changes detected, see
+	// [WithSchemaTracking].
+	SchemaChanges int64
+	// BudgetUsed is the number of requests counted in the current window of
+	// the request budget, see [WithRequestBudget].
+	BudgetUsed int64
+	// ConnectionsNew is the number of requests sent on a new connection.
+	ConnectionsNew int64
+	// ConnectionsReused is the number of requests sent on a reused connection.
+	ConnectionsReused int64
+	// TLSHandshakes is the number of TLS handshakes completed.
+	TLSHandshakes int64
+}
+
+// stats holds the counters of a client. It is safe for concurrent use.
+type stats struct {
+	rateLimitWait atomic.Int64
+	rateLimited   atomic.Int64
+	retries       atomic.Int64
+	schemaChanges atomic.Int64
+
+	connectionsNew    atomic.Int64
+	connectionsReused atomic.Int64
+	tlsHandshakes     atomic.Int64
+}
+
+// Stats returns a snapshot of the request counters of the client.
+func (c *Client) Stats() Stats {
+	s := Stats{
+		RateLimitWait: time.Duration(c.stats.rateLimitWait.Load()),
+		RateLimited:   c.stats.rateLimited.Load(),
+		Retries:       c.stats.retries.Load(),
+		SchemaChanges: c.stats.schemaChanges.Load(),
+
+		ConnectionsNew:    c.stats.connectionsNew.Load(),
+		ConnectionsReused: c.stats.connectionsReused.Load(),
+		TLSHandshakes:     c.stats.tlsHandshakes.Load(),
+	}
+	if c.budget != nil {
+		s.BudgetUsed = int64(c.budget.used())
+	}
+
+	return s
+}
@@ -0,0 +1,79 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Stats(t *testing.T) {
+	var calls atomic.Int64
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set(
+				"X-Ratelimit-Retry-After",
+				strconv.FormatInt(time.Now().Add(time.Second).Unix(), 10),
+			)
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}))
+
+	if got := client.Stats(); got != (Stats{}) {
+		t.Errorf("Stats() = %+v, want zero value before requests", got)
+	}
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+
+	stats := client.Stats()
+	if stats.RateLimited != 1 {
+		t.Errorf("RateLimited = %d, want 1", stats.RateLimited)
+	}
+	if stats.Retries != 1 {
+		t.Errorf("Retries = %d, want 1", stats.Retries)
+	}
+	if stats.RateLimitWait <= 0 {
+		t.Errorf("RateLimitWait = %v, want > 0", stats.RateLimitWait)
+	}
+}
+
+func TestClient_Stats_Concurrent(t *testing.T) {
+	var calls atomic.Int64
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Rate limit every other request, with a window that has already passed.
+		if calls.Add(1)%2 == 1 {
+			w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(time.Now().Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}))
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := client.Contact(context.Background(), 1); err != nil {
+				t.Errorf("Contact() error = %v", err)
+			}
+			_ = client.Stats()
+		})
+	}
+	wg.Wait()
+
+	stats := client.Stats()
+	if stats.RateLimited != stats.Retries {
+		t.Errorf("RateLimited = %d, Retries = %d, want equal", stats.RateLimited, stats.Retries)
+	}
+	if got := calls.Load(); got != 10+stats.RateLimited {
+		t.Errorf("server saw %d requests, want %d", got, 10+stats.RateLimited)
+	}
+}
@@ -0,0 +1,166 @@
+package fairgate
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIncompleteIteration is returned by iterators using [VerifyCompleteness]
+// if the number of items differs from the total reported by the server.
+var ErrIncompleteIteration = errors.New("incomplete iteration")
+
+// IterationSummary describes how an iteration ended. It is populated using
+// [WithSummary] once the iteration stops.
+type IterationSummary struct {
+	// ItemsYielded is the number of items passed to the consumer.
+	ItemsYielded int
+	// PagesFetched is the number of pages fetched successfully.
+	PagesFetched int
+	// ServerTotalRecords is the total number of items reported by the server
+	// with the last page, or zero if unknown.
+	ServerTotalRecords int
+	// Completed reports whether all pages were iterated. It is false if the
+	// consumer stopped early, the iteration was limited, or it failed.
+	Completed bool
+	// Limited reports whether the iteration stopped cleanly due to [MaxItems]
+	// or [MaxPages] before all pages were iterated.
+	Limited bool
+	// Err is the error the iteration failed with, if any.
+	Err error
+}
+
+// WithSummary populates summary when the iteration stops, so silent
+// truncation can be detected by comparing ItemsYielded with
+// ServerTotalRecords.
+func WithSummary(summary *IterationSummary) IterOption {
+	return func(c *iterConfig) {
+		c.summary = summary
+	}
+}
+
+// IncompleteIterationError is yielded by iterators using [VerifyCompleteness].
+// It matches [ErrIncompleteIteration] using [errors.Is].
+type IncompleteIterationError struct {
+	// Expected is the total number of items reported by the server with the
+	// first page.
+	Expected int
+	// Yielded is the number of items yielded. It exceeds Expected if items
+	// were yielded more than once, e.g. as items were added concurrently.
+	Yielded int
+}
+
+// Error implements the error interface.
+func (e *IncompleteIterationError) Error() string {
+	return fmt.Sprintf(
+		"%s: yielded %d items, server reported %d",
+		ErrIncompleteIteration,
+		e.Yielded,
+		e.Expected,
+	)
+}
+
+// Is reports whether target is [ErrIncompleteIteration].
+func (e *IncompleteIterationError) Is(target error) bool {
+	return target == ErrIncompleteIteration
+}
+
+// VerifyCompleteness compares the number of items yielded with the total
+// number of items reported by the server with the first page. If they differ
+// once all pages were iterated, an [IncompleteIterationError] is yielded last.
+// The check is skipped if the consumer stops early or the server reports no
+// total.
+func VerifyCompleteness(verify bool) IterOption {
+	return func(c *iterConfig) {
+		c.verifyCompleteness = verify
+	}
+}
+
+// PageError is the error fetching a page failed with.
+type PageError struct {
+	// PageNo is the number of the page, starting at 1.
+	PageNo int
+	// Err is the error fetching the page failed with.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *PageError) Error() string {
+	return fmt.Sprintf("page %d: %v", e.PageNo, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// OrganisationError is the error fetching the data of an organisation failed
+// with, for iterators walking organisations such as
+// [Client.ExecutiveBoardIter].
+type OrganisationError struct {
+	// OrganizationID is the oid of the organisation.
+	OrganizationID string
+	// Err is the error fetching the data of the organisation failed with.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *OrganisationError) Error() string {
+	return fmt.Sprintf("organisation %s: %v", e.OrganizationID, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *OrganisationError) Unwrap() error {
+	return e.Err
+}
+
+// IterationError is yielded last by iterators using [ContinueOnError] if any
+// page or organisation failed. Its Unwrap method returns the [*PageError] of
+// each failed page and the [*OrganisationError] of each failed organisation.
+type IterationError struct {
+	// Pages are the errors of the failed pages, in order.
+	Pages []*PageError
+	// Organisations are the errors of the failed organisations, in order.
+	Organisations []*OrganisationError
+	// Aborted reports whether the iteration stopped because too many pages
+	// failed.
+	Aborted bool
+}
+
+// Error implements the error interface.
+func (e *IterationError) Error() string {
+	msg := fmt.Sprintf("%d pages failed", len(e.Pages))
+	if len(e.Organisations) > 0 {
+		msg = fmt.Sprintf("%d organisations failed", len(e.Organisations))
+		if len(e.Pages) > 0 {
+			msg = fmt.Sprintf(
+				"%d pages and %d organisations failed",
+				len(e.Pages),
+				len(e.Organisations),
+			)
+		}
+	}
+	if e.Aborted {
+		msg += ", iteration aborted"
+	}
+	for _, page := range e.Pages {
+		msg += "\n" + page.Error()
+	}
+	for _, organisation := range e.Organisations {
+		msg += "\n" + organisation.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns the errors of the failed pages and organisations.
+func (e *IterationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Pages)+len(e.Organisations))
+	for _, page := range e.Pages {
+		errs = append(errs, page)
+	}
+	for _, organisation := range e.Organisations {
+		errs = append(errs, organisation)
+	}
+
+	return errs
+}
@@ -0,0 +1,259 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestIterate_WithSummary(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	tests := []struct {
+		name    string
+		failAt  int
+		breakAt int
+		want    IterationSummary
+	}{
+		{
+			name: "completed",
+			want: IterationSummary{
+				ItemsYielded:       6,
+				PagesFetched:       3,
+				ServerTotalRecords: 6,
+				Completed:          true,
+			},
+		},
+		{
+			name:    "consumer break",
+			breakAt: 3,
+			want: IterationSummary{
+				ItemsYielded:       3,
+				PagesFetched:       2,
+				ServerTotalRecords: 6,
+			},
+		},
+		{
+			name:   "error",
+			failAt: 3,
+			want: IterationSummary{
+				ItemsYielded:       4,
+				PagesFetched:       2,
+				ServerTotalRecords: 6,
+				Err:                errFetch,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := func(ctx context.Context, params PageParams) ([]int, Pagination, error) {
+				if params.PageNo == tt.failAt {
+					return nil, Pagination{}, errFetch
+				}
+				items := []int{params.PageNo*2 - 1, params.PageNo * 2}
+				return items, Pagination{TotalRecords: 6, TotalPages: 3}, nil
+			}
+
+			var summary IterationSummary
+			for item, err := range iterate(context.Background(), fetcher, WithSummary(&summary)) {
+				if err != nil {
+					break
+				}
+				if item == tt.breakAt {
+					break
+				}
+			}
+
+			if summary != tt.want {
+				t.Errorf("summary = %+v, want %+v", summary, tt.want)
+			}
+		})
+	}
+}
+
+func TestIterate_VerifyCompleteness(t *testing.T) {
+	tests := []struct {
+		name    string
+		pages   map[int][]int
+		total   int
+		breakAt int
+		wantErr *IncompleteIterationError
+	}{
+		{
+			name:  "exact",
+			pages: map[int][]int{1: {1, 2}, 2: {3, 4}, 3: {5}},
+			total: 5,
+		},
+		{
+			name:    "undercount",
+			pages:   map[int][]int{1: {1, 2}, 2: {3}},
+			total:   5,
+			wantErr: &IncompleteIterationError{Expected: 5, Yielded: 3},
+		},
+		{
+			name:    "overcount",
+			pages:   map[int][]int{1: {1, 2}, 2: {2, 3}, 3: {4, 5}},
+			total:   5,
+			wantErr: &IncompleteIterationError{Expected: 5, Yielded: 6},
+		},
+		{
+			name:    "consumer break",
+			pages:   map[int][]int{1: {1, 2}, 2: {3}},
+			total:   5,
+			breakAt: 2,
+		},
+		{
+			name:  "unknown total",
+			pages: map[int][]int{1: {1, 2}, 2: {3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := func(ctx context.Context, params PageParams) ([]int, Pagination, error) {
+				return tt.pages[params.PageNo], Pagination{TotalRecords: FlexInt(tt.total)}, nil
+			}
+
+			var items []int
+			var errs []error
+			for item, err := range iterate(context.Background(), fetcher, VerifyCompleteness(true)) {
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				items = append(items, item)
+				if len(items) == tt.breakAt {
+					break
+				}
+			}
+
+			if tt.wantErr == nil {
+				if len(errs) != 0 {
+					t.Errorf("errors = %v, want none", errs)
+				}
+				return
+			}
+
+			var incomplete *IncompleteIterationError
+			if len(errs) != 1 || !errors.As(errs[0], &incomplete) {
+				t.Fatalf("errors = %v, want IncompleteIterationError", errs)
+			}
+			if !errors.Is(errs[0], ErrIncompleteIteration) {
+				t.Errorf("error %v does not match ErrIncompleteIteration", errs[0])
+			}
+			if *incomplete != *tt.wantErr {
+				t.Errorf("error = %+v, want %+v", incomplete, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIterate_ContinueOnError(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	tests := []struct {
+		name        string
+		failPages   []int
+		opts        []IterOption
+		wantItems   []int
+		wantPages   []int
+		wantAborted bool
+		wantFetches int
+	}{
+		{
+			name:        "fail fast by default",
+			failPages:   []int{2},
+			wantItems:   []int{1, 2},
+			wantFetches: 2,
+		},
+		{
+			name:        "skip failed pages",
+			failPages:   []int{2, 4},
+			opts:        []IterOption{ContinueOnError(2)},
+			wantItems:   []int{1, 2, 5, 6, 9, 10},
+			wantPages:   []int{2, 4},
+			wantFetches: 5,
+		},
+		{
+			name:        "skip first page",
+			failPages:   []int{1},
+			opts:        []IterOption{ContinueOnError(1)},
+			wantItems:   []int{3, 4, 5, 6, 7, 8, 9, 10},
+			wantPages:   []int{1},
+			wantFetches: 5,
+		},
+		{
+			name:        "skip last page",
+			failPages:   []int{5},
+			opts:        []IterOption{ContinueOnError(1)},
+			wantItems:   []int{1, 2, 3, 4, 5, 6, 7, 8},
+			wantPages:   []int{5},
+			wantFetches: 5,
+		},
+		{
+			name:        "too many errors",
+			failPages:   []int{2, 3, 4},
+			opts:        []IterOption{ContinueOnError(2)},
+			wantItems:   []int{1, 2},
+			wantPages:   []int{2, 3, 4},
+			wantAborted: true,
+			wantFetches: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches := 0
+			fetcher := func(ctx context.Context, params PageParams) ([]int, Pagination, error) {
+				fetches++
+				if slices.Contains(tt.failPages, params.PageNo) {
+					return nil, Pagination{}, errFetch
+				}
+				items := []int{params.PageNo*2 - 1, params.PageNo * 2}
+				return items, Pagination{TotalRecords: 10, TotalPages: 5}, nil
+			}
+
+			var items []int
+			var errs []error
+			opts := append([]IterOption{WithPageLimit(2)}, tt.opts...)
+			for item, err := range iterate(context.Background(), fetcher, opts...) {
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				items = append(items, item)
+			}
+
+			if !slices.Equal(items, tt.wantItems) {
+				t.Errorf("items = %v, want %v", items, tt.wantItems)
+			}
+			if fetches != tt.wantFetches {
+				t.Errorf("fetches = %d, want %d", fetches, tt.wantFetches)
+			}
+			if len(errs) != 1 || !errors.Is(errs[0], errFetch) {
+				t.Fatalf("errors = %v, want a single fetch error", errs)
+			}
+
+			var iterErr *IterationError
+			if !errors.As(errs[0], &iterErr) {
+				if tt.wantPages != nil {
+					t.Errorf("error = %v, want *IterationError", errs[0])
+				}
+				return
+			}
+			var pages []int
+			for _, page := range iterErr.Pages {
+				pages = append(pages, page.PageNo)
+			}
+			if !slices.Equal(pages, tt.wantPages) || iterErr.Aborted != tt.wantAborted {
+				t.Errorf("IterationError pages = %v, aborted = %t, want %v, %t",
+					pages, iterErr.Aborted, tt.wantPages, tt.wantAborted)
+			}
+			if len(iterErr.Unwrap()) != len(tt.wantPages) {
+				t.Errorf("Unwrap() = %v, want %d errors", iterErr.Unwrap(), len(tt.wantPages))
+			}
+		})
+	}
+}
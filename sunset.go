@@ -0,0 +1,130 @@
+package fairgate
+
+import (
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeprecationInfo describes the deprecation of an endpoint announced by the
+// Deprecation and Sunset response headers.
+type DeprecationInfo struct {
+	// Deprecated reports whether the endpoint sent a Deprecation header.
+	Deprecated bool
+	// Sunset is the earliest time the endpoint announced to become
+	// unavailable, or zero if it didn't send a Sunset header.
+	Sunset time.Time
+	// Link is the URL of the deprecation or sunset policy, if any.
+	Link string
+}
+
+// observeDeprecation records the deprecation headers of the response of
+// endpoint and reports whether the endpoint was seen deprecated for the first
+// time.
+func (s *clientState) observeDeprecation(
+	endpoint string,
+	header http.Header,
+) (DeprecationInfo, bool) {
+	deprecated := parseDeprecation(header.Get("Deprecation"))
+	sunset, _ := http.ParseTime(header.Get("Sunset"))
+	if !deprecated && sunset.IsZero() {
+		return DeprecationInfo{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, seen := s.deprecations[endpoint]
+	info.Deprecated = info.Deprecated || deprecated
+	if !sunset.IsZero() && (info.Sunset.IsZero() || sunset.Before(info.Sunset)) {
+		info.Sunset = sunset
+	}
+	if link := deprecationLink(header); link != "" {
+		info.Link = link
+	}
+	if s.deprecations == nil {
+		s.deprecations = map[string]DeprecationInfo{}
+	}
+	s.deprecations[endpoint] = info
+
+	return info, !seen
+}
+
+// parseDeprecation reports whether the Deprecation header value v marks an
+// endpoint as deprecated. It accepts the boolean form "true", an HTTP-date and
+// the "@<unix time>" date of RFC 9745.
+func parseDeprecation(v string) bool {
+	v = strings.TrimSpace(v)
+	switch {
+	case v == "":
+		return false
+	case strings.EqualFold(v, "true"):
+		return true
+	case strings.HasPrefix(v, "@"):
+		_, err := strconv.ParseInt(v[1:], 10, 64)
+		return err == nil
+	default:
+		_, err := http.ParseTime(v)
+		return err == nil
+	}
+}
+
+// deprecationLink returns the target of the Link header with the relation
+// type "deprecation" or "sunset".
+func deprecationLink(header http.Header) string {
+	for _, value := range header.Values("Link") {
+		for link := range strings.SplitSeq(value, ",") {
+			target, params, ok := strings.Cut(link, ";")
+			if !ok {
+				continue
+			}
+			for param := range strings.SplitSeq(params, ";") {
+				name, rel, _ := strings.Cut(strings.TrimSpace(param), "=")
+				rel = strings.Trim(rel, `"`)
+				if strings.EqualFold(name, "rel") && (rel == "deprecation" || rel == "sunset") {
+					return strings.Trim(strings.TrimSpace(target), "<>")
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// Deprecations returns the endpoints announced as deprecated by the
+// Deprecation or Sunset response headers, keyed by path with the organisation
+// ID replaced by "{oid}" and numeric IDs by "{id}". Token endpoints are
+// included. The warning handler is called the first time a deprecated endpoint
+// is used.
+func (c *Client) Deprecations() map[string]DeprecationInfo {
+	c.state.mu.RLock()
+	defer c.state.mu.RUnlock()
+
+	return maps.Clone(c.state.deprecations)
+}
+
+// observeSunset records the deprecation headers of resp and warns the first
+// time a deprecated endpoint is used.
+func (c *Client) observeSunset(resp *http.Response) error {
+	if resp.Request == nil {
+		return nil
+	}
+
+	endpoint := c.schemaEndpoint(resp.Request.URL.Path)
+	info, first := c.state.observeDeprecation(endpoint, resp.Header)
+	if !first {
+		return nil
+	}
+
+	message := "endpoint " + endpoint + " is deprecated"
+	if !info.Sunset.IsZero() {
+		message += ", sunset at " + info.Sunset.Format(time.RFC3339)
+	}
+	if info.Link != "" {
+		message += ", see " + info.Link
+	}
+
+	return c.warn("%s", message)
+}
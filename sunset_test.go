@@ -0,0 +1,103 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_Deprecations(t *testing.T) {
+	sunsets := []string{"Tue, 01 Jun 2027 00:00:00 GMT", "Mon, 01 Mar 2027 00:00:00 GMT"}
+	privateKey, publicKey := generateTestKeyPair(t)
+	var refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/auth/refresh/") {
+			writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+			return
+		}
+
+		n := refreshes.Add(1)
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Sunset", sunsets[(n-1)%2])
+		w.Header().Set("Link", `<https://example.com/v2-auth>; rel="deprecation"`)
+		// The token expires within a minute, so every request refreshes it.
+		writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+			Success: true,
+			Data: CreateTokenResponse{
+				Token:        createTestToken(t, privateKey, time.Now().Add(time.Minute)),
+				RefreshToken: "refresh",
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	var warnings []string
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+		WithWarningHandler(func(message string) { warnings = append(warnings, message) }),
+	)
+	err := client.auth.updateToken(CreateTokenResponse{
+		Token:        createTestToken(t, privateKey, time.Now().Add(time.Minute)),
+		RefreshToken: "refresh",
+	})
+	if err != nil {
+		t.Fatalf("failed to set up token: %v", err)
+	}
+
+	for range 3 {
+		if _, err := client.Contact(context.Background(), 1); err != nil {
+			t.Fatalf("Contact() error = %v", err)
+		}
+	}
+	if got := refreshes.Load(); got != 3 {
+		t.Fatalf("refreshes = %d, want 3", got)
+	}
+
+	deprecations := client.Deprecations()
+	if len(deprecations) != 1 {
+		t.Fatalf("Deprecations() = %v, want only the refresh endpoint", deprecations)
+	}
+	info, ok := deprecations["/fsa/v1.1/auth/refresh/{oid}/token"]
+	if !ok {
+		t.Fatalf("Deprecations() = %v, want the refresh endpoint", deprecations)
+	}
+	want := DeprecationInfo{
+		Deprecated: true,
+		Sunset:     time.Date(2027, 3, 1, 0, 0, 0, 0, time.UTC),
+		Link:       "https://example.com/v2-auth",
+	}
+	if info != want {
+		t.Errorf("DeprecationInfo = %+v, want %+v", info, want)
+	}
+
+	wantWarning := "endpoint /fsa/v1.1/auth/refresh/{oid}/token is deprecated, " +
+		"sunset at 2027-06-01T00:00:00Z, see https://example.com/v2-auth"
+	if len(warnings) != 1 || warnings[0] != wantWarning {
+		t.Errorf("warnings = %q, want [%q]", warnings, wantWarning)
+	}
+}
+
+func TestParseDeprecation(t *testing.T) {
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{"true", true},
+		{"Sun, 11 Nov 2018 23:59:59 GMT", true},
+		{"@1688169599", true},
+		{"", false},
+		{"false", false},
+		{"soon", false},
+	}
+
+	for _, tt := range tests {
+		if got := parseDeprecation(tt.value); got != tt.want {
+			t.Errorf("parseDeprecation(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package fairgate
+
+import (
+	"sync"
+	"time"
+)
+
+// SyncCursor computes the start of the next incremental sync from the
+// LastUpdate times observed during a sync, e.g. to pass it as from to
+// [Client.ContactsChangedBetween] next time. It is safe for concurrent use.
+//
+//	cursor := fairgate.NewSyncCursor(previous)
+//	for contact, err := range client.ContactsIter(ctx) {
+//		// ...
+//		cursor.Observe(contact.Basefields.LastUpdate)
+//	}
+//	persist(cursor.Next(time.Minute))
+type SyncCursor struct {
+	mu       sync.Mutex
+	previous time.Time
+	latest   time.Time
+	observed int
+	now      func() time.Time
+}
+
+// NewSyncCursor returns a cursor starting at previous, the value returned by
+// [SyncCursor.Next] for the previous sync. Use the zero time for the first
+// sync.
+func NewSyncCursor(previous time.Time) *SyncCursor {
+	return &SyncCursor{previous: previous, now: time.Now}
+}
+
+// Observe records the LastUpdate time of a synced item. Zero times are
+// ignored.
+func (s *SyncCursor) Observe(lastUpdate Time) {
+	if lastUpdate.IsZero() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.observed++
+	if lastUpdate.After(s.latest) {
+		s.latest = lastUpdate.Time
+	}
+}
+
+// Next returns the start of the next sync: the latest LastUpdate observed,
+// moved back by margin so items updated while syncing aren't missed. It
+// returns the previous cursor if no time was observed, and never a time
+// before it. LastUpdate times in the future, e.g. due to clock skew, are
+// clamped to now, so a skewed item doesn't skip later updates.
+func (s *SyncCursor) Next(margin time.Duration) time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.observed == 0 {
+		return s.previous
+	}
+
+	next := s.latest
+	if now := s.now(); next.After(now) {
+		next = now
+	}
+	next = next.Add(-max(margin, 0))
+	if next.Before(s.previous) {
+		return s.previous
+	}
+
+	return next
+}
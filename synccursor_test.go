@@ -0,0 +1,82 @@
+package fairgate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncCursor_Next(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	previous := now.Add(-time.Hour)
+
+	tests := []struct {
+		name     string
+		previous time.Time
+		observed []time.Time
+		margin   time.Duration
+		want     time.Time
+	}{
+		{name: "no observations", previous: previous, margin: time.Minute, want: previous},
+		{
+			name:     "only zero times",
+			previous: previous,
+			observed: []time.Time{{}},
+			margin:   time.Minute,
+			want:     previous,
+		},
+		{
+			name:     "latest minus margin",
+			previous: previous,
+			observed: []time.Time{now.Add(-30 * time.Minute), now.Add(-10 * time.Minute), {}},
+			margin:   time.Minute,
+			want:     now.Add(-11 * time.Minute),
+		},
+		{
+			name:     "first sync",
+			observed: []time.Time{now.Add(-10 * time.Minute)},
+			margin:   time.Minute,
+			want:     now.Add(-11 * time.Minute),
+		},
+		{
+			name:     "future clamped to now",
+			previous: previous,
+			observed: []time.Time{now.Add(-10 * time.Minute), now.Add(time.Hour)},
+			margin:   time.Minute,
+			want:     now.Add(-time.Minute),
+		},
+		{
+			name:     "margin not before previous",
+			previous: previous,
+			observed: []time.Time{previous.Add(time.Minute)},
+			margin:   5 * time.Minute,
+			want:     previous,
+		},
+		{
+			name:     "observed before previous",
+			previous: previous,
+			observed: []time.Time{previous.Add(-time.Minute)},
+			want:     previous,
+		},
+		{
+			name:     "negative margin",
+			previous: previous,
+			observed: []time.Time{now.Add(-10 * time.Minute)},
+			margin:   -time.Minute,
+			want:     now.Add(-10 * time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor := NewSyncCursor(tt.previous)
+			cursor.now = func() time.Time { return now }
+			for _, lastUpdate := range tt.observed {
+				cursor.Observe(Time{lastUpdate})
+			}
+
+			if got := cursor.Next(tt.margin); !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, want %v", tt.margin, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,19 +2,95 @@ package fairgate
 
 import (
 	"encoding/json"
+	"reflect"
 	"time"
+	// The API location must be available on systems without time zone database.
+	_ "time/tzdata"
 )
 
+// APILocation is the location of the Fairgate API. Times returned without
+// offset are interpreted in this location, unless configured differently using
+// [WithLocation].
+var APILocation = mustLoadLocation("Europe/Zurich")
+
+// zonelessLayouts are the layouts of times returned without offset.
+var zonelessLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// mustLoadLocation loads the location name or panics.
+func mustLoadLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
 // Time supports unmarshalling times returned by the Fairgate API.
 type Time struct {
 	time.Time
 }
 
 // UnmarshalJSON implements the [json.Unmarshaler] interface.
+// Times without offset are interpreted in [APILocation].
 func (m *Time) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" || string(data) == `""` {
 		return nil
 	}
 
-	return json.Unmarshal(data, &m.Time)
+	err := json.Unmarshal(data, &m.Time)
+	if err == nil {
+		return nil
+	}
+
+	var s string
+	if json.Unmarshal(data, &s) != nil {
+		return err
+	}
+	for _, layout := range zonelessLayouts {
+		if t, perr := time.ParseInLocation(layout, s, APILocation); perr == nil {
+			m.Time = t
+			return nil
+		}
+	}
+
+	return err
+}
+
+// zoneless reports whether the time was returned without offset.
+func (m Time) zoneless() bool {
+	return m.Location() == APILocation
+}
+
+// WithLocation sets the location used for times returned without offset,
+// e.g. [time.UTC] to keep the raw values. Defaults to [APILocation].
+func WithLocation(loc *time.Location) ClientOption {
+	return func(c *Client) {
+		c.loc = loc
+	}
+}
+
+// location returns the location used for times without offset.
+func (c *Client) location() *time.Location {
+	if c.loc == nil {
+		return APILocation
+	}
+	return c.loc
+}
+
+// relocateTimes interprets the zone-less times reachable from v in loc,
+// keeping their wall clock.
+func relocateTimes(v any, loc *time.Location) {
+	walk(reflect.ValueOf(v), func(t *Time) {
+		if !t.zoneless() {
+			return
+		}
+
+		year, month, day := t.Date()
+		hour, minute, sec := t.Clock()
+		t.Time = time.Date(year, month, day, hour, minute, sec, t.Nanosecond(), loc)
+	})
 }
@@ -1,7 +1,9 @@
 package fairgate
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 	"time"
 )
@@ -168,3 +170,109 @@ func TestTime_IsZero(t *testing.T) {
 		})
 	}
 }
+
+func TestTime_UnmarshalJSON_Zoneless(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{
+			name:  "winter time",
+			input: `"2024-03-30 00:00:00"`,
+			want:  time.Date(2024, 3, 29, 23, 0, 0, 0, time.UTC),
+		},
+		{
+			name:  "before DST transition",
+			input: `"2024-03-31T01:30:00"`,
+			want:  time.Date(2024, 3, 31, 0, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "after DST transition",
+			input: `"2024-03-31T03:30:00"`,
+			want:  time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:  "summer time midnight",
+			input: `"2024-04-01"`,
+			want:  time.Date(2024, 3, 31, 22, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Time
+			if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+				t.Fatalf("Time.UnmarshalJSON() error = %v", err)
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("Time.UnmarshalJSON() = %v, want %v", got.UTC(), tt.want)
+			}
+			if got.Location() != APILocation {
+				t.Errorf("Time.UnmarshalJSON() location = %v, want %v", got.Location(), APILocation)
+			}
+		})
+	}
+}
+
+func TestClient_WithLocation(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"success":true,"data":{
+			"basefields":{"last_update":"2024-03-31T10:00:00+02:00"},
+			"membership":{"first_joining_date":"2024-03-31 00:00:00"},
+			"club_assignments":{"secondary":[
+				{"membership":{"first_joining_date":"2024-03-31T03:30:00"}}
+			]}
+		}}`))
+	})
+
+	tests := []struct {
+		name          string
+		opts          []ClientOption
+		wantJoined    time.Time
+		wantSecondary time.Time
+	}{
+		{
+			name:          "default location",
+			wantJoined:    time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC),
+			wantSecondary: time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:          "UTC",
+			opts:          []ClientOption{WithLocation(time.UTC)},
+			wantJoined:    time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+			wantSecondary: time.Date(2024, 3, 31, 3, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, handler, tt.opts...)
+
+			resp, err := client.Contact(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("Contact() error = %v", err)
+			}
+			contact := resp.Data
+
+			if got := contact.Membership.FirstJoiningDate; !got.Equal(tt.wantJoined) {
+				t.Errorf("FirstJoiningDate = %v, want %v", got.UTC(), tt.wantJoined)
+			}
+			secondary := contact.ClubAssignments.Secondary[0].Membership.FirstJoiningDate
+			if !secondary.Equal(tt.wantSecondary) {
+				t.Errorf(
+					"secondary FirstJoiningDate = %v, want %v",
+					secondary.UTC(),
+					tt.wantSecondary,
+				)
+			}
+
+			// Times with offset are not affected.
+			wantUpdate := time.Date(2024, 3, 31, 8, 0, 0, 0, time.UTC)
+			if got := contact.Basefields.LastUpdate; !got.Equal(wantUpdate) {
+				t.Errorf("LastUpdate = %v, want %v", got.UTC(), wantUpdate)
+			}
+		})
+	}
+}
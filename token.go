@@ -2,9 +2,9 @@ package fairgate
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
@@ -20,18 +20,40 @@ type tokenStore struct {
 
 	token        string
 	refreshToken string
-	accessKey    string
+
+	// refreshExpiresAt is the expiry of a JWT refresh token, zero if unknown.
+	refreshExpiresAt time.Time
+	// refreshWarned reports whether the expiry of the refresh token was warned about.
+	refreshWarned bool
+
+	// tokenKeyVersion is the access key version the token was created with.
+	tokenKeyVersion uint64
+
+	// keyMU guards the access key separately, so it can be rotated while a
+	// token request holds the store lock.
+	keyMU      sync.Mutex
+	accessKey  string
+	keyVersion uint64
 
 	claim *jwtClaim
+	// environment holds the expected issuer and audience of tokens.
+	environment tokenEnvironment
 
 	keyFunc jwt.Keyfunc
 	parser  *jwt.Parser
+
+	// state receives the token information on updates, see
+	// [Client.TokenInfo].
+	state *clientState
 }
 
 // jwtClaim represents the custom claims in Fairgate JWT tokens.
 type jwtClaim struct {
-	FsaID  string `json:"fsa_id"`
-	UniqID string `json:"uniq_id"`
+	FsaID  string   `json:"fsa_id"`
+	UniqID string   `json:"uniq_id"`
+	Scopes []string `json:"scopes,omitempty"`
+	// OID is the organisation the token was issued for, if claimed.
+	OID string `json:"oid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -46,6 +68,14 @@ type CreateTokenResponse struct {
 
 // TokenCreate generates a JWT token using an access key.
 func (c *Client) TokenCreate(ctx context.Context, accessKey string) error {
+	_, version := c.auth.currentAccessKey()
+
+	return c.tokenCreate(ctx, accessKey, version)
+}
+
+// tokenCreate generates a JWT token and records the access key version it was
+// created with.
+func (c *Client) tokenCreate(ctx context.Context, accessKey string, version uint64) error {
 	c.auth.Lock()
 	defer c.auth.Unlock()
 
@@ -53,34 +83,60 @@ func (c *Client) TokenCreate(ctx context.Context, accessKey string) error {
 		return ErrNoAccessKey
 	}
 
-	req, err := c.newRequest(ctx,
-		http.MethodPost,
-		fmt.Sprintf("/fsa/v1.1/auth/create/%s/token", c.oid),
-		nil,
+	authResp, err := c.doAuth(
+		ctx,
+		OperationTokenCreate,
+		fmt.Sprintf("/fsa/v1.1/auth/create/%s/token", url.PathEscape(c.oid)),
 		CreateTokenRequest{AccessKey: accessKey},
 	)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	if err := c.auth.updateToken(authResp.Data); err != nil {
 		return err
 	}
-	if resp.Body != nil {
-		defer resp.Body.Close()
-	}
+	c.auth.tokenKeyVersion = version
 
-	var authResp Response[CreateTokenResponse]
-	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return err
+	return nil
+}
+
+// SetAccessKey replaces the access key used to lazily create tokens, e.g. after
+// rotating keys. Cached tokens created with the previous key are no longer
+// used, so the next request authenticates with the new key. A lazy token
+// creation failing while the key is rotated is retried with the new key.
+func (c *Client) SetAccessKey(accessKey string) {
+	c.auth.keyMU.Lock()
+	defer c.auth.keyMU.Unlock()
+
+	c.auth.accessKey = accessKey
+	c.auth.keyVersion++
+}
+
+// ensureToken makes sure a valid token is available, shared with other clients
+// if a [TokenCoordinator] is configured.
+func (c *Client) ensureToken(ctx context.Context) error {
+	if c.coordinator != nil && c.tokenExpiring() {
+		return c.ensureSharedToken(ctx)
 	}
 
-	if err := authResp.Error(); err != nil {
-		return err
+	return c.ensureLocalToken(ctx)
+}
+
+// ensureLocalToken makes sure a valid token is available without coordinating
+// with other clients. If the access key was rotated while the token was
+// refreshed, the refresh is retried once so it picks up the new key.
+func (c *Client) ensureLocalToken(ctx context.Context) error {
+	_, version := c.auth.currentAccessKey()
+
+	err := c.TokenRefresh(ctx)
+	if err != nil {
+		if _, current := c.auth.currentAccessKey(); current != version {
+			return c.TokenRefresh(ctx)
+		}
 	}
 
-	return c.auth.updateToken(authResp.Data)
+	return err
 }
 
 type RefreshTokenRequest struct {
@@ -89,15 +145,21 @@ type RefreshTokenRequest struct {
 
 // TokenRefresh refreshes the JWT token if necessary.
 func (c *Client) TokenRefresh(ctx context.Context) error {
+	accessKey, version := c.auth.currentAccessKey()
+
 	c.auth.Lock()
-	if c.auth.token == "" {
+	if c.auth.token == "" && accessKey == "" {
 		c.auth.Unlock()
-		return c.TokenCreate(ctx, c.auth.accessKey)
+		return ErrNoToken
+	}
+	if c.auth.token == "" || c.auth.tokenKeyVersion != version {
+		c.auth.Unlock()
+		return c.tokenCreate(ctx, accessKey, version)
 	}
 
 	defer c.auth.Unlock()
 
-	if !c.auth.shouldRefresh(time.Now()) {
+	if !c.auth.shouldRefresh(c.clock.serverNow()) {
 		return nil
 	}
 
@@ -105,41 +167,66 @@ func (c *Client) TokenRefresh(ctx context.Context) error {
 		return ErrNoRefreshToken
 	}
 
-	reqBody := RefreshTokenRequest{RefreshToken: c.auth.refreshToken}
-	path := fmt.Sprintf("/fsa/v1.1/auth/refresh/%s/token", c.oid)
-
-	req, err := c.newRequest(ctx, http.MethodPost, path, nil, reqBody)
+	authResp, err := c.doAuth(
+		ctx,
+		OperationTokenRefresh,
+		fmt.Sprintf("/fsa/v1.1/auth/refresh/%s/token", url.PathEscape(c.oid)),
+		RefreshTokenRequest{RefreshToken: c.auth.refreshToken},
+	)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-	if resp.Body != nil {
-		defer resp.Body.Close()
-	}
+	return c.auth.updateToken(authResp.Data)
+}
+
+// authOperationCtxKey marks requests to the auth endpoints with the name of
+// the operation reported to the tracer, see [Client.doAuth].
+type authOperationCtxKey struct{}
+
+// authOperation returns the operation of a request to an auth endpoint sent
+// with ctx, if any.
+func authOperation(ctx context.Context) (string, bool) {
+	operation, ok := ctx.Value(authOperationCtxKey{}).(string)
+	return operation, ok
+}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf(
-			"%s: %d, %w",
-			http.StatusText(resp.StatusCode),
-			resp.StatusCode,
-			ErrStatus,
-		)
+// doAuth sends a request with body to the auth endpoint path, using the same
+// pipeline as other requests, so rate limits are waited for and retried. The
+// request isn't authorized with the token, as that would refresh the token
+// recursively, see [Client.authorize]. Each attempt is reported to the
+// tracer as operation. The caller must hold the token store lock.
+func (c *Client) doAuth(
+	ctx context.Context,
+	operation, path string,
+	body any,
+) (*Response[CreateTokenResponse], error) {
+	ctx = context.WithValue(ctx, authOperationCtxKey{}, operation)
+	req, err := c.newEndpointRequest(ctx, http.MethodPost, path, nil, body)
+	if err != nil {
+		return nil, err
 	}
 
 	var authResp Response[CreateTokenResponse]
-	if err := json.NewDecoder(resp.Body).Decode(&authResp); err != nil {
-		return err
+	resp, err := c.doJSON(req, &authResp)
+	if err != nil {
+		// Failures reported with another status are classified by
+		// [Client.responseError].
+		if resp != nil && resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return nil, authError(authResp, err)
+		}
+		return nil, err
 	}
 
-	if err := authResp.Error(); err != nil {
-		return err
-	}
+	return &authResp, nil
+}
 
-	return c.auth.updateToken(authResp.Data)
+// currentAccessKey returns the access key and its version.
+func (ts *tokenStore) currentAccessKey() (string, uint64) {
+	ts.keyMU.Lock()
+	defer ts.keyMU.Unlock()
+
+	return ts.accessKey, ts.keyVersion
 }
 
 // shouldRefresh checks if token needs refreshing.
@@ -151,11 +238,17 @@ func (ts *tokenStore) shouldRefresh(now time.Time) bool {
 	if ts.token == "" {
 		return true
 	}
-	if ts.claim == nil {
+
+	return refreshDue(ts.claim, now)
+}
+
+// refreshDue reports whether a token with claim expires soon after now.
+func refreshDue(claim *jwtClaim, now time.Time) bool {
+	if claim == nil || claim.ExpiresAt == nil {
 		return true
 	}
 
-	return now.Add(2 * time.Minute).After(ts.claim.ExpiresAt.Time)
+	return now.Add(2 * time.Minute).After(claim.ExpiresAt.Time)
 }
 
 // updateToken validates and updates the token store.
@@ -167,7 +260,7 @@ func (ts *tokenStore) updateToken(resp CreateTokenResponse) error {
 
 	ts.claim = claim
 	ts.token = resp.Token
-	ts.refreshToken = resp.RefreshToken
+	ts.setRefreshToken(resp.RefreshToken)
 
 	return nil
 }
@@ -180,6 +273,9 @@ func (ts *tokenStore) validateToken(tokenString string) (*jwtClaim, error) {
 	}
 
 	if claim, ok := token.Claims.(*jwtClaim); ok {
+		if err := ts.environment.check(claim); err != nil {
+			return nil, err
+		}
 		return claim, nil
 	}
 
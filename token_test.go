@@ -3,49 +3,62 @@ package fairgate
 import (
 	"context"
 	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"thde.io/fairgate/fairgatetest"
 )
 
-// Helper function to generate a test key pair
+// generateTestKeyPair generates a key pair using [fairgatetest.GenerateKeyPair].
 func generateTestKeyPair(t *testing.T) (*ecdsa.PrivateKey, *ecdsa.PublicKey) {
 	t.Helper()
-	privateKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+
+	privateKey, publicKey, err := fairgatetest.GenerateKeyPair()
 	if err != nil {
 		t.Fatalf("failed to generate key: %v", err)
 	}
-	return privateKey, &privateKey.PublicKey
+	return privateKey, publicKey
 }
 
-// Helper function to create a test JWT token
+// createTestToken signs a token using [fairgatetest.SignToken].
 func createTestToken(t *testing.T, privateKey *ecdsa.PrivateKey, expiresAt time.Time) string {
 	t.Helper()
 
-	claims := jwtClaim{
-		FsaID:  "test-fsa-id",
-		UniqID: "test-uniq-id",
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-		},
+	tokenString, err := fairgatetest.SignToken(privateKey, fairgatetest.Claims(), expiresAt)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
 	}
 
-	token := jwt.NewWithClaims(signingMethod, claims)
-	tokenString, err := token.SignedString(privateKey)
+	return tokenString
+}
+
+func TestTokenStore_validateToken_FairgatetestClaims(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	client := New("test-org", publicKey)
+
+	claims := fairgatetest.Claims().WithFsaID("fsa-42").WithUniqID("uniq-42")
+	token, err := fairgatetest.SignToken(privateKey, claims, time.Now().Add(time.Hour))
 	if err != nil {
 		t.Fatalf("failed to sign token: %v", err)
 	}
 
-	return tokenString
+	got, err := client.auth.validateToken(token)
+	if err != nil {
+		t.Fatalf("validateToken() error = %v", err)
+	}
+	if got.FsaID != "fsa-42" || got.UniqID != "uniq-42" {
+		t.Errorf("claim = %+v, want fsa-42 and uniq-42", got)
+	}
 }
 
 func TestTokenStore_shouldRefresh(t *testing.T) {
@@ -335,6 +348,7 @@ func TestClient_TokenCreate(t *testing.T) {
 		serverResponse Response[CreateTokenResponse]
 		statusCode     int
 		wantErr        bool
+		wantErrIs      error
 		errContains    string
 	}{
 		{
@@ -355,6 +369,7 @@ func TestClient_TokenCreate(t *testing.T) {
 			name:      "empty access key",
 			accessKey: "",
 			wantErr:   true,
+			wantErrIs: ErrNoAccessKey,
 		},
 		{
 			name:      "API error response",
@@ -366,6 +381,7 @@ func TestClient_TokenCreate(t *testing.T) {
 			},
 			statusCode:  http.StatusOK,
 			wantErr:     true,
+			wantErrIs:   ErrAuthFailed,
 			errContains: "invalid access key",
 		},
 		{
@@ -424,6 +440,9 @@ func TestClient_TokenCreate(t *testing.T) {
 					t.Errorf("TokenCreate() error = %v, should contain %q", err, tt.errContains)
 				}
 			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("TokenCreate() error = %v, want %v", err, tt.wantErrIs)
+			}
 
 			if !tt.wantErr {
 				if client.auth.token == "" {
@@ -449,6 +468,7 @@ func TestClient_TokenRefresh(t *testing.T) {
 		expiresAt        time.Time
 		serverResponse   Response[CreateTokenResponse]
 		wantErr          bool
+		wantErrIs        error
 		errContains      string
 		expectCreate     bool // Should call TokenCreate instead
 	}{
@@ -474,12 +494,18 @@ func TestClient_TokenRefresh(t *testing.T) {
 			initialAccessKey: "access-key-123",
 			expectCreate:     true,
 		},
+		{
+			name:      "no token and no access key",
+			wantErr:   true,
+			wantErrIs: ErrNoToken,
+		},
 		{
 			name:           "no refresh token",
 			initialToken:   createTestToken(t, privateKey, time.Now().Add(1*time.Minute)),
 			initialRefresh: "",
 			expiresAt:      time.Now().Add(1 * time.Minute),
 			wantErr:        true,
+			wantErrIs:      ErrNoRefreshToken,
 			errContains:    "no refresh token",
 		},
 		{
@@ -493,6 +519,7 @@ func TestClient_TokenRefresh(t *testing.T) {
 				Message: "refresh token expired",
 			},
 			wantErr:     true,
+			wantErrIs:   ErrAuthFailed,
 			errContains: "refresh token expired",
 		},
 	}
@@ -571,6 +598,9 @@ func TestClient_TokenRefresh(t *testing.T) {
 					t.Errorf("TokenRefresh() error = %v, should contain %q", err, tt.errContains)
 				}
 			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("TokenRefresh() error = %v, want %v", err, tt.wantErrIs)
+			}
 
 			if tt.expectCreate && !createCalled {
 				t.Error("expected TokenCreate to be called")
@@ -684,3 +714,281 @@ func mustParseURL(urlStr string) *url.URL {
 	}
 	return u
 }
+
+// rotatingAuthServer is a fake server accepting a changing set of access keys.
+// Contact requests are only authorized with tokens created from a valid key.
+type rotatingAuthServer struct {
+	t          *testing.T
+	privateKey *ecdsa.PrivateKey
+
+	mu        sync.Mutex
+	validKeys map[string]bool
+	tokenKeys map[string]string
+
+	// createHook is called before a create request is answered.
+	createHook func(accessKey string)
+}
+
+func (s *rotatingAuthServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.Contains(r.URL.Path, "/auth/create/") {
+		var req CreateTokenRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		if s.createHook != nil {
+			s.createHook(req.AccessKey)
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.validKeys[req.AccessKey] {
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Code:    401,
+				Message: "access key revoked",
+			})
+			return
+		}
+
+		token := createTestToken(s.t, s.privateKey, time.Now().Add(1*time.Hour))
+		s.tokenKeys[token] = req.AccessKey
+		writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+			Code:    200,
+			Success: true,
+			Data:    CreateTokenResponse{Token: token, RefreshToken: "refresh-" + req.AccessKey},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !s.validKeys[s.tokenKeys[token]] {
+		writeJSON(w, http.StatusUnauthorized, Response[any]{Code: 401, Message: "unauthorized"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, Response[Contact]{Code: 200, Success: true})
+}
+
+// rotate revokes all access keys and accepts only the given key.
+func (s *rotatingAuthServer) rotate(accessKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.validKeys = map[string]bool{accessKey: true}
+}
+
+func TestClient_SetAccessKey_Concurrent(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+
+	authServer := &rotatingAuthServer{
+		t:          t,
+		privateKey: privateKey,
+		validKeys:  map[string]bool{"old-key": true},
+		tokenKeys:  map[string]string{},
+	}
+	server := httptest.NewServer(authServer)
+	defer server.Close()
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+		WithAccessKey("old-key"),
+	)
+
+	var rotated atomic.Bool
+	var failedAfterRotation atomic.Int64
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for range 8 {
+		wg.Go(func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+
+				afterRotation := rotated.Load()
+				_, err := client.Contact(context.Background(), 1)
+				if err != nil && afterRotation {
+					t.Errorf("request after rotation failed: %v", err)
+					failedAfterRotation.Add(1)
+				}
+			}
+		})
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	authServer.rotate("new-key")
+	client.SetAccessKey("new-key")
+	rotated.Store(true)
+	time.Sleep(100 * time.Millisecond)
+
+	close(stop)
+	wg.Wait()
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Errorf("Contact() after rotation error = %v", err)
+	}
+	if n := failedAfterRotation.Load(); n > 0 {
+		t.Errorf("%d requests failed after rotation", n)
+	}
+}
+
+func TestClient_SetAccessKey_InFlightCreate(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+
+	inFlight := make(chan struct{})
+	release := make(chan struct{})
+	authServer := &rotatingAuthServer{
+		t:          t,
+		privateKey: privateKey,
+		validKeys:  map[string]bool{"old-key": true},
+		tokenKeys:  map[string]string{},
+		createHook: func(accessKey string) {
+			if accessKey == "old-key" {
+				close(inFlight)
+				<-release
+			}
+		},
+	}
+	server := httptest.NewServer(authServer)
+	defer server.Close()
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+		WithAccessKey("old-key"),
+	)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Contact(context.Background(), 1)
+		done <- err
+	}()
+
+	<-inFlight
+	authServer.rotate("new-key")
+	client.SetAccessKey("new-key")
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Errorf("Contact() error = %v, want retry with new key", err)
+	}
+}
+
+// newRefreshTestClient returns a client with a token due for refresh, talking
+// to a test server backed by handler.
+func newRefreshTestClient(
+	t *testing.T,
+	privateKey *ecdsa.PrivateKey,
+	publicKey *ecdsa.PublicKey,
+	handler http.Handler,
+) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := New("test-org", publicKey,
+		WithHTTPClient(server.Client()),
+		WithBaseURL(mustParseURL(server.URL)),
+	)
+	// The token expires within a minute, so it is due for refresh.
+	err := client.auth.updateToken(CreateTokenResponse{
+		Token:        createTestToken(t, privateKey, time.Now().Add(time.Minute)),
+		RefreshToken: "refresh",
+	})
+	if err != nil {
+		t.Fatalf("failed to set up token: %v", err)
+	}
+
+	return client
+}
+
+func TestClient_TokenRefresh_NotAuthorized(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	var refreshes atomic.Int32
+	client := newRefreshTestClient(t, privateKey, publicKey, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			refreshes.Add(1)
+			if auth := r.Header.Get("Authorization"); auth != "" {
+				t.Errorf("refresh sent with Authorization %q, want none", auth)
+			}
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Success: true,
+				Data: CreateTokenResponse{
+					Token:        createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+					RefreshToken: "refresh",
+				},
+			})
+		},
+	))
+
+	// Authorizing the refresh would refresh the token again while the token
+	// store is locked, which never returns.
+	done := make(chan error, 1)
+	go func() { done <- client.TokenRefresh(context.Background()) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("TokenRefresh() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("TokenRefresh() didn't return, refresh was authorized recursively")
+	}
+
+	if got := refreshes.Load(); got != 1 {
+		t.Errorf("server saw %d refreshes, want 1", got)
+	}
+}
+
+func TestClient_TokenRefresh_RateLimited(t *testing.T) {
+	privateKey, publicKey := generateTestKeyPair(t)
+	retryAt := time.Now().Add(time.Second).Truncate(time.Second)
+	var refreshes atomic.Int32
+	client := newRefreshTestClient(t, privateKey, publicKey, http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if !strings.Contains(r.URL.Path, "/auth/refresh/") {
+				writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+				return
+			}
+
+			if refreshes.Add(1) == 1 {
+				w.Header().Set("X-Ratelimit-Retry-After", strconv.FormatInt(retryAt.Unix(), 10))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			if time.Now().Before(retryAt) {
+				t.Errorf(
+					"refresh retried at %v, before the rate limit passed at %v",
+					time.Now(),
+					retryAt,
+				)
+			}
+			writeJSON(w, http.StatusOK, Response[CreateTokenResponse]{
+				Success: true,
+				Data: CreateTokenResponse{
+					Token:        createTestToken(t, privateKey, time.Now().Add(time.Hour)),
+					RefreshToken: "refresh",
+				},
+			})
+		},
+	))
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+	if got := refreshes.Load(); got != 2 {
+		t.Errorf("server saw %d refreshes, want 2", got)
+	}
+	if got := client.RateLimit().RetryAfter; !got.Equal(retryAt) {
+		t.Errorf("RateLimit().RetryAfter = %v, want %v", got, retryAt)
+	}
+	if got := client.Stats().RateLimited; got != 1 {
+		t.Errorf("Stats().RateLimited = %d, want 1", got)
+	}
+}
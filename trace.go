@@ -0,0 +1,108 @@
+package fairgate
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Operation names reported to a [Tracer].
+const (
+	// OperationRequest is a single attempt of an API request.
+	OperationRequest = "request"
+	// OperationTokenCreate creates a token using the access key.
+	OperationTokenCreate = "token.create"
+	// OperationTokenRefresh refreshes the token using the refresh token.
+	OperationTokenRefresh = "token.refresh"
+	// OperationRateLimitWait waits for the rate limit window to pass.
+	OperationRateLimitWait = "rate_limit.wait"
+)
+
+// Operation describes an operation of the client reported to a [Tracer].
+type Operation struct {
+	// Name is one of the Operation constants, e.g. [OperationRequest].
+	Name string
+	// Method is the HTTP method of the request, if any.
+	Method string
+	// Path is the URL path of the request, if any. It contains the
+	// organisation ID.
+	Path string
+	// OrganisationID is the organisation ID of the client.
+	OrganisationID string
+	// Attempt is the attempt number of a request, starting at 1.
+	Attempt int
+}
+
+// Span is an operation in progress.
+type Span interface {
+	// RateLimited is called when the server rate limited the operation.
+	RateLimited(retryAfter time.Time)
+	// End is called when the operation finished with the HTTP status code,
+	// or zero if no response was received, and the resulting error.
+	End(statusCode int, err error)
+}
+
+// Tracer observes the operations of a client, including token refreshes and
+// rate limit waits. See the fairgateotel package for an OpenTelemetry tracer.
+type Tracer interface {
+	// Start is called when an operation starts. The returned context is used
+	// for the operation, so nested operations can be related.
+	Start(ctx context.Context, op Operation) (context.Context, Span)
+}
+
+// WithTracer reports the operations of the client to tracer.
+func WithTracer(tracer Tracer) ClientOption {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// startSpan starts an operation using the tracer of the client, if any. It
+// returns a [CallbackPanicError] if the tracer panics.
+func (c *Client) startSpan(
+	ctx context.Context,
+	op Operation,
+) (context.Context, guardedSpan, error) {
+	if c.tracer == nil {
+		return ctx, guardedSpan{noopSpan{}}, nil
+	}
+
+	op.OrganisationID = c.oid
+	spanCtx, span := ctx, Span(nil)
+	if err := callSafely("Tracer.Start", func() {
+		spanCtx, span = c.tracer.Start(ctx, op)
+	}); err != nil {
+		return ctx, guardedSpan{noopSpan{}}, err
+	}
+	if span == nil {
+		span = noopSpan{}
+	}
+
+	return spanCtx, guardedSpan{span}, nil
+}
+
+// guardedSpan reports to a span, returning its panics as [CallbackPanicError].
+type guardedSpan struct {
+	span Span
+}
+
+func (s guardedSpan) rateLimited(retryAfter time.Time) error {
+	return callSafely("Span.RateLimited", func() { s.span.RateLimited(retryAfter) })
+}
+
+// end ends the span and returns err, joined with a panic of the span.
+func (s guardedSpan) end(statusCode int, err error) error {
+	panicErr := callSafely("Span.End", func() { s.span.End(statusCode, err) })
+	if panicErr == nil {
+		return err
+	}
+
+	return errors.Join(err, panicErr)
+}
+
+// noopSpan is used without tracer.
+type noopSpan struct{}
+
+func (noopSpan) RateLimited(time.Time) {}
+
+func (noopSpan) End(int, error) {}
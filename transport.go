@@ -0,0 +1,197 @@
+package fairgate
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Connection settings of the default transport, see [newTransport].
+const (
+	// defaultMaxIdleConns is the number of idle connections kept to the API
+	// unless limited by [WithMaxConcurrentRequests].
+	defaultMaxIdleConns = 16
+	// defaultIdleConnTimeout is the time idle connections are kept open.
+	defaultIdleConnTimeout = 90 * time.Second
+)
+
+// newTransport returns the transport of the default HTTP client, tuned for a
+// single API host: unlike [http.DefaultTransport], which only keeps 2 idle
+// connections per host, it keeps maxIdleConns connections open for reuse.
+func newTransport(maxIdleConns int) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          maxIdleConns,
+		MaxIdleConnsPerHost:   maxIdleConns,
+		IdleConnTimeout:       defaultIdleConnTimeout,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// transportSettings holds the settings of the default HTTP client changed by
+// options such as [WithTimeout].
+type transportSettings struct {
+	// options are the names of the options used.
+	options []string
+	// errs are the invalid settings, which aren't applied.
+	errs  []error
+	apply []func(*http.Client, *http.Transport)
+}
+
+// set records the setting of option, applied by apply unless err is set.
+func (s *transportSettings) set(
+	option string,
+	err error,
+	apply func(*http.Client, *http.Transport),
+) {
+	s.options = append(s.options, option)
+	if err != nil {
+		s.errs = append(s.errs, err)
+		return
+	}
+	s.apply = append(s.apply, apply)
+}
+
+// validate returns the problems of the settings, including options conflicting
+// with [WithHTTPClient].
+func (s *transportSettings) validate(customHTTPClient bool) []error {
+	errs := s.errs
+	if customHTTPClient && len(s.options) > 0 {
+		errs = append(errs, fmt.Errorf(
+			"%s conflict with the HTTP client set by WithHTTPClient",
+			strings.Join(s.options, ", "),
+		))
+	}
+
+	return errs
+}
+
+// WithTimeout sets the time limit of requests of the default HTTP client,
+// including reading the response body. Zero means no limit. Defaults to 30
+// seconds.
+//
+// Like all options changing the default HTTP client, it is ignored if a client
+// is set by [WithHTTPClient], and [NewWithOptions] reports the conflict.
+func WithTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		var err error
+		if d < 0 {
+			err = fmt.Errorf("timeout %v is negative", d)
+		}
+		c.transportSettings.set("WithTimeout", err, func(hc *http.Client, _ *http.Transport) {
+			hc.Timeout = d
+		})
+	}
+}
+
+// WithTLSHandshakeTimeout sets the time limit of TLS handshakes of the default
+// HTTP client. Zero means no limit. Defaults to 10 seconds.
+func WithTLSHandshakeTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		var err error
+		if d < 0 {
+			err = fmt.Errorf("TLS handshake timeout %v is negative", d)
+		}
+		c.transportSettings.set(
+			"WithTLSHandshakeTimeout",
+			err,
+			func(_ *http.Client, t *http.Transport) {
+				t.TLSHandshakeTimeout = d
+			},
+		)
+	}
+}
+
+// WithProxy sets the proxy function of the default HTTP client, see
+// [http.Transport.Proxy]. A nil function disables proxies. Defaults to
+// [http.ProxyFromEnvironment].
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOption {
+	return func(c *Client) {
+		c.transportSettings.set("WithProxy", nil, func(_ *http.Client, t *http.Transport) {
+			t.Proxy = proxy
+		})
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of the default HTTP client, e.g. to
+// trust a private root CA. A copy of config is used. Certificates pinned by
+// [WithPinnedCertificates] are verified in addition.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.transportSettings.set("WithTLSConfig", nil, func(_ *http.Client, t *http.Transport) {
+			t.TLSClientConfig = config.Clone()
+		})
+	}
+}
+
+// WithDialContext sets the function the default HTTP client opens connections
+// with, e.g. to use a custom resolver. A nil function uses [net.Dial].
+func WithDialContext(
+	dial func(ctx context.Context, network, addr string) (net.Conn, error),
+) ClientOption {
+	return func(c *Client) {
+		c.transportSettings.set("WithDialContext", nil, func(_ *http.Client, t *http.Transport) {
+			t.DialContext = dial
+		})
+	}
+}
+
+// WithHTTP2 sets whether the default HTTP client uses HTTP/2 if the server
+// supports it. Defaults to true.
+func WithHTTP2(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.transportSettings.set("WithHTTP2", nil, func(_ *http.Client, t *http.Transport) {
+			t.ForceAttemptHTTP2 = enabled
+			t.TLSNextProto = nil
+			if !enabled {
+				// A non-nil empty map disables HTTP/2.
+				t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+			}
+		})
+	}
+}
+
+// maxIdleConns returns the number of idle connections kept by the default
+// transport, matching the limit of concurrent requests if set.
+func (c *Client) maxIdleConns() int {
+	if c.slots != nil {
+		return cap(c.slots)
+	}
+
+	return defaultMaxIdleConns
+}
+
+// traceConnections returns req with a trace counting the connections used and
+// TLS handshakes in the stats of the client.
+func (c *Client) traceConnections(req *http.Request) *http.Request {
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				c.stats.connectionsReused.Add(1)
+			} else {
+				c.stats.connectionsNew.Add(1)
+			}
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err == nil {
+				c.stats.tlsHandshakes.Add(1)
+			}
+		},
+	}
+
+	return req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+}
@@ -0,0 +1,252 @@
+package fairgate
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"thde.io/fairgate/fairgatetest"
+)
+
+func TestClient_DefaultTransport(t *testing.T) {
+	custom := &http.Client{}
+
+	tests := []struct {
+		name          string
+		opts          []ClientOption
+		wantMaxIdle   int
+		wantUntouched bool
+	}{
+		{name: "default", wantMaxIdle: defaultMaxIdleConns},
+		{
+			name:        "concurrency limit",
+			opts:        []ClientOption{WithMaxConcurrentRequests(32)},
+			wantMaxIdle: 32,
+		},
+		{name: "custom client", opts: []ClientOption{WithHTTPClient(custom)}, wantUntouched: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New("org", nil, tt.opts...)
+
+			if tt.wantUntouched {
+				if client.httpClient.Transport != nil || custom.Transport != nil {
+					t.Errorf(
+						"transport of custom client = %v, want nil",
+						client.httpClient.Transport,
+					)
+				}
+				return
+			}
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("transport = %T, want *http.Transport", client.httpClient.Transport)
+			}
+			if transport.MaxIdleConnsPerHost != tt.wantMaxIdle {
+				t.Errorf("MaxIdleConnsPerHost = %d, want %d",
+					transport.MaxIdleConnsPerHost, tt.wantMaxIdle)
+			}
+			if !transport.ForceAttemptHTTP2 || transport.IdleConnTimeout == 0 {
+				t.Errorf("transport = %+v, want HTTP/2 and idle timeout", transport)
+			}
+		})
+	}
+}
+
+func TestClient_ConnectionReuse(t *testing.T) {
+	server, err := fairgatetest.NewServer("access-key", fairgatetest.Contact{ID: 1})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	defer server.Close()
+
+	client := New("org", server.PublicKey,
+		WithBaseURLString(server.URL),
+		WithAccessKey("access-key"),
+	)
+
+	for range 5 {
+		if _, err := client.Contact(context.Background(), 1); err != nil {
+			t.Fatalf("Contact() error = %v", err)
+		}
+	}
+
+	// The token request opens the connection reused by all contact requests.
+	stats := client.Stats()
+	if stats.ConnectionsNew != 1 || stats.ConnectionsReused != 5 {
+		t.Errorf("connections new %d, reused %d, want 1 and 5",
+			stats.ConnectionsNew, stats.ConnectionsReused)
+	}
+	if stats.TLSHandshakes != 0 {
+		t.Errorf("TLSHandshakes = %d, want 0 without TLS", stats.TLSHandshakes)
+	}
+}
+
+func TestClient_ConnectionStats_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Response[Contact]{Success: true})
+	}))
+	t.Cleanup(server.Close)
+	client := newTestClientForServer(t, server)
+
+	for range 3 {
+		if _, err := client.Contact(context.Background(), 1); err != nil {
+			t.Fatalf("Contact() error = %v", err)
+		}
+	}
+
+	stats := client.Stats()
+	if stats.TLSHandshakes != 1 || stats.ConnectionsNew != 1 || stats.ConnectionsReused != 2 {
+		t.Errorf("handshakes %d, connections new %d, reused %d, want 1, 1 and 2",
+			stats.TLSHandshakes, stats.ConnectionsNew, stats.ConnectionsReused)
+	}
+}
+
+func TestClient_TransportOptions(t *testing.T) {
+	proxyURL := mustParseURL("http://proxy.example.com:3128")
+	tlsConfig := &tls.Config{ServerName: "fsa.example.com"}
+
+	tests := []struct {
+		name  string
+		opts  []ClientOption
+		check func(*testing.T, *http.Client, *http.Transport)
+	}{
+		{
+			name: "defaults",
+			check: func(t *testing.T, hc *http.Client, tr *http.Transport) {
+				if hc.Timeout != 30*time.Second || tr.Proxy == nil || !tr.ForceAttemptHTTP2 {
+					t.Errorf("client %+v with transport %+v, want defaults", hc, tr)
+				}
+			},
+		},
+		{
+			name: "timeouts",
+			opts: []ClientOption{WithTimeout(time.Minute), WithTLSHandshakeTimeout(time.Second)},
+			check: func(t *testing.T, hc *http.Client, tr *http.Transport) {
+				if hc.Timeout != time.Minute || tr.TLSHandshakeTimeout != time.Second {
+					t.Errorf("timeouts %v and %v, want 1m and 1s",
+						hc.Timeout, tr.TLSHandshakeTimeout)
+				}
+			},
+		},
+		{
+			name: "proxy and TLS",
+			opts: []ClientOption{WithProxy(http.ProxyURL(proxyURL)), WithTLSConfig(tlsConfig)},
+			check: func(t *testing.T, _ *http.Client, tr *http.Transport) {
+				got, err := tr.Proxy(&http.Request{URL: mustParseURL(ProductionURL)})
+				if err != nil || got.String() != proxyURL.String() {
+					t.Errorf("proxy = %v, %v, want %v", got, err, proxyURL)
+				}
+				if tr.TLSClientConfig == tlsConfig ||
+					tr.TLSClientConfig.ServerName != "fsa.example.com" {
+					t.Errorf("TLS config = %+v, want copy of %+v", tr.TLSClientConfig, tlsConfig)
+				}
+			},
+		},
+		{
+			name: "no proxy and HTTP/1.1",
+			opts: []ClientOption{WithProxy(nil), WithHTTP2(false)},
+			check: func(t *testing.T, _ *http.Client, tr *http.Transport) {
+				if tr.Proxy != nil || tr.ForceAttemptHTTP2 || tr.TLSNextProto == nil {
+					t.Errorf("transport %+v, want no proxy and HTTP/2 disabled", tr)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New("org", nil, tt.opts...)
+
+			transport, ok := client.httpClient.Transport.(*http.Transport)
+			if !ok {
+				t.Fatalf("transport = %T, want *http.Transport", client.httpClient.Transport)
+			}
+			tt.check(t, client.httpClient, transport)
+		})
+	}
+}
+
+func TestClient_WithDialContext(t *testing.T) {
+	server, err := fairgatetest.NewServer("access-key", fairgatetest.Contact{ID: 1})
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+	defer server.Close()
+
+	// All connections are dialed to the server, whatever the base URL.
+	var dialed []string
+	var dialer net.Dialer
+	client := New("org", server.PublicKey,
+		WithBaseURLString("http://fsa.example.com"),
+		WithAllowInsecureHTTP(),
+		WithAccessKey("access-key"),
+		WithDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+			dialed = append(dialed, addr)
+			return dialer.DialContext(ctx, network, server.Listener.Addr().String())
+		}),
+	)
+
+	if _, err := client.Contact(context.Background(), 1); err != nil {
+		t.Fatalf("Contact() error = %v", err)
+	}
+	if !slices.Equal(dialed, []string{"fsa.example.com:80"}) {
+		t.Errorf("dialed %v, want fsa.example.com:80", dialed)
+	}
+}
+
+func TestClient_TransportOptions_WithHTTPClient(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+	custom := &http.Client{Timeout: time.Second}
+
+	// The explicit client wins.
+	client := New("org", publicKey, WithTimeout(time.Minute), WithHTTPClient(custom))
+	if client.httpClient.Timeout != time.Second || client.httpClient.Transport != nil {
+		t.Errorf("client = %+v, want custom client", client.httpClient)
+	}
+
+	tests := []struct {
+		name    string
+		opts    []OptionE
+		wantErr string
+	}{
+		{
+			name: "conflict",
+			opts: []OptionE{
+				Option(WithHTTPClient(custom)),
+				Option(WithTimeout(time.Minute)),
+				Option(WithProxy(nil)),
+			},
+			wantErr: "WithTimeout, WithProxy conflict with the HTTP client set by WithHTTPClient",
+		},
+		{
+			name:    "negative timeout",
+			opts:    []OptionE{Option(WithTimeout(-time.Second))},
+			wantErr: "timeout -1s is negative",
+		},
+		{
+			name:    "negative TLS handshake timeout",
+			opts:    []OptionE{Option(WithTLSHandshakeTimeout(-time.Second))},
+			wantErr: "TLS handshake timeout -1s is negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewWithOptions("org", publicKey, tt.opts...)
+			if !errors.Is(err, ErrInvalidOption) || !strings.Contains(fmt.Sprint(err), tt.wantErr) {
+				t.Errorf("NewWithOptions() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
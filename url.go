@@ -0,0 +1,75 @@
+package fairgate
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// The endpoint URLs are parsed at init, so a malformed constant fails at
+// program start rather than at the first request.
+var (
+	productionURL = mustParseBaseURL(ProductionURL)
+	testURL       = mustParseBaseURL(TestURL)
+)
+
+// mustParseBaseURL parses a base URL or panics.
+func mustParseBaseURL(s string) *url.URL {
+	u, err := parseBaseURL(s)
+	if err != nil {
+		panic(fmt.Sprintf("fairgate: %v", err))
+	}
+
+	return u
+}
+
+// parseBaseURL parses and validates a base URL.
+func parseBaseURL(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidBaseURL, s, err)
+	}
+	if err := checkBaseURL(u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// checkBaseURL validates a parsed base URL.
+func checkBaseURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("%w %q: scheme must be http or https", ErrInvalidBaseURL, u)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w %q: missing host", ErrInvalidBaseURL, u)
+	}
+
+	return nil
+}
+
+// checkInsecureBaseURL returns an error wrapping [ErrInsecureBaseURL] if the
+// base URL uses plain HTTP to a host other than a loopback address and
+// [WithAllowInsecureHTTP] wasn't used.
+func (c *Client) checkInsecureBaseURL() error {
+	if c.allowInsecureHTTP || c.baseURL == nil || c.baseURL.Scheme != "http" {
+		return nil
+	}
+
+	host := c.baseURL.Hostname()
+	if ip := net.ParseIP(host); host == "localhost" || ip != nil && ip.IsLoopback() {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"%w %q: plain HTTP would send the token in cleartext, use https or WithAllowInsecureHTTP",
+		ErrInsecureBaseURL,
+		c.baseURL,
+	)
+}
+
+// cloneURL returns a copy of u.
+func cloneURL(u *url.URL) *url.URL {
+	clone := *u
+	return &clone
+}
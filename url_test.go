@@ -0,0 +1,152 @@
+package fairgate
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+)
+
+func TestWithBaseURLString(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr bool
+	}{
+		{name: "valid", baseURL: "https://fsa.example.com/"},
+		{name: "invalid", baseURL: "https://fsa example.com/%zz", wantErr: true},
+		{name: "missing scheme", baseURL: "fsa.example.com", wantErr: true},
+		{name: "unsupported scheme", baseURL: "ftp://fsa.example.com", wantErr: true},
+		{name: "missing host", baseURL: "https:///fsa", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, publicKey := generateTestKeyPair(t)
+			client := New("test-org", publicKey, WithBaseURLString(tt.baseURL))
+
+			req, err := client.newRequest(context.Background(), "GET", "/fsa/v2.0/test", nil, nil)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("newRequest() error = %v", err)
+				}
+				if req.URL.Host != "fsa.example.com" {
+					t.Errorf("request host = %q, want fsa.example.com", req.URL.Host)
+				}
+				return
+			}
+
+			if !errors.Is(err, ErrInvalidBaseURL) {
+				t.Errorf("newRequest() error = %v, want ErrInvalidBaseURL", err)
+			}
+
+			// Token creation fails the same way, before any network call.
+			if err := client.TokenCreate(context.Background(), "key"); !errors.Is(
+				err,
+				ErrInvalidBaseURL,
+			) {
+				t.Errorf("TokenCreate() error = %v, want ErrInvalidBaseURL", err)
+			}
+		})
+	}
+}
+
+func TestWithTest(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+	client := New("test-org", publicKey, WithTest())
+
+	if got := client.baseURL.String(); got != TestURL {
+		t.Errorf("baseURL = %q, want %q", got, TestURL)
+	}
+	if client.baseURL == testURL {
+		t.Error("client shares the package level test URL")
+	}
+}
+
+func TestClient_InsecureBaseURL(t *testing.T) {
+	_, publicKey := generateTestKeyPair(t)
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantErr bool
+	}{
+		{name: "https", opts: []ClientOption{WithBaseURLString("https://fsa.example.com/")}},
+		{
+			name:    "http to public host",
+			opts:    []ClientOption{WithBaseURLString("http://fsa.fairgate.ch/")},
+			wantErr: true,
+		},
+		{
+			name:    "http to public host by URL",
+			opts:    []ClientOption{WithBaseURL(mustParseURL("http://fsa.fairgate.ch/"))},
+			wantErr: true,
+		},
+		{
+			name: "http to loopback",
+			opts: []ClientOption{WithBaseURLString("http://127.0.0.1:8080/")},
+		},
+		{
+			name: "http to IPv6 loopback",
+			opts: []ClientOption{WithBaseURLString("http://[::1]:8080/")},
+		},
+		{
+			name: "http to localhost",
+			opts: []ClientOption{WithBaseURLString("http://localhost:8080/")},
+		},
+		{
+			name: "allowed",
+			opts: []ClientOption{
+				WithBaseURLString("http://fsa.example.com/"),
+				WithAllowInsecureHTTP(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := New("test-org", publicKey, tt.opts...)
+			_, err := client.newRequest(context.Background(), "GET", "/fsa/v2.0/test", nil, nil)
+			if got := errors.Is(err, ErrInsecureBaseURL); got != tt.wantErr {
+				t.Errorf("newRequest() error = %v, want ErrInsecureBaseURL: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("newRequest() error = %v", err)
+			}
+
+			var opts []OptionE
+			for _, opt := range tt.opts {
+				opts = append(opts, Option(opt))
+			}
+			_, err = NewWithOptions("test-org", publicKey, opts...)
+			if got := errors.Is(err, ErrInsecureBaseURL); got != tt.wantErr {
+				t.Errorf(
+					"NewWithOptions() error = %v, want ErrInsecureBaseURL: %v",
+					err,
+					tt.wantErr,
+				)
+			}
+		})
+	}
+
+	t.Run("from environment", func(t *testing.T) {
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		if err != nil {
+			t.Fatalf("failed to marshal public key: %v", err)
+		}
+		t.Setenv(EnvOrganisationID, "test-org")
+		t.Setenv(EnvAccessKey, "access-key")
+		t.Setenv(
+			EnvPublicKey,
+			string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+		)
+
+		client, err := NewFromEnv(WithBaseURLString("http://fsa.fairgate.ch/"))
+		if err != nil {
+			t.Fatalf("NewFromEnv() error = %v", err)
+		}
+		if _, err := client.Contact(context.Background(), 1); !errors.Is(err, ErrInsecureBaseURL) {
+			t.Errorf("Contact() error = %v, want ErrInsecureBaseURL", err)
+		}
+	})
+}
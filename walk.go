@@ -0,0 +1,31 @@
+package fairgate
+
+import (
+	"reflect"
+)
+
+// walk calls fn for each addressable T reachable from v.
+func walk[T any](v reflect.Value, fn func(*T)) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem(), fn)
+		}
+	case reflect.Struct:
+		if v.Type() == reflect.TypeFor[T]() {
+			if v.CanAddr() {
+				fn(v.Addr().Interface().(*T))
+			}
+			return
+		}
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				walk(v.Field(i), fn)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			walk(v.Index(i), fn)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package fairgate
+
+import "fmt"
+
+// WarningHandler is called when the client works around unexpected API
+// behavior, such as a page limit capped by the server.
+type WarningHandler func(message string)
+
+// WithWarningHandler sets a handler called with a description of each
+// unexpected API behavior the client works around.
+func WithWarningHandler(handler WarningHandler) ClientOption {
+	return func(c *Client) {
+		c.warningHandler = handler
+	}
+}
+
+// warn formats a warning and passes it to the warning handler, if any. It
+// returns a [CallbackPanicError] if the handler panics.
+func (c *Client) warn(format string, args ...any) error {
+	if c.warningHandler == nil {
+		return nil
+	}
+
+	message := fmt.Sprintf(format, args...)
+	return callSafely("WarningHandler", func() { c.warningHandler(message) })
+}
+
+// iterOptions returns opts preceded by the options derived from the client
+// configuration.
+func (c *Client) iterOptions(opts []IterOption) []IterOption {
+	return append([]IterOption{func(cfg *iterConfig) {
+		cfg.warn = c.warn
+	}}, opts...)
+}
@@ -0,0 +1,64 @@
+package fairgate
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestWithWarningHandler_CappedPageLimit(t *testing.T) {
+	const total, limit = 5, 2
+
+	var requests, warnings []string
+	client, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+
+		// The server caps the page limit but echoes the requested one.
+		pageNo, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		pageLimit, _ := strconv.Atoi(r.URL.Query().Get("pageLimit"))
+
+		var notes []Note
+		for i := (pageNo - 1) * limit; i < min(pageNo*limit, total); i++ {
+			notes = append(notes, Note{NoteID: i + 1})
+		}
+		writeJSON(w, http.StatusOK, Response[NotesList]{
+			Success: true,
+			Data: NotesList{
+				Pagination: Pagination{
+					TotalRecords: total,
+					TotalPages:   1,
+					PageNo:       FlexInt(pageNo),
+					PageLimit:    FlexInt(pageLimit),
+				},
+				Notes: notes,
+			},
+		})
+	}), WithWarningHandler(func(message string) {
+		warnings = append(warnings, message)
+	}))
+
+	var ids []int
+	for note, err := range client.ContactNotesIter(context.Background(), 42) {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, note.NoteID)
+	}
+
+	if len(ids) != total {
+		t.Errorf("got notes %v, want %d notes", ids, total)
+	}
+	want := []string{"pageLimit=100&pageNo=1", "pageLimit=2&pageNo=2", "pageLimit=2&pageNo=3"}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %q, want %q", requests, want)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("requests[%d] = %q, want %q", i, requests[i], want[i])
+		}
+	}
+	if len(warnings) != 1 {
+		t.Errorf("got warnings %q, want 1 warning", warnings)
+	}
+}